@@ -0,0 +1,277 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jattento/go-iso8583/pkg/bitmap"
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+// newFlagSet returns a flag set that reports usage to stderr.
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("iso8583 "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// parseFlags wraps flag errors as usage errors.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return usageError{err}
+	}
+	return nil
+}
+
+func decodeCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("decode", stderr)
+	templateName := fs.String("template", "", "name of the inbuilt template, for example: MasterCardISO87")
+	specFile := fs.String("spec", "", "path to a JSON spec file describing the message fields")
+	format := fs.String("in", formatHex, "input format: hex or bin")
+	header := fs.String("header", headerNone, "framing header to strip: none, binary2, ascii4 or auto")
+	asJSON := fs.Bool("json", false, "print fields as JSON, sensitive fields are masked")
+	asFixture := fs.Bool("fixture", false, "print fields as unmasked JSON document accepted by encode")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	t, err := messageType(*templateName, *specFile)
+	if err != nil {
+		return err
+	}
+
+	msg, err := readMessage(fs.Args(), stdin, *format, *header)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.New(t)
+	consumed, err := iso8583.Unmarshal(msg, v.Interface())
+	if err != nil {
+		return decodeError{err: err, consumed: consumed}
+	}
+
+	if consumed < len(msg) {
+		fmt.Fprintf(stderr, "warning: %v trailing bytes were not consumed\n", len(msg)-consumed)
+	}
+
+	switch {
+	case *asFixture:
+		b, err := iso8583.MarshalJSONMessage(v.Interface())
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(stdout, string(b))
+		return err
+	case *asJSON:
+		b, err := iso8583.DumpJSON(v.Interface())
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(stdout, string(b))
+		return err
+	default:
+		s, err := iso8583.Dump(v.Interface())
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(stdout, s)
+		return err
+	}
+}
+
+func encodeCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("encode", stderr)
+	templateName := fs.String("template", "", "name of the inbuilt template, for example: MasterCardISO87")
+	specFile := fs.String("spec", "", "path to a JSON spec file describing the message fields")
+	format := fs.String("out", formatHex, "output format: hex or bin")
+	header := fs.String("header", headerNone, "framing header to add: none, binary2 or ascii4")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	t, err := messageType(*templateName, *specFile)
+	if err != nil {
+		return err
+	}
+
+	document, err := readInput(fs.Args(), stdin)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.New(t)
+	if err := iso8583.UnmarshalJSONMessage(document, v.Interface()); err != nil {
+		return err
+	}
+
+	msg, err := iso8583.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+
+	msg, err = addHeader(msg, *header)
+	if err != nil {
+		return err
+	}
+
+	return writeFormat(stdout, msg, *format)
+}
+
+func bitmapCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("bitmap", stderr)
+	format := fs.String("in", formatHex, "input format: hex or bin")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	input, err := readInput(fs.Args(), stdin)
+	if err != nil {
+		return err
+	}
+
+	b, err := parseFormat(input, *format)
+	if err != nil {
+		return err
+	}
+
+	if len(b) == 0 {
+		return fmt.Errorf("empty bitmap")
+	}
+
+	bits := bitmap.FromBytes(b)
+
+	present := make([]int, 0)
+	for n, on := range bits {
+		if on {
+			present = append(present, n)
+		}
+	}
+	sort.Ints(present)
+
+	fields := make([]string, 0, len(present))
+	for _, n := range present {
+		fields = append(fields, strconv.Itoa(n))
+	}
+
+	_, err = fmt.Fprintf(stdout, "bitmap: %X\nfields: %s\n", b, strings.Join(fields, " "))
+	return err
+}
+
+func convertCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("convert", stderr)
+	from := fs.String("from", "ascii", "encoding of the input, any registered iso8583 codec")
+	to := fs.String("to", "ebcdic", "encoding of the output, any registered iso8583 codec")
+	inFormat := fs.String("in", formatBinary, "input format: hex or bin")
+	outFormat := fs.String("out", formatHex, "output format: hex or bin")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	decoder, exist := iso8583.LookupCodec(*from)
+	if !exist {
+		return fmt.Errorf("encoding '%s' does not exist", *from)
+	}
+
+	encoder, exist := iso8583.LookupCodec(*to)
+	if !exist {
+		return fmt.Errorf("encoding '%s' does not exist", *to)
+	}
+
+	input, err := readInput(fs.Args(), stdin)
+	if err != nil {
+		return err
+	}
+
+	b, err := parseFormat(input, *inFormat)
+	if err != nil {
+		return err
+	}
+
+	if b, err = decoder.Decode(b); err != nil {
+		return err
+	}
+
+	if b, err = encoder.Encode(b); err != nil {
+		return err
+	}
+
+	return writeFormat(stdout, b, *outFormat)
+}
+
+func diffCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("diff", stderr)
+	templateName := fs.String("template", "", "name of the inbuilt template, for example: MasterCardISO87")
+	specFile := fs.String("spec", "", "path to a JSON spec file describing the message fields")
+	format := fs.String("in", formatHex, "input format: hex or bin")
+	header := fs.String("header", headerNone, "framing header to strip: none, binary2, ascii4 or auto")
+	ignore := fs.String("ignore", "", "comma separated list of fields to ignore, for example: 7,11,37")
+	volatile := fs.Bool("volatile", false, "ignore volatile fields: "+strings.Join(iso8583.VolatileFields, ","))
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 2 {
+		return fmt.Errorf("two message files must be indicated, got %v", fs.NArg())
+	}
+
+	t, err := messageType(*templateName, *specFile)
+	if err != nil {
+		return err
+	}
+
+	a, err := readMessage(fs.Args()[:1], stdin, *format, *header)
+	if err != nil {
+		return err
+	}
+
+	b, err := readMessage(fs.Args()[1:], stdin, *format, *header)
+	if err != nil {
+		return err
+	}
+
+	ignored := make([]string, 0)
+	if *ignore != "" {
+		ignored = append(ignored, strings.Split(*ignore, ",")...)
+	}
+	if *volatile {
+		ignored = append(ignored, iso8583.VolatileFields...)
+	}
+
+	differences, err := iso8583.Diff(a, b, reflect.New(t).Interface(), ignored...)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range differences {
+		if _, err := fmt.Fprintln(stdout, d.String()); err != nil {
+			return err
+		}
+	}
+
+	if len(differences) > 0 {
+		return errMessagesDiffer
+	}
+
+	return nil
+}
+
+// readMessage reads a message in the indicated format and strips its framing header.
+func readMessage(args []string, stdin io.Reader, format, header string) ([]byte, error) {
+	input, err := readInput(args, stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := parseFormat(input, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return stripHeader(b, header)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	formatHex    = "hex"
+	formatBinary = "bin"
+
+	headerNone    = "none"
+	headerAuto    = "auto"
+	headerBinary2 = "binary2"
+	headerASCII4  = "ascii4"
+)
+
+// readInput reads the whole content of the file in args or stdin if there is no file.
+func readInput(args []string, stdin io.Reader) ([]byte, error) {
+	switch len(args) {
+	case 0:
+		return ioutil.ReadAll(stdin)
+	case 1:
+		return ioutil.ReadFile(args[0])
+	default:
+		return nil, fmt.Errorf("only one input file is allowed, got %v", len(args))
+	}
+}
+
+// parseFormat converts the input to bytes according to the format.
+// Hexadecimal input can contain white spaces and line breaks.
+func parseFormat(b []byte, format string) ([]byte, error) {
+	switch format {
+	case formatBinary:
+		return b, nil
+	case formatHex:
+		s := strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, string(b))
+
+		byt, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hexadecimal input: %w", err)
+		}
+
+		return byt, nil
+	default:
+		return nil, fmt.Errorf("unknown format '%s'", format)
+	}
+}
+
+// writeFormat writes b according to the format, hexadecimal output ends with a line break.
+func writeFormat(w io.Writer, b []byte, format string) error {
+	switch format {
+	case formatBinary:
+		_, err := w.Write(b)
+		return err
+	case formatHex:
+		_, err := fmt.Fprintf(w, "%X\n", b)
+		return err
+	default:
+		return fmt.Errorf("unknown format '%s'", format)
+	}
+}
+
+// stripHeader removes the framing header of the message.
+// - binary2: 2 bytes big endian length.
+// - ascii4: 4 ascii digits length.
+// - auto: any of the above if the indicated length matches the remaining bytes.
+// auto is opt-in as the first bytes of a message without header could match a length by chance.
+func stripHeader(b []byte, header string) ([]byte, error) {
+	switch header {
+	case headerNone:
+		return b, nil
+	case headerBinary2:
+		if len(b) < 2 || int(binary.BigEndian.Uint16(b)) != len(b)-2 {
+			return nil, fmt.Errorf("message does not start with a valid %s header", header)
+		}
+		return b[2:], nil
+	case headerASCII4:
+		if len(b) < 4 {
+			return nil, fmt.Errorf("message does not start with a valid %s header", header)
+		}
+		if n, err := strconv.Atoi(string(b[:4])); err != nil || n != len(b)-4 {
+			return nil, fmt.Errorf("message does not start with a valid %s header", header)
+		}
+		return b[4:], nil
+	case headerAuto:
+		for _, h := range []string{headerBinary2, headerASCII4} {
+			if stripped, err := stripHeader(b, h); err == nil {
+				return stripped, nil
+			}
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unknown header '%s'", header)
+	}
+}
+
+// addHeader prepends a framing header to the message, see stripHeader.
+func addHeader(b []byte, header string) ([]byte, error) {
+	switch header {
+	case headerNone:
+		return b, nil
+	case headerBinary2:
+		if len(b) > 0xFFFF {
+			return nil, fmt.Errorf("message is too long for %s header", header)
+		}
+		h := make([]byte, 2)
+		binary.BigEndian.PutUint16(h, uint16(len(b)))
+		return append(h, b...), nil
+	case headerASCII4:
+		if len(b) > 9999 {
+			return nil, fmt.Errorf("message is too long for %s header", header)
+		}
+		return append([]byte(fmt.Sprintf("%04d", len(b))), b...), nil
+	default:
+		return nil, fmt.Errorf("unknown header '%s'", header)
+	}
+}
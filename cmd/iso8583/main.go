@@ -0,0 +1,122 @@
+// Command iso8583 decodes, encodes and inspects ISO-8583 messages.
+//
+// Usage:
+// 	iso8583 <command> [flags] [file]
+//
+// The commands are:
+// 	decode   decode a message using a template or spec file and print its fields
+// 	encode   encode a JSON document (as generated by decode -fixture) into a message
+// 	bitmap   print which fields are indicated by a bitmap
+// 	convert  convert data between encodings, for example from ascii to ebcdic
+// 	diff     decode two messages and print its differences
+//
+// Input is read from file or from stdin if no file is given. On malformed input the command exits
+// with a non zero status and writes a JSON error to stderr. The diff command exits with status 3
+// if the messages differ.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+
+	// exitDifferent is returned by diff when the messages differ.
+	exitDifferent = 3
+)
+
+// command is a iso8583 subcommand, it returns the error to be reported.
+type command func(args []string, stdin io.Reader, stdout, stderr io.Writer) error
+
+var commands = map[string]command{
+	"decode":  decodeCommand,
+	"encode":  encodeCommand,
+	"bitmap":  bitmapCommand,
+	"convert": convertCommand,
+	"diff":    diffCommand,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) < 1 {
+		usage(stderr)
+		return exitUsage
+	}
+
+	cmd, exist := commands[args[0]]
+	if !exist {
+		usage(stderr)
+		return exitUsage
+	}
+
+	if err := cmd(args[1:], stdin, stdout, stderr); err != nil {
+		if _, isUsageErr := err.(usageError); isUsageErr {
+			return exitUsage
+		}
+
+		if err == errMessagesDiffer {
+			return exitDifferent
+		}
+
+		reportError(stderr, args[0], err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+func usage(w io.Writer) {
+	fmt.Fprint(w, "usage: iso8583 <command> [flags] [file]\n\n"+
+		"commands:\n"+
+		"  decode   decode a message using a template or spec file and print its fields\n"+
+		"  encode   encode a JSON document into a message\n"+
+		"  bitmap   print which fields are indicated by a bitmap\n"+
+		"  convert  convert data between encodings\n"+
+		"  diff     decode two messages and print its differences\n\n"+
+		"run 'iso8583 <command> -h' for the command flags.\n")
+}
+
+// errMessagesDiffer is returned by diff when differences are found, they are already printed.
+var errMessagesDiffer = errors.New("messages differ")
+
+// usageError is returned when flags are invalid, usage is already printed by the flag set.
+type usageError struct{ error }
+
+// cliError is the structured error written to stderr.
+type cliError struct {
+	Command string `json:"command"`
+	Error   string `json:"error"`
+
+	// Consumed is the amount of bytes of the message read before the error, only present on decoding.
+	Consumed *int `json:"consumed,omitempty"`
+}
+
+// decodeError is returned by decode when the message is malformed.
+type decodeError struct {
+	err      error
+	consumed int
+}
+
+func (e decodeError) Error() string { return e.err.Error() }
+
+func reportError(w io.Writer, cmd string, err error) {
+	output := cliError{Command: cmd, Error: err.Error()}
+
+	if decodeErr, ok := err.(decodeError); ok {
+		output.Consumed = &decodeErr.consumed
+	}
+
+	// cliError can not fail to be marshaled.
+	b, _ := json.Marshal(output)
+	fmt.Fprintln(w, string(b))
+}
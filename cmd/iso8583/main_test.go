@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testMessageHex = "F0F1F0F07000000000001200F1F6F5F4F0F0F0F0F0F0F0F0F0F0F0F0F1F1F0F0F0F0F0F0F0F0F0F0F0F0F0F0F0F1" +
+	"F0F00123456789ABCDEFF0F0F49F2601FF"
+
+const testMessageJSON = `{"mti":"0100","2":"5400000000000011","3":"000000","4":"000000000100",` +
+	`"52":"0123456789ABCDEF","55":"9F2601FF"}`
+
+func TestRun(t *testing.T) {
+	testList := []struct {
+		Name           string
+		Args           []string
+		Stdin          string
+		ExpectedStdout string
+		ExpectedStderr string
+		ExpectedCode   int
+	}{
+		{
+			Name:           "encode_template",
+			Args:           []string{"encode", "-template", "MasterCardISO87"},
+			Stdin:          testMessageJSON,
+			ExpectedStdout: testMessageHex + "\n",
+			ExpectedCode:   exitOK,
+		},
+		{
+			Name:           "encode_with_header",
+			Args:           []string{"encode", "-template", "MasterCardISO87", "-header", "ascii4", "-out", "bin"},
+			Stdin:          `{"mti":"0800","70":"301"}`,
+			ExpectedStdout: "0023\xf0\xf8\xf0\xf0\x80\x00\x00\x00\x00\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00\xf3\xf0\xf1",
+			ExpectedCode:   exitOK,
+		},
+		{
+			Name:  "decode_template",
+			Args:  []string{"decode", "-template", "MasterCardISO87"},
+			Stdin: testMessageHex,
+			ExpectedStdout: "mti    MessageTypeIdentifier: 0100\n" +
+				"bitmap Bitmap: 7000000000001200\n" +
+				"2      PrimaryAccountNumber: 540000******0011\n" +
+				"3      ProcessingCode: 000000\n" +
+				"4      AmountTransaction: 000000000100\n" +
+				"52     PersonalIDNumberData: ****************\n" +
+				"55     IntegratedCircuitCardSystemRelatedData: 9F2601FF\n",
+			ExpectedCode: exitOK,
+		},
+		{
+			Name:  "decode_json_binary2_header",
+			Args:  []string{"decode", "-template", "MasterCardISO87", "-json", "-header", "binary2"},
+			Stdin: "003F " + testMessageHex,
+			ExpectedStdout: `{"mti":"0100","bitmap":"7000000000001200","2":"540000******0011","3":"000000",` +
+				`"4":"000000000100","52":"****************","55":"9F2601FF"}` + "\n",
+			ExpectedCode: exitOK,
+		},
+		{
+			Name:  "decode_json_auto_header",
+			Args:  []string{"decode", "-template", "MasterCardISO87", "-json", "-header", "auto"},
+			Stdin: "30303633" + testMessageHex,
+			ExpectedStdout: `{"mti":"0100","bitmap":"7000000000001200","2":"540000******0011","3":"000000",` +
+				`"4":"000000000100","52":"****************","55":"9F2601FF"}` + "\n",
+			ExpectedCode: exitOK,
+		},
+		{
+			Name:  "decode_header_not_stripped_by_default",
+			Args:  []string{"decode", "-template", "MasterCardISO87", "-json"},
+			Stdin: "003F " + testMessageHex,
+			ExpectedStderr: `{"command":"decode","error":"iso8583.unmarshal: cant unmarshal field mti: ` +
+				`mti isnt 4 characters long, its: 2","consumed":0}` + "\n",
+			ExpectedCode: exitError,
+		},
+		{
+			Name:  "decode_fixture",
+			Args:  []string{"decode", "-template", "MasterCardISO87", "-fixture"},
+			Stdin: testMessageHex,
+			ExpectedStdout: `{"mti":"0100","bitmap":"7000000000001200","2":"5400000000000011","3":"000000",` +
+				`"4":"000000000100","52":"0123456789ABCDEF","55":"9F2601FF"}` + "\n",
+			ExpectedCode: exitOK,
+		},
+		{
+			Name:           "decode_trailing_bytes",
+			Args:           []string{"decode", "-template", "MasterCardISO87"},
+			Stdin:          "F0F8F0F0" + "0000000000000000" + "FFFF",
+			ExpectedStdout: "mti    MessageTypeIdentifier: 0800\nbitmap Bitmap: 0000000000000000\n",
+			ExpectedStderr: "warning: 2 trailing bytes were not consumed\n",
+			ExpectedCode:   exitOK,
+		},
+		{
+			Name:  "decode_malformed",
+			Args:  []string{"decode", "-template", "MasterCardISO87"},
+			Stdin: "F0F1F0F0FF",
+			ExpectedStderr: `{"command":"decode","error":"iso8583.unmarshal: cant unmarshal field bitmap: ` +
+				`bitmap should be 8 bytes long but only 1 bytes are avaiable","consumed":4}` + "\n",
+			ExpectedCode: exitError,
+		},
+		{
+			Name:           "decode_invalid_hex",
+			Args:           []string{"decode", "-template", "MasterCardISO87"},
+			Stdin:          "F0F",
+			ExpectedStderr: `{"command":"decode","error":"invalid hexadecimal input: encoding/hex: odd length hex string"}` + "\n",
+			ExpectedCode:   exitError,
+		},
+		{
+			Name:           "decode_unknown_template",
+			Args:           []string{"decode", "-template", "WhaleSong"},
+			ExpectedStderr: `{"command":"decode","error":"unknown template 'WhaleSong'"}` + "\n",
+			ExpectedCode:   exitError,
+		},
+		{
+			Name:           "decode_without_layout",
+			Args:           []string{"decode"},
+			ExpectedStderr: `{"command":"decode","error":"a template or a spec file must be indicated"}` + "\n",
+			ExpectedCode:   exitError,
+		},
+		{
+			Name:           "bitmap",
+			Args:           []string{"bitmap"},
+			Stdin:          "F230000000000000 0000000000000001",
+			ExpectedStdout: "bitmap: F2300000000000000000000000000001\nfields: 1 2 3 4 7 11 12 128\n",
+			ExpectedCode:   exitOK,
+		},
+		{
+			Name:           "convert_ascii_to_ebcdic",
+			Args:           []string{"convert"},
+			Stdin:          "0100",
+			ExpectedStdout: "F0F1F0F0\n",
+			ExpectedCode:   exitOK,
+		},
+		{
+			Name:           "convert_ebcdic_to_ascii",
+			Args:           []string{"convert", "-from", "ebcdic", "-to", "ascii", "-in", "hex", "-out", "bin"},
+			Stdin:          "F0F1F0F0",
+			ExpectedStdout: "0100",
+			ExpectedCode:   exitOK,
+		},
+		{
+			Name:           "convert_unknown_encoding",
+			Args:           []string{"convert", "-to", "whale_song"},
+			ExpectedStderr: `{"command":"convert","error":"encoding 'whale_song' does not exist"}` + "\n",
+			ExpectedCode:   exitError,
+		},
+		{
+			Name:         "unknown_command",
+			Args:         []string{"whale_song"},
+			ExpectedCode: exitUsage,
+		},
+		{
+			Name:         "no_command",
+			Args:         []string{},
+			ExpectedCode: exitUsage,
+		},
+		{
+			Name:         "invalid_flag",
+			Args:         []string{"decode", "-whale_song"},
+			ExpectedCode: exitUsage,
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			code := run(testCase.Args, strings.NewReader(testCase.Stdin), &stdout, &stderr)
+
+			assert.Equal(t, testCase.ExpectedCode, code)
+			assert.Equal(t, testCase.ExpectedStdout, stdout.String())
+			if testCase.ExpectedCode != exitUsage {
+				assert.Equal(t, testCase.ExpectedStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRun_spec_file(t *testing.T) {
+	dir, err := ioutil.TempDir("", "iso8583")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	spec := filepath.Join(dir, "spec.json")
+	assert.Nil(t, ioutil.WriteFile(spec, []byte(`{
+		"mti":    {"type": "MTI", "length": 4, "encoding": "ascii"},
+		"bitmap": {"type": "BITMAP"},
+		"2":      {"type": "LLVAR", "length": 2, "encoding": "ascii"},
+		"4":      {"type": "VAR", "length": 12, "encoding": "ascii", "mask": "full"}
+	}`), 0600))
+
+	message := filepath.Join(dir, "message.hex")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"encode", "-spec", spec}, strings.NewReader(`{"mti":"0200","2":"5400000000000011",`+
+		`"4":"000000000100"}`), &stdout, &stderr)
+	if !assert.Equal(t, exitOK, code, stderr.String()) {
+		t.FailNow()
+	}
+	assert.Nil(t, ioutil.WriteFile(message, stdout.Bytes(), 0600))
+
+	stdout.Reset()
+	code = run([]string{"decode", "-spec", spec, message}, nil, &stdout, &stderr)
+	assert.Equal(t, exitOK, code, stderr.String())
+	assert.Equal(t, "mti    FieldMti: 0200\n"+
+		"bitmap FieldBitmap: 5000000000000000\n"+
+		"2      Field2: 540000******0011\n"+
+		"4      Field4: ************\n", stdout.String())
+}
+
+func TestRun_diff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "iso8583")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	a, b := filepath.Join(dir, "a.hex"), filepath.Join(dir, "b.hex")
+	for file, document := range map[string]string{
+		a: `{"mti":"0100","2":"5400000000000011","11":"000001","41":"TERM0001"}`,
+		b: `{"mti":"0100","2":"5400000000000011","11":"000002","41":"TERM0002"}`,
+	} {
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"encode", "-template", "MasterCardISO87"}, strings.NewReader(document), &stdout, &stderr)
+		if !assert.Equal(t, exitOK, code, stderr.String()) {
+			t.FailNow()
+		}
+		assert.Nil(t, ioutil.WriteFile(file, stdout.Bytes(), 0600))
+	}
+
+	testList := []struct {
+		Name           string
+		Args           []string
+		ExpectedStdout string
+		ExpectedStderr string
+		ExpectedCode   int
+	}{
+		{
+			Name:           "differences",
+			Args:           []string{"diff", "-template", "MasterCardISO87", a, b},
+			ExpectedStdout: "field 11: 000001 != 000002\nfield 41: TERM0001 != TERM0002\n",
+			ExpectedCode:   exitDifferent,
+		},
+		{
+			Name:           "ignore_fields",
+			Args:           []string{"diff", "-template", "MasterCardISO87", "-volatile", "-ignore", "41", a, b},
+			ExpectedStdout: "",
+			ExpectedCode:   exitOK,
+		},
+		{
+			Name:           "missing_file",
+			Args:           []string{"diff", "-template", "MasterCardISO87", a},
+			ExpectedStderr: `{"command":"diff","error":"two message files must be indicated, got 1"}` + "\n",
+			ExpectedCode:   exitError,
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			code := run(testCase.Args, nil, &stdout, &stderr)
+
+			assert.Equal(t, testCase.ExpectedCode, code)
+			assert.Equal(t, testCase.ExpectedStdout, stdout.String())
+			assert.Equal(t, testCase.ExpectedStderr, stderr.String())
+		})
+	}
+}
+
+func TestParseSpec_errors(t *testing.T) {
+	testList := []struct {
+		Name        string
+		Input       string
+		OutputError string
+	}{
+		{Name: "unknown_type", Input: `{"2": {"type": "WHALE"}}`, OutputError: "invalid spec: field 2: unknown type 'WHALE'"},
+		{Name: "invalid_name", Input: `{"two": {"type": "VAR"}}`, OutputError: "invalid spec: invalid field name: two"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := parseSpec([]byte(testCase.Input))
+			if assert.NotNil(t, err) {
+				assert.Equal(t, testCase.OutputError, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+	"github.com/jattento/go-iso8583/pkg/template"
+)
+
+// templates are the inbuilt message layouts that can be selected by name.
+var templates = map[string]reflect.Type{
+	"MasterCardISO87": reflect.TypeOf(template.MasterCardISO87{}),
+}
+
+// fieldTypes are the field types that can be used in a spec file.
+var fieldTypes = map[string]reflect.Type{
+	"MTI":           reflect.TypeOf(iso8583.MTI{}),
+	"BITMAP":        reflect.TypeOf(iso8583.BITMAP{}),
+	"VAR":           reflect.TypeOf(iso8583.VAR("")),
+	"LLVAR":         reflect.TypeOf(iso8583.LLVAR("")),
+	"LLLVAR":        reflect.TypeOf(iso8583.LLLVAR("")),
+	"LLLLVAR":       reflect.TypeOf(iso8583.LLLLVAR("")),
+	"LVAR":          reflect.TypeOf(iso8583.LVAR("")),
+	"BINARY":        reflect.TypeOf(iso8583.BINARY{}),
+	"LLBINARY":      reflect.TypeOf(iso8583.LLBINARY{}),
+	"LLLBINARY":     reflect.TypeOf(iso8583.LLLBINARY{}),
+	"LLLLBINARY":    reflect.TypeOf(iso8583.LLLLBINARY{}),
+	"LBINARY":       reflect.TypeOf(iso8583.LBINARY{}),
+	"TLV":           reflect.TypeOf(iso8583.TLV{}),
+	"TLVASCII":      reflect.TypeOf(iso8583.TLVASCII{}),
+	"NUMERIC":       reflect.TypeOf(iso8583.NUMERIC(0)),
+	"SIGNEDNUMERIC": reflect.TypeOf(iso8583.SIGNEDNUMERIC(0)),
+	"MMDDHHMMSS":    reflect.TypeOf(iso8583.MMDDHHMMSS{}),
+	"MMDD":          reflect.TypeOf(iso8583.MMDD{}),
+	"YYMM":          reflect.TypeOf(iso8583.YYMM{}),
+	"HHMMSS":        reflect.TypeOf(iso8583.HHMMSS{}),
+	"Track1":        reflect.TypeOf(iso8583.Track1{}),
+	"Track2":        reflect.TypeOf(iso8583.Track2{}),
+	"PAN":           reflect.TypeOf(iso8583.PAN("")),
+}
+
+// specField is the description of a field in a spec file.
+// A spec file is a JSON object keyed by field name, for example:
+// 	{
+// 		"mti":    {"type": "MTI", "length": 4, "encoding": "ascii"},
+// 		"bitmap": {"type": "BITMAP"},
+// 		"2":      {"type": "LLVAR", "length": 2, "encoding": "ascii", "mask": "pan"}
+// 	}
+type specField struct {
+	Type     string `json:"type"`
+	Length   int    `json:"length"`
+	Encoding string `json:"encoding"`
+	Mask     string `json:"mask"`
+}
+
+// messageType returns the struct type selected by template name or spec file.
+func messageType(templateName, specFile string) (reflect.Type, error) {
+	switch {
+	case templateName != "" && specFile != "":
+		return nil, fmt.Errorf("template and spec can not be used together")
+	case specFile != "":
+		b, err := ioutil.ReadFile(specFile)
+		if err != nil {
+			return nil, err
+		}
+		return parseSpec(b)
+	case templateName != "":
+		t, exist := templates[templateName]
+		if !exist {
+			return nil, fmt.Errorf("unknown template '%s'", templateName)
+		}
+		return t, nil
+	default:
+		return nil, fmt.Errorf("a template or a spec file must be indicated")
+	}
+}
+
+// parseSpec builds a struct type with iso8583 tags from a spec file.
+// All fields are omitempty.
+func parseSpec(b []byte) (reflect.Type, error) {
+	var spec map[string]specField
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+
+	names := make([]string, 0, len(spec))
+	for name := range spec {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	structFields := make([]reflect.StructField, 0, len(spec))
+	for _, name := range names {
+		f := spec[name]
+
+		t, exist := fieldTypes[f.Type]
+		if !exist {
+			return nil, fmt.Errorf("invalid spec: field %s: unknown type '%s'", name, f.Type)
+		}
+
+		if _, err := strconv.Atoi(name); err != nil && name != "mti" && name != "bitmap" {
+			return nil, fmt.Errorf("invalid spec: invalid field name: %s", name)
+		}
+
+		tag := []string{name}
+		if f.Length != 0 {
+			tag = append(tag, "length:"+strconv.Itoa(f.Length))
+		}
+		if f.Encoding != "" {
+			tag = append(tag, "encoding:"+f.Encoding)
+		}
+		if f.Mask != "" {
+			tag = append(tag, "mask:"+f.Mask)
+		}
+		tag = append(tag, "omitempty")
+
+		structFields = append(structFields, reflect.StructField{
+			Name: "Field" + strings.ToUpper(name[:1]) + name[1:],
+			Type: t,
+			Tag:  reflect.StructTag(`iso8583:"` + strings.Join(tag, ",") + `"`),
+		})
+	}
+
+	return reflect.StructOf(structFields), nil
+}
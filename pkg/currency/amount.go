@@ -0,0 +1,114 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Amount is an amount in minor units of a currency, as carried by ISO 8583 amount fields.
+// For example DE4 000000001050 with DE49 840 is:
+// 	amount, err := currency.New(string(msg.AmountTransaction), string(msg.CurrencyCodeTransaction))
+// 	amount.Decimal() // "10.50"
+type Amount struct {
+	Minor    int64
+	Currency Currency
+}
+
+// New combines an amount field, which content are minor unit digits, and the currency code field.
+func New(amount string, code string) (Amount, error) {
+	c, err := Lookup(code)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	amount = strings.TrimSpace(amount)
+	if amount == "" || strings.TrimLeft(amount, "0123456789") != "" {
+		return Amount{}, fmt.Errorf("amount '%s' must contain only digits", amount)
+	}
+
+	minor, err := strconv.ParseInt(amount, 10, 64)
+	if err != nil {
+		return Amount{}, fmt.Errorf("amount '%s' overflows int64", amount)
+	}
+
+	return Amount{Minor: minor, Currency: c}, nil
+}
+
+// Parse reads a decimal amount like "10.5" or "-3" in the indicated currency,
+// more decimals than the currency exponent are not allowed.
+func Parse(decimal string, code string) (Amount, error) {
+	c, err := Lookup(code)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	s := strings.TrimSpace(decimal)
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	integer, fraction := s, ""
+	if dot := strings.Index(s, "."); dot >= 0 {
+		integer, fraction = s[:dot], s[dot+1:]
+	}
+
+	if integer == "" || strings.TrimLeft(integer+fraction, "0123456789") != "" {
+		return Amount{}, fmt.Errorf("'%s' is not a valid decimal amount", decimal)
+	}
+
+	if len(fraction) > c.Exponent {
+		return Amount{}, fmt.Errorf("'%s' has more than %v decimals allowed by %s", decimal, c.Exponent, c.Code)
+	}
+
+	minor, err := strconv.ParseInt(integer+fraction+strings.Repeat("0", c.Exponent-len(fraction)), 10, 64)
+	if err != nil {
+		return Amount{}, fmt.Errorf("amount '%s' overflows int64", decimal)
+	}
+
+	if negative {
+		minor = -minor
+	}
+
+	return Amount{Minor: minor, Currency: c}, nil
+}
+
+// Decimal represents the amount with the currency exponent decimals, for example "10.50" for USD or "1050" for JPY.
+func (a Amount) Decimal() string {
+	sign, abs := "", uint64(a.Minor)
+	if a.Minor < 0 {
+		sign, abs = "-", -uint64(a.Minor)
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if a.Currency.Exponent <= 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= a.Currency.Exponent {
+		digits = strings.Repeat("0", a.Currency.Exponent-len(digits)+1) + digits
+	}
+
+	point := len(digits) - a.Currency.Exponent
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// String returns the decimal amount followed by the currency code, for example "10.50 USD".
+func (a Amount) String() string {
+	return a.Decimal() + " " + a.Currency.Code
+}
+
+// Field returns the minor units padded with zeros up to length, as required by amount fields.
+func (a Amount) Field(length int) (string, error) {
+	if a.Minor < 0 {
+		return "", errors.New("negative amounts can not be represented by an amount field")
+	}
+
+	digits := strconv.FormatInt(a.Minor, 10)
+	if len(digits) > length {
+		return "", fmt.Errorf("amount %s exceeds %v digits", digits, length)
+	}
+
+	return strings.Repeat("0", length-len(digits)) + digits, nil
+}
@@ -0,0 +1,94 @@
+package currency_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/currency"
+)
+
+func TestNew(t *testing.T) {
+	testList := []struct {
+		Name          string
+		Amount        string
+		Code          string
+		OutputDecimal string
+		OutputString  string
+		OutputError   string
+	}{
+		{Name: "usd", Amount: "000000001050", Code: "840", OutputDecimal: "10.50", OutputString: "10.50 USD"},
+		{Name: "jpy", Amount: "000000001050", Code: "392", OutputDecimal: "1050", OutputString: "1050 JPY"},
+		{Name: "bhd", Amount: "000000001050", Code: "048", OutputDecimal: "1.050", OutputString: "1.050 BHD"},
+		{Name: "cents", Amount: "000000000005", Code: "USD", OutputDecimal: "0.05", OutputString: "0.05 USD"},
+		{Name: "non_digits", Amount: "0000000010.5", Code: "840", OutputError: "amount '0000000010.5' must contain only digits"},
+		{Name: "unknown_currency", Amount: "000000001050", Code: "000", OutputError: "unknown currency: '000'"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			a, err := currency.New(testCase.Amount, testCase.Code)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputDecimal, a.Decimal())
+			assert.Equal(t, testCase.OutputString, a.String())
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	testList := []struct {
+		Name        string
+		Decimal     string
+		Code        string
+		OutputMinor int64
+		OutputError string
+	}{
+		{Name: "usd", Decimal: "10.5", Code: "USD", OutputMinor: 1050},
+		{Name: "integer", Decimal: "10", Code: "USD", OutputMinor: 1000},
+		{Name: "negative", Decimal: "-0.25", Code: "EUR", OutputMinor: -25},
+		{Name: "jpy", Decimal: "1050", Code: "JPY", OutputMinor: 1050},
+		{Name: "too_many_decimals", Decimal: "10.505", Code: "USD",
+			OutputError: "'10.505' has more than 2 decimals allowed by USD"},
+		{Name: "invalid", Decimal: "1,05", Code: "USD", OutputError: "'1,05' is not a valid decimal amount"},
+		{Name: "empty", Decimal: ".5", Code: "USD", OutputError: "'.5' is not a valid decimal amount"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			a, err := currency.Parse(testCase.Decimal, testCase.Code)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputMinor, a.Minor)
+		})
+	}
+}
+
+func TestAmount_Field(t *testing.T) {
+	a, err := currency.Parse("10.50", "USD")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	field, err := a.Field(12)
+	assert.Nil(t, err)
+	assert.Equal(t, "000000001050", field)
+
+	_, err = a.Field(3)
+	assert.EqualError(t, err, "amount 1050 exceeds 3 digits")
+
+	_, err = currency.Amount{Minor: -1}.Field(12)
+	assert.EqualError(t, err, "negative amounts can not be represented by an amount field")
+}
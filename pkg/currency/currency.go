@@ -0,0 +1,201 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownCurrency is returned when a currency code is not present in Currencies, exported error for asserting.
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// Currency is an ISO 4217 currency.
+// Exponent is the amount of minor unit digits, which ISO 8583 amounts imply, for example
+// an amount of 000000001050 is 10.50 in USD (exponent 2) but 1050 in JPY (exponent 0).
+type Currency struct {
+	Code     string
+	Number   string
+	Exponent int
+	Name     string
+}
+
+// Currencies contains the ISO 4217 active currencies keyed by alphabetic code, it can be modified
+// to add private or withdrawn currencies.
+var Currencies = map[string]Currency{
+	"AED": {Code: "AED", Number: "784", Exponent: 2, Name: "UAE Dirham"},
+	"AFN": {Code: "AFN", Number: "971", Exponent: 2, Name: "Afghani"},
+	"ALL": {Code: "ALL", Number: "008", Exponent: 2, Name: "Lek"},
+	"AMD": {Code: "AMD", Number: "051", Exponent: 2, Name: "Armenian Dram"},
+	"ANG": {Code: "ANG", Number: "532", Exponent: 2, Name: "Netherlands Antillean Guilder"},
+	"AOA": {Code: "AOA", Number: "973", Exponent: 2, Name: "Kwanza"},
+	"ARS": {Code: "ARS", Number: "032", Exponent: 2, Name: "Argentine Peso"},
+	"AUD": {Code: "AUD", Number: "036", Exponent: 2, Name: "Australian Dollar"},
+	"AWG": {Code: "AWG", Number: "533", Exponent: 2, Name: "Aruban Florin"},
+	"AZN": {Code: "AZN", Number: "944", Exponent: 2, Name: "Azerbaijan Manat"},
+	"BAM": {Code: "BAM", Number: "977", Exponent: 2, Name: "Convertible Mark"},
+	"BBD": {Code: "BBD", Number: "052", Exponent: 2, Name: "Barbados Dollar"},
+	"BDT": {Code: "BDT", Number: "050", Exponent: 2, Name: "Taka"},
+	"BGN": {Code: "BGN", Number: "975", Exponent: 2, Name: "Bulgarian Lev"},
+	"BHD": {Code: "BHD", Number: "048", Exponent: 3, Name: "Bahraini Dinar"},
+	"BIF": {Code: "BIF", Number: "108", Exponent: 0, Name: "Burundi Franc"},
+	"BMD": {Code: "BMD", Number: "060", Exponent: 2, Name: "Bermudian Dollar"},
+	"BND": {Code: "BND", Number: "096", Exponent: 2, Name: "Brunei Dollar"},
+	"BOB": {Code: "BOB", Number: "068", Exponent: 2, Name: "Boliviano"},
+	"BRL": {Code: "BRL", Number: "986", Exponent: 2, Name: "Brazilian Real"},
+	"BSD": {Code: "BSD", Number: "044", Exponent: 2, Name: "Bahamian Dollar"},
+	"BTN": {Code: "BTN", Number: "064", Exponent: 2, Name: "Ngultrum"},
+	"BWP": {Code: "BWP", Number: "072", Exponent: 2, Name: "Pula"},
+	"BYN": {Code: "BYN", Number: "933", Exponent: 2, Name: "Belarusian Ruble"},
+	"BZD": {Code: "BZD", Number: "084", Exponent: 2, Name: "Belize Dollar"},
+	"CAD": {Code: "CAD", Number: "124", Exponent: 2, Name: "Canadian Dollar"},
+	"CDF": {Code: "CDF", Number: "976", Exponent: 2, Name: "Congolese Franc"},
+	"CHF": {Code: "CHF", Number: "756", Exponent: 2, Name: "Swiss Franc"},
+	"CLF": {Code: "CLF", Number: "990", Exponent: 4, Name: "Unidad de Fomento"},
+	"CLP": {Code: "CLP", Number: "152", Exponent: 0, Name: "Chilean Peso"},
+	"CNY": {Code: "CNY", Number: "156", Exponent: 2, Name: "Yuan Renminbi"},
+	"COP": {Code: "COP", Number: "170", Exponent: 2, Name: "Colombian Peso"},
+	"CRC": {Code: "CRC", Number: "188", Exponent: 2, Name: "Costa Rican Colon"},
+	"CUP": {Code: "CUP", Number: "192", Exponent: 2, Name: "Cuban Peso"},
+	"CVE": {Code: "CVE", Number: "132", Exponent: 2, Name: "Cabo Verde Escudo"},
+	"CZK": {Code: "CZK", Number: "203", Exponent: 2, Name: "Czech Koruna"},
+	"DJF": {Code: "DJF", Number: "262", Exponent: 0, Name: "Djibouti Franc"},
+	"DKK": {Code: "DKK", Number: "208", Exponent: 2, Name: "Danish Krone"},
+	"DOP": {Code: "DOP", Number: "214", Exponent: 2, Name: "Dominican Peso"},
+	"DZD": {Code: "DZD", Number: "012", Exponent: 2, Name: "Algerian Dinar"},
+	"EGP": {Code: "EGP", Number: "818", Exponent: 2, Name: "Egyptian Pound"},
+	"ERN": {Code: "ERN", Number: "232", Exponent: 2, Name: "Nakfa"},
+	"ETB": {Code: "ETB", Number: "230", Exponent: 2, Name: "Ethiopian Birr"},
+	"EUR": {Code: "EUR", Number: "978", Exponent: 2, Name: "Euro"},
+	"FJD": {Code: "FJD", Number: "242", Exponent: 2, Name: "Fiji Dollar"},
+	"FKP": {Code: "FKP", Number: "238", Exponent: 2, Name: "Falkland Islands Pound"},
+	"GBP": {Code: "GBP", Number: "826", Exponent: 2, Name: "Pound Sterling"},
+	"GEL": {Code: "GEL", Number: "981", Exponent: 2, Name: "Lari"},
+	"GHS": {Code: "GHS", Number: "936", Exponent: 2, Name: "Ghana Cedi"},
+	"GIP": {Code: "GIP", Number: "292", Exponent: 2, Name: "Gibraltar Pound"},
+	"GMD": {Code: "GMD", Number: "270", Exponent: 2, Name: "Dalasi"},
+	"GNF": {Code: "GNF", Number: "324", Exponent: 0, Name: "Guinean Franc"},
+	"GTQ": {Code: "GTQ", Number: "320", Exponent: 2, Name: "Quetzal"},
+	"GYD": {Code: "GYD", Number: "328", Exponent: 2, Name: "Guyana Dollar"},
+	"HKD": {Code: "HKD", Number: "344", Exponent: 2, Name: "Hong Kong Dollar"},
+	"HNL": {Code: "HNL", Number: "340", Exponent: 2, Name: "Lempira"},
+	"HTG": {Code: "HTG", Number: "332", Exponent: 2, Name: "Gourde"},
+	"HUF": {Code: "HUF", Number: "348", Exponent: 2, Name: "Forint"},
+	"IDR": {Code: "IDR", Number: "360", Exponent: 2, Name: "Rupiah"},
+	"ILS": {Code: "ILS", Number: "376", Exponent: 2, Name: "New Israeli Sheqel"},
+	"INR": {Code: "INR", Number: "356", Exponent: 2, Name: "Indian Rupee"},
+	"IQD": {Code: "IQD", Number: "368", Exponent: 3, Name: "Iraqi Dinar"},
+	"IRR": {Code: "IRR", Number: "364", Exponent: 2, Name: "Iranian Rial"},
+	"ISK": {Code: "ISK", Number: "352", Exponent: 0, Name: "Iceland Krona"},
+	"JMD": {Code: "JMD", Number: "388", Exponent: 2, Name: "Jamaican Dollar"},
+	"JOD": {Code: "JOD", Number: "400", Exponent: 3, Name: "Jordanian Dinar"},
+	"JPY": {Code: "JPY", Number: "392", Exponent: 0, Name: "Yen"},
+	"KES": {Code: "KES", Number: "404", Exponent: 2, Name: "Kenyan Shilling"},
+	"KGS": {Code: "KGS", Number: "417", Exponent: 2, Name: "Som"},
+	"KHR": {Code: "KHR", Number: "116", Exponent: 2, Name: "Riel"},
+	"KMF": {Code: "KMF", Number: "174", Exponent: 0, Name: "Comorian Franc"},
+	"KPW": {Code: "KPW", Number: "408", Exponent: 2, Name: "North Korean Won"},
+	"KRW": {Code: "KRW", Number: "410", Exponent: 0, Name: "Won"},
+	"KWD": {Code: "KWD", Number: "414", Exponent: 3, Name: "Kuwaiti Dinar"},
+	"KYD": {Code: "KYD", Number: "136", Exponent: 2, Name: "Cayman Islands Dollar"},
+	"KZT": {Code: "KZT", Number: "398", Exponent: 2, Name: "Tenge"},
+	"LAK": {Code: "LAK", Number: "418", Exponent: 2, Name: "Lao Kip"},
+	"LBP": {Code: "LBP", Number: "422", Exponent: 2, Name: "Lebanese Pound"},
+	"LKR": {Code: "LKR", Number: "144", Exponent: 2, Name: "Sri Lanka Rupee"},
+	"LRD": {Code: "LRD", Number: "430", Exponent: 2, Name: "Liberian Dollar"},
+	"LSL": {Code: "LSL", Number: "426", Exponent: 2, Name: "Loti"},
+	"LYD": {Code: "LYD", Number: "434", Exponent: 3, Name: "Libyan Dinar"},
+	"MAD": {Code: "MAD", Number: "504", Exponent: 2, Name: "Moroccan Dirham"},
+	"MDL": {Code: "MDL", Number: "498", Exponent: 2, Name: "Moldovan Leu"},
+	"MGA": {Code: "MGA", Number: "969", Exponent: 2, Name: "Malagasy Ariary"},
+	"MKD": {Code: "MKD", Number: "807", Exponent: 2, Name: "Denar"},
+	"MMK": {Code: "MMK", Number: "104", Exponent: 2, Name: "Kyat"},
+	"MNT": {Code: "MNT", Number: "496", Exponent: 2, Name: "Tugrik"},
+	"MOP": {Code: "MOP", Number: "446", Exponent: 2, Name: "Pataca"},
+	"MRU": {Code: "MRU", Number: "929", Exponent: 2, Name: "Ouguiya"},
+	"MUR": {Code: "MUR", Number: "480", Exponent: 2, Name: "Mauritius Rupee"},
+	"MVR": {Code: "MVR", Number: "462", Exponent: 2, Name: "Rufiyaa"},
+	"MWK": {Code: "MWK", Number: "454", Exponent: 2, Name: "Malawi Kwacha"},
+	"MXN": {Code: "MXN", Number: "484", Exponent: 2, Name: "Mexican Peso"},
+	"MYR": {Code: "MYR", Number: "458", Exponent: 2, Name: "Malaysian Ringgit"},
+	"MZN": {Code: "MZN", Number: "943", Exponent: 2, Name: "Mozambique Metical"},
+	"NAD": {Code: "NAD", Number: "516", Exponent: 2, Name: "Namibia Dollar"},
+	"NGN": {Code: "NGN", Number: "566", Exponent: 2, Name: "Naira"},
+	"NIO": {Code: "NIO", Number: "558", Exponent: 2, Name: "Cordoba Oro"},
+	"NOK": {Code: "NOK", Number: "578", Exponent: 2, Name: "Norwegian Krone"},
+	"NPR": {Code: "NPR", Number: "524", Exponent: 2, Name: "Nepalese Rupee"},
+	"NZD": {Code: "NZD", Number: "554", Exponent: 2, Name: "New Zealand Dollar"},
+	"OMR": {Code: "OMR", Number: "512", Exponent: 3, Name: "Rial Omani"},
+	"PAB": {Code: "PAB", Number: "590", Exponent: 2, Name: "Balboa"},
+	"PEN": {Code: "PEN", Number: "604", Exponent: 2, Name: "Sol"},
+	"PGK": {Code: "PGK", Number: "598", Exponent: 2, Name: "Kina"},
+	"PHP": {Code: "PHP", Number: "608", Exponent: 2, Name: "Philippine Peso"},
+	"PKR": {Code: "PKR", Number: "586", Exponent: 2, Name: "Pakistan Rupee"},
+	"PLN": {Code: "PLN", Number: "985", Exponent: 2, Name: "Zloty"},
+	"PYG": {Code: "PYG", Number: "600", Exponent: 0, Name: "Guarani"},
+	"QAR": {Code: "QAR", Number: "634", Exponent: 2, Name: "Qatari Rial"},
+	"RON": {Code: "RON", Number: "946", Exponent: 2, Name: "Romanian Leu"},
+	"RSD": {Code: "RSD", Number: "941", Exponent: 2, Name: "Serbian Dinar"},
+	"RUB": {Code: "RUB", Number: "643", Exponent: 2, Name: "Russian Ruble"},
+	"RWF": {Code: "RWF", Number: "646", Exponent: 0, Name: "Rwanda Franc"},
+	"SAR": {Code: "SAR", Number: "682", Exponent: 2, Name: "Saudi Riyal"},
+	"SBD": {Code: "SBD", Number: "090", Exponent: 2, Name: "Solomon Islands Dollar"},
+	"SCR": {Code: "SCR", Number: "690", Exponent: 2, Name: "Seychelles Rupee"},
+	"SDG": {Code: "SDG", Number: "938", Exponent: 2, Name: "Sudanese Pound"},
+	"SEK": {Code: "SEK", Number: "752", Exponent: 2, Name: "Swedish Krona"},
+	"SGD": {Code: "SGD", Number: "702", Exponent: 2, Name: "Singapore Dollar"},
+	"SHP": {Code: "SHP", Number: "654", Exponent: 2, Name: "Saint Helena Pound"},
+	"SLE": {Code: "SLE", Number: "925", Exponent: 2, Name: "Leone"},
+	"SOS": {Code: "SOS", Number: "706", Exponent: 2, Name: "Somali Shilling"},
+	"SRD": {Code: "SRD", Number: "968", Exponent: 2, Name: "Surinam Dollar"},
+	"SSP": {Code: "SSP", Number: "728", Exponent: 2, Name: "South Sudanese Pound"},
+	"STN": {Code: "STN", Number: "930", Exponent: 2, Name: "Dobra"},
+	"SVC": {Code: "SVC", Number: "222", Exponent: 2, Name: "El Salvador Colon"},
+	"SYP": {Code: "SYP", Number: "760", Exponent: 2, Name: "Syrian Pound"},
+	"SZL": {Code: "SZL", Number: "748", Exponent: 2, Name: "Lilangeni"},
+	"THB": {Code: "THB", Number: "764", Exponent: 2, Name: "Baht"},
+	"TJS": {Code: "TJS", Number: "972", Exponent: 2, Name: "Somoni"},
+	"TMT": {Code: "TMT", Number: "934", Exponent: 2, Name: "Turkmenistan New Manat"},
+	"TND": {Code: "TND", Number: "788", Exponent: 3, Name: "Tunisian Dinar"},
+	"TOP": {Code: "TOP", Number: "776", Exponent: 2, Name: "Pa'anga"},
+	"TRY": {Code: "TRY", Number: "949", Exponent: 2, Name: "Turkish Lira"},
+	"TTD": {Code: "TTD", Number: "780", Exponent: 2, Name: "Trinidad and Tobago Dollar"},
+	"TWD": {Code: "TWD", Number: "901", Exponent: 2, Name: "New Taiwan Dollar"},
+	"TZS": {Code: "TZS", Number: "834", Exponent: 2, Name: "Tanzanian Shilling"},
+	"UAH": {Code: "UAH", Number: "980", Exponent: 2, Name: "Hryvnia"},
+	"UGX": {Code: "UGX", Number: "800", Exponent: 0, Name: "Uganda Shilling"},
+	"USD": {Code: "USD", Number: "840", Exponent: 2, Name: "US Dollar"},
+	"UYI": {Code: "UYI", Number: "940", Exponent: 0, Name: "Uruguay Peso en Unidades Indexadas"},
+	"UYU": {Code: "UYU", Number: "858", Exponent: 2, Name: "Peso Uruguayo"},
+	"UYW": {Code: "UYW", Number: "927", Exponent: 4, Name: "Unidad Previsional"},
+	"UZS": {Code: "UZS", Number: "860", Exponent: 2, Name: "Uzbekistan Sum"},
+	"VES": {Code: "VES", Number: "928", Exponent: 2, Name: "Bolivar Soberano"},
+	"VND": {Code: "VND", Number: "704", Exponent: 0, Name: "Dong"},
+	"VUV": {Code: "VUV", Number: "548", Exponent: 0, Name: "Vatu"},
+	"WST": {Code: "WST", Number: "882", Exponent: 2, Name: "Tala"},
+	"XAF": {Code: "XAF", Number: "950", Exponent: 0, Name: "CFA Franc BEAC"},
+	"XCD": {Code: "XCD", Number: "951", Exponent: 2, Name: "East Caribbean Dollar"},
+	"XOF": {Code: "XOF", Number: "952", Exponent: 0, Name: "CFA Franc BCEAO"},
+	"XPF": {Code: "XPF", Number: "953", Exponent: 0, Name: "CFP Franc"},
+	"YER": {Code: "YER", Number: "886", Exponent: 2, Name: "Yemeni Rial"},
+	"ZAR": {Code: "ZAR", Number: "710", Exponent: 2, Name: "Rand"},
+	"ZMW": {Code: "ZMW", Number: "967", Exponent: 2, Name: "Zambian Kwacha"},
+	"ZWL": {Code: "ZWL", Number: "932", Exponent: 2, Name: "Zimbabwe Dollar"},
+}
+
+// Lookup returns the currency of an alphabetic code, like "USD", or a numeric code, like "840",
+// as used by DE49, DE50 and DE51.
+func Lookup(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	if c, found := Currencies[code]; found {
+		return c, nil
+	}
+
+	for _, c := range Currencies {
+		if c.Number == code {
+			return c, nil
+		}
+	}
+
+	return Currency{}, fmt.Errorf("%w: '%s'", ErrUnknownCurrency, code)
+}
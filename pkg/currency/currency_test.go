@@ -0,0 +1,45 @@
+package currency_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/currency"
+)
+
+func TestLookup(t *testing.T) {
+	c, err := currency.Lookup("840")
+	if assert.Nil(t, err) {
+		assert.Equal(t, currency.Currency{Code: "USD", Number: "840", Exponent: 2, Name: "US Dollar"}, c)
+	}
+
+	c, err = currency.Lookup("jpy")
+	if assert.Nil(t, err) {
+		assert.Equal(t, 0, c.Exponent)
+	}
+
+	c, err = currency.Lookup("048")
+	if assert.Nil(t, err) {
+		assert.Equal(t, "BHD", c.Code)
+		assert.Equal(t, 3, c.Exponent)
+	}
+
+	_, err = currency.Lookup("999")
+	assert.True(t, errors.Is(err, currency.ErrUnknownCurrency))
+	assert.EqualError(t, err, "unknown currency: '999'")
+}
+
+func TestCurrencies_consistency(t *testing.T) {
+	numbers := make(map[string]string)
+	for code, c := range currency.Currencies {
+		assert.Equal(t, code, c.Code)
+		assert.Len(t, c.Number, 3, code)
+
+		if other, repeated := numbers[c.Number]; repeated {
+			t.Errorf("number %s is repeated by %s and %s", c.Number, code, other)
+		}
+		numbers[c.Number] = code
+	}
+}
@@ -0,0 +1,126 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// _rateDigits is the amount of digits of a conversion rate after its decimal position digit.
+const _rateDigits = 7
+
+// Rate is a conversion rate as carried by DE9 and DE10: 8 digits where the leftmost one indicates
+// the position of the decimal point from the right, for example "61234567" is 1.234567.
+type Rate struct {
+	Value    int64
+	Decimals int
+}
+
+// ParseRate reads a conversion rate field.
+func ParseRate(field string) (Rate, error) {
+	if len(field) != _rateDigits+1 || strings.TrimLeft(field, "0123456789") != "" {
+		return Rate{}, fmt.Errorf("conversion rate '%s' must be %v digits long", field, _rateDigits+1)
+	}
+
+	decimals := int(field[0] - '0')
+	if decimals > _rateDigits {
+		return Rate{}, fmt.Errorf("conversion rate '%s' decimal position %v exceeds %v", field, decimals, _rateDigits)
+	}
+
+	// Digits were already checked.
+	value, _ := strconv.ParseInt(field[1:], 10, 64)
+
+	return Rate{Value: value, Decimals: decimals}, nil
+}
+
+// ParseDecimalRate reads a conversion rate like "1.234567", it fails if it can not be represented
+// with 7 significant digits.
+func ParseDecimalRate(decimal string) (Rate, error) {
+	integer, fraction := decimal, ""
+	if dot := strings.Index(decimal, "."); dot >= 0 {
+		integer, fraction = decimal[:dot], decimal[dot+1:]
+	}
+
+	if integer+fraction == "" || strings.TrimLeft(integer+fraction, "0123456789") != "" {
+		return Rate{}, fmt.Errorf("'%s' is not a valid decimal conversion rate", decimal)
+	}
+
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > _rateDigits || len(strings.TrimLeft(integer+fraction, "0")) > _rateDigits {
+		return Rate{}, fmt.Errorf("conversion rate '%s' does not fit in %v digits", decimal, _rateDigits)
+	}
+
+	// Digits were already checked.
+	value, _ := strconv.ParseInt(integer+fraction, 10, 64)
+
+	return Rate{Value: value, Decimals: len(fraction)}, nil
+}
+
+// Field returns the 8 digits representation of the rate.
+func (r Rate) Field() (string, error) {
+	digits := strconv.FormatInt(r.Value, 10)
+	if r.Value < 0 || len(digits) > _rateDigits || r.Decimals < 0 || r.Decimals > _rateDigits {
+		return "", fmt.Errorf("conversion rate %v with %v decimals does not fit in %v digits",
+			r.Value, r.Decimals, _rateDigits)
+	}
+
+	return strconv.Itoa(r.Decimals) + strings.Repeat("0", _rateDigits-len(digits)) + digits, nil
+}
+
+// String represents the rate as a decimal, for example "1.234567".
+func (r Rate) String() string {
+	return Amount{Minor: r.Value, Currency: Currency{Exponent: r.Decimals}}.Decimal()
+}
+
+// Convert applies the conversion rate to the amount, expressing it in the indicated currency,
+// like cardholder billing amount (DE6) is obtained from the transaction amount (DE4) and DE10.
+// The result is rounded half away from zero to the minor unit.
+func (a Amount) Convert(rate Rate, to Currency) (Amount, error) {
+	numerator := big.NewInt(a.Minor)
+	numerator.Mul(numerator, big.NewInt(rate.Value))
+	numerator.Mul(numerator, pow10(to.Exponent))
+
+	denominator := pow10(rate.Decimals)
+	denominator.Mul(denominator, pow10(a.Currency.Exponent))
+
+	return divideRounded(numerator, denominator, to)
+}
+
+// ConvertInverse reverts the conversion rate, expressing the amount in the indicated currency,
+// like the transaction amount (DE4) is obtained from the cardholder billing amount (DE6) and DE10.
+// The result is rounded half away from zero to the minor unit.
+func (a Amount) ConvertInverse(rate Rate, to Currency) (Amount, error) {
+	if rate.Value == 0 {
+		return Amount{}, errors.New("conversion rate is zero")
+	}
+
+	numerator := big.NewInt(a.Minor)
+	numerator.Mul(numerator, pow10(rate.Decimals))
+	numerator.Mul(numerator, pow10(to.Exponent))
+
+	denominator := big.NewInt(rate.Value)
+	denominator.Mul(denominator, pow10(a.Currency.Exponent))
+
+	return divideRounded(numerator, denominator, to)
+}
+
+func divideRounded(numerator, denominator *big.Int, to Currency) (Amount, error) {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+
+	// Remainder keeps the sign of the numerator.
+	if new(big.Int).Abs(new(big.Int).Mul(remainder, big.NewInt(2))).Cmp(new(big.Int).Abs(denominator)) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(numerator.Sign()*denominator.Sign())))
+	}
+
+	if !quotient.IsInt64() {
+		return Amount{}, fmt.Errorf("converted amount %s overflows int64", quotient)
+	}
+
+	return Amount{Minor: quotient.Int64(), Currency: to}, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
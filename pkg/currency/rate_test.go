@@ -0,0 +1,134 @@
+package currency_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/currency"
+)
+
+func TestParseRate(t *testing.T) {
+	testList := []struct {
+		Name         string
+		Field        string
+		OutputRate   currency.Rate
+		OutputString string
+		OutputError  string
+	}{
+		{Name: "six_decimals", Field: "61234567", OutputRate: currency.Rate{Value: 1234567, Decimals: 6},
+			OutputString: "1.234567"},
+		{Name: "no_decimals", Field: "00000150", OutputRate: currency.Rate{Value: 150}, OutputString: "150"},
+		{Name: "seven_decimals", Field: "70012345", OutputRate: currency.Rate{Value: 12345, Decimals: 7},
+			OutputString: "0.0012345"},
+		{Name: "invalid_decimals", Field: "81234567", OutputError: "conversion rate '81234567' decimal position 8 exceeds 7"},
+		{Name: "short", Field: "6123456", OutputError: "conversion rate '6123456' must be 8 digits long"},
+		{Name: "non_digits", Field: "6123456A", OutputError: "conversion rate '6123456A' must be 8 digits long"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r, err := currency.ParseRate(testCase.Field)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputRate, r)
+			assert.Equal(t, testCase.OutputString, r.String())
+
+			field, err := r.Field()
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.Field, field)
+		})
+	}
+}
+
+func TestParseDecimalRate(t *testing.T) {
+	r, err := currency.ParseDecimalRate("1.2345670")
+	if assert.Nil(t, err) {
+		field, _ := r.Field()
+		assert.Equal(t, "61234567", field)
+	}
+
+	r, err = currency.ParseDecimalRate("0.0012345")
+	if assert.Nil(t, err) {
+		field, _ := r.Field()
+		assert.Equal(t, "70012345", field)
+	}
+
+	_, err = currency.ParseDecimalRate("1.23456789")
+	assert.EqualError(t, err, "conversion rate '1.23456789' does not fit in 7 digits")
+
+	_, err = currency.ParseDecimalRate("1,2")
+	assert.EqualError(t, err, "'1,2' is not a valid decimal conversion rate")
+
+	_, err = currency.Rate{Value: 12345678}.Field()
+	assert.EqualError(t, err, "conversion rate 12345678 with 0 decimals does not fit in 7 digits")
+}
+
+func TestAmount_Convert(t *testing.T) {
+	usd, _ := currency.Lookup("USD")
+	jpy, _ := currency.Lookup("JPY")
+	bhd, _ := currency.Lookup("BHD")
+
+	testList := []struct {
+		Name        string
+		Amount      currency.Amount
+		Rate        string
+		To          currency.Currency
+		OutputMinor int64
+	}{
+		// 10.50 USD * 149.5 = 1569.75 JPY, rounded to 1570.
+		{Name: "usd_to_jpy", Amount: currency.Amount{Minor: 1050, Currency: usd}, Rate: "41495000", To: jpy,
+			OutputMinor: 1570},
+		// 1570 JPY * 0.0066890 = 10.50173 USD.
+		{Name: "jpy_to_usd", Amount: currency.Amount{Minor: 1570, Currency: jpy}, Rate: "70066890", To: usd,
+			OutputMinor: 1050},
+		// 10.00 USD * 0.376 = 3.760 BHD.
+		{Name: "usd_to_bhd", Amount: currency.Amount{Minor: 1000, Currency: usd}, Rate: "30000376", To: bhd,
+			OutputMinor: 3760},
+		{Name: "same_currency", Amount: currency.Amount{Minor: 1050, Currency: usd}, Rate: "00000001", To: usd,
+			OutputMinor: 1050},
+		{Name: "negative_rounding", Amount: currency.Amount{Minor: -1050, Currency: usd}, Rate: "41495000", To: jpy,
+			OutputMinor: -1570},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r, err := currency.ParseRate(testCase.Rate)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			o, err := testCase.Amount.Convert(r, testCase.To)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputMinor, o.Minor)
+			assert.Equal(t, testCase.To, o.Currency)
+		})
+	}
+}
+
+func TestAmount_ConvertInverse(t *testing.T) {
+	usd, _ := currency.Lookup("USD")
+	jpy, _ := currency.Lookup("JPY")
+
+	r, _ := currency.ParseRate("41495000")
+
+	// 1570 JPY / 149.5 = 10.5016 USD.
+	o, err := currency.Amount{Minor: 1570, Currency: jpy}.ConvertInverse(r, usd)
+	if assert.Nil(t, err) {
+		assert.Equal(t, currency.Amount{Minor: 1050, Currency: usd}, o)
+	}
+
+	_, err = currency.Amount{Minor: 1570, Currency: jpy}.ConvertInverse(currency.Rate{}, usd)
+	assert.EqualError(t, err, "conversion rate is zero")
+
+	_, err = currency.Amount{Minor: 1 << 62, Currency: jpy}.Convert(r, usd)
+	assert.EqualError(t, err, "converted amount 68944705975489449164800 overflows int64")
+}
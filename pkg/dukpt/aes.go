@@ -0,0 +1,235 @@
+package dukpt
+
+import (
+	"crypto/aes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidKey is returned when a key length is not allowed, exported error for asserting.
+	ErrInvalidKey = errors.New("invalid key")
+	// ErrInvalidKSN is returned when a key serial number is malformed, exported error for asserting.
+	ErrInvalidKSN = errors.New("invalid key serial number")
+	// ErrCounterExhausted is returned when no transaction counter follows a KSN, exported error for asserting.
+	ErrCounterExhausted = errors.New("transaction counter exhausted")
+)
+
+const (
+	// AESKSNLength is the length in bytes of an AES DUKPT key serial number:
+	// 8 bytes of initial key ID followed by a 4 bytes transaction counter.
+	AESKSNLength = 12
+
+	// _aesMaxCounterOnes is the maximum amount of one bits of a valid AES DUKPT transaction counter.
+	_aesMaxCounterOnes = 16
+)
+
+// KeyType is the algorithm of an ANSI X9.24-3 derived key.
+type KeyType int
+
+const (
+	// KeyType2TDEA is a double length TDES key.
+	KeyType2TDEA KeyType = iota
+	// KeyType3TDEA is a triple length TDES key.
+	KeyType3TDEA
+	// KeyTypeAES128 is a 128 bits AES key.
+	KeyTypeAES128
+	// KeyTypeAES192 is a 192 bits AES key.
+	KeyTypeAES192
+	// KeyTypeAES256 is a 256 bits AES key.
+	KeyTypeAES256
+)
+
+var _keyTypeLengths = map[KeyType]int{
+	KeyType2TDEA: 16, KeyType3TDEA: 24, KeyTypeAES128: 16, KeyTypeAES192: 24, KeyTypeAES256: 32,
+}
+
+// KeyUsage is the ANSI X9.24-3 usage of a working key.
+type KeyUsage uint16
+
+const (
+	// KeyUsageKeyEncryption is a key encryption key.
+	KeyUsageKeyEncryption KeyUsage = 0x0002
+	// KeyUsagePIN is a PIN encryption key.
+	KeyUsagePIN KeyUsage = 0x1000
+	// KeyUsageMACGeneration is a MAC generation key.
+	KeyUsageMACGeneration KeyUsage = 0x2000
+	// KeyUsageMACVerification is a MAC verification key.
+	KeyUsageMACVerification KeyUsage = 0x2001
+	// KeyUsageMAC is a MAC generation and verification key.
+	KeyUsageMAC KeyUsage = 0x2002
+	// KeyUsageDataEncryption is a data encryption key.
+	KeyUsageDataEncryption KeyUsage = 0x3000
+	// KeyUsageDataDecryption is a data decryption key.
+	KeyUsageDataDecryption KeyUsage = 0x3001
+	// KeyUsageData is a data encryption and decryption key.
+	KeyUsageData KeyUsage = 0x3002
+
+	_keyUsageDerivation        KeyUsage = 0x8000
+	_keyUsageDerivationInitial KeyUsage = 0x8001
+)
+
+// InitialKey derives the ANSI X9.24-3 initial key of initialKeyID, the leftmost 8 bytes of the KSN,
+// from an AES bdk. The initial key has the type of bdk.
+func InitialKey(bdk []byte, initialKeyID []byte) ([]byte, error) {
+	keyType, err := aesKeyType(bdk)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(initialKeyID) != 8 {
+		return nil, fmt.Errorf("%w: initial key ID is %v bytes long but should be 8", ErrInvalidKSN, len(initialKeyID))
+	}
+
+	return deriveKey(bdk, derivationData(_keyUsageDerivationInitial, keyType, initialKeyID))
+}
+
+// WorkingKey derives the key of usage and type of the transaction counter of ksn from initialKey.
+func WorkingKey(initialKey []byte, ksn []byte, usage KeyUsage, keyType KeyType) ([]byte, error) {
+	derivationType, err := aesKeyType(initialKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := _keyTypeLengths[keyType]; !ok {
+		return nil, fmt.Errorf("key type %v is not supported", int(keyType))
+	}
+
+	if _keyTypeLengths[keyType] > len(initialKey) {
+		return nil, fmt.Errorf("%w: a %v bytes key can not be derived from a %v bytes initial key",
+			ErrInvalidKey, _keyTypeLengths[keyType], len(initialKey))
+	}
+
+	if len(ksn) != AESKSNLength {
+		return nil, fmt.Errorf("%w: AES KSN is %v bytes long but should be %v", ErrInvalidKSN, len(ksn), AESKSNLength)
+	}
+
+	counter := binary.BigEndian.Uint32(ksn[8:])
+	if ones(counter) > _aesMaxCounterOnes {
+		return nil, fmt.Errorf("%w: transaction counter %v has more than %v one bits",
+			ErrInvalidKSN, counter, _aesMaxCounterOnes)
+	}
+
+	// Derivation ID followed by the working counter, which is set bit by bit from the highest one.
+	register := make([]byte, 8)
+	copy(register, ksn[4:8])
+
+	key, working := initialKey, uint32(0)
+	for bit := uint32(1) << 31; bit > 0; bit >>= 1 {
+		if counter&bit == 0 {
+			continue
+		}
+
+		working |= bit
+		binary.BigEndian.PutUint32(register[4:], working)
+
+		if key, err = deriveKey(key, derivationData(_keyUsageDerivation, derivationType, register)); err != nil {
+			return nil, err
+		}
+	}
+
+	binary.BigEndian.PutUint32(register[4:], counter)
+
+	return deriveKey(key, derivationData(usage, keyType, register))
+}
+
+// DeriveAES derives the working key of ksn from bdk, it is the host side combination of InitialKey and WorkingKey.
+func DeriveAES(bdk []byte, ksn []byte, usage KeyUsage, keyType KeyType) ([]byte, error) {
+	if len(ksn) != AESKSNLength {
+		return nil, fmt.Errorf("%w: AES KSN is %v bytes long but should be %v", ErrInvalidKSN, len(ksn), AESKSNLength)
+	}
+
+	initialKey, err := InitialKey(bdk, ksn[:8])
+	if err != nil {
+		return nil, err
+	}
+
+	return WorkingKey(initialKey, ksn, usage, keyType)
+}
+
+// NextAESKSN returns ksn with the following valid transaction counter, which has at most 16 one bits.
+// ErrCounterExhausted is returned after the last one.
+func NextAESKSN(ksn []byte) ([]byte, error) {
+	if len(ksn) != AESKSNLength {
+		return nil, fmt.Errorf("%w: AES KSN is %v bytes long but should be %v", ErrInvalidKSN, len(ksn), AESKSNLength)
+	}
+
+	for counter := uint64(binary.BigEndian.Uint32(ksn[8:])) + 1; counter < 1<<32; counter++ {
+		if ones(uint32(counter)) <= _aesMaxCounterOnes {
+			next := append([]byte{}, ksn...)
+			binary.BigEndian.PutUint32(next[8:], uint32(counter))
+
+			return next, nil
+		}
+	}
+
+	return nil, ErrCounterExhausted
+}
+
+// derivationData returns the ANSI X9.24-3 derivation data of a key, for the initial key id are the 8 bytes
+// of the initial key ID, otherwise the derivation ID followed by the transaction counter.
+func derivationData(usage KeyUsage, keyType KeyType, id []byte) []byte {
+	data := make([]byte, 16)
+	data[0] = 0x01 // Version.
+	data[1] = 0x01 // Key block counter.
+	binary.BigEndian.PutUint16(data[2:], uint16(usage))
+	binary.BigEndian.PutUint16(data[4:], uint16(keyType))
+	binary.BigEndian.PutUint16(data[6:], uint16(_keyTypeLengths[keyType]*8))
+	copy(data[8:], id)
+
+	return data
+}
+
+// deriveKey enciphers as many derivation data blocks as needed for the indicated key length,
+// incrementing the key block counter of each one.
+func deriveKey(key []byte, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: AES key is %v bytes long but should be 16, 24 or 32", ErrInvalidKey, len(key))
+	}
+
+	length := int(binary.BigEndian.Uint16(data[6:])) / 8
+
+	output := make([]byte, 0, length+aes.BlockSize)
+	for n := byte(1); len(output) < length; n++ {
+		data[1] = n
+
+		result := make([]byte, aes.BlockSize)
+		block.Encrypt(result, data)
+		output = append(output, result...)
+	}
+
+	return output[:length], nil
+}
+
+func aesKeyType(key []byte) (KeyType, error) {
+	switch len(key) {
+	case 16:
+		return KeyTypeAES128, nil
+	case 24:
+		return KeyTypeAES192, nil
+	case 32:
+		return KeyTypeAES256, nil
+	}
+
+	return 0, fmt.Errorf("%w: AES key is %v bytes long but should be 16, 24 or 32", ErrInvalidKey, len(key))
+}
+
+func ones(n uint32) int {
+	var count int
+	for ; n > 0; n &= n - 1 {
+		count++
+	}
+
+	return count
+}
+
+func xor(a []byte, b []byte) []byte {
+	output := make([]byte, len(a))
+	for n := range a {
+		output[n] = a[n] ^ b[n]
+	}
+
+	return output
+}
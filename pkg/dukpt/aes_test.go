@@ -0,0 +1,98 @@
+package dukpt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/dukpt"
+)
+
+const _testAESBDK = "FEDCBA9876543210F1F1F1F1F1F1F1F1"
+
+func TestInitialKey(t *testing.T) {
+	key, err := dukpt.InitialKey(decode(_testAESBDK), decode("1234567890123456"))
+	assert.Nil(t, err)
+	assert.Equal(t, "1273671EA26AC29AFA4D1084127652A1", encode(key))
+}
+
+func TestDeriveAES(t *testing.T) {
+	key, err := dukpt.DeriveAES(decode(_testAESBDK), decode("123456789012345600000001"),
+		dukpt.KeyUsagePIN, dukpt.KeyTypeAES128)
+	assert.Nil(t, err)
+	assert.Equal(t, "AF8CB133A78F8DC2D1359F18527593FB", encode(key))
+}
+
+func TestDeriveAES_key_types(t *testing.T) {
+	testList := []struct {
+		Name   string
+		BDK    string
+		Type   dukpt.KeyType
+		Length int
+		Error  string
+	}{
+		{Name: "2tdea", BDK: _testAESBDK, Type: dukpt.KeyType2TDEA, Length: 16},
+		{Name: "aes256", BDK: _testAESBDK + _testAESBDK, Type: dukpt.KeyTypeAES256, Length: 32},
+		{Name: "3tdea_from_aes256", BDK: _testAESBDK + _testAESBDK, Type: dukpt.KeyType3TDEA, Length: 24},
+		{Name: "longer_than_initial_key", BDK: _testAESBDK, Type: dukpt.KeyTypeAES256,
+			Error: "invalid key: a 32 bytes key can not be derived from a 16 bytes initial key"},
+		{Name: "unknown_type", BDK: _testAESBDK, Type: dukpt.KeyType(7), Error: "key type 7 is not supported"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			key, err := dukpt.DeriveAES(decode(testCase.BDK), decode("123456789012345600000005"),
+				dukpt.KeyUsageData, testCase.Type)
+			if testCase.Error != "" {
+				assert.EqualError(t, err, testCase.Error)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Len(t, key, testCase.Length)
+		})
+	}
+}
+
+func TestWorkingKey_usages_differ(t *testing.T) {
+	ksn := decode("123456789012345600000003")
+	initialKey, _ := dukpt.InitialKey(decode(_testAESBDK), ksn[:8])
+
+	pin, err := dukpt.WorkingKey(initialKey, ksn, dukpt.KeyUsagePIN, dukpt.KeyTypeAES128)
+	assert.Nil(t, err)
+
+	mac, err := dukpt.WorkingKey(initialKey, ksn, dukpt.KeyUsageMACGeneration, dukpt.KeyTypeAES128)
+	assert.Nil(t, err)
+	assert.NotEqual(t, pin, mac)
+}
+
+func TestNextAESKSN(t *testing.T) {
+	next, err := dukpt.NextAESKSN(decode("123456789012345600000001"))
+	assert.Nil(t, err)
+	assert.Equal(t, "123456789012345600000002", encode(next))
+
+	next, err = dukpt.NextAESKSN(decode("12345678901234560000FFFE"))
+	assert.Nil(t, err)
+	assert.Equal(t, "12345678901234560000FFFF", encode(next))
+
+	next, err = dukpt.NextAESKSN(decode("12345678901234560000FFFF"))
+	assert.Nil(t, err)
+	assert.Equal(t, "123456789012345600010000", encode(next))
+
+	_, err = dukpt.NextAESKSN(decode("1234567890123456FFFF0000"))
+	assert.True(t, errors.Is(err, dukpt.ErrCounterExhausted), err)
+}
+
+func TestDeriveAES_errors(t *testing.T) {
+	_, err := dukpt.DeriveAES(decode(_testAESBDK), decode("1234567890123456FFFFFFFF"),
+		dukpt.KeyUsagePIN, dukpt.KeyTypeAES128)
+	assert.EqualError(t, err, "invalid key serial number: transaction counter 4294967295 has more than 16 one bits")
+
+	_, err = dukpt.DeriveAES(decode(_testBDK[:14]), decode("123456789012345600000001"),
+		dukpt.KeyUsagePIN, dukpt.KeyTypeAES128)
+	assert.EqualError(t, err, "invalid key: AES key is 7 bytes long but should be 16, 24 or 32")
+
+	_, err = dukpt.DeriveAES(decode(_testAESBDK), decode("1234567890123456"), dukpt.KeyUsagePIN, dukpt.KeyTypeAES128)
+	assert.EqualError(t, err, "invalid key serial number: AES KSN is 8 bytes long but should be 12")
+}
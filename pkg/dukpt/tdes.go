@@ -0,0 +1,221 @@
+package dukpt
+
+import (
+	"crypto/des"
+	"fmt"
+)
+
+const (
+	// TDESKSNLength is the length in bytes of a TDES DUKPT key serial number.
+	TDESKSNLength = 10
+
+	// _tdesCounterBits is the amount of transaction counter bits of a TDES KSN.
+	_tdesCounterBits = 21
+	// _tdesMaxCounterOnes is the maximum amount of one bits of the counters used by originating devices.
+	_tdesMaxCounterOnes = 10
+)
+
+// Variant selects the TDES DUKPT session key derived from a transaction key.
+type Variant int
+
+const (
+	// VariantPIN is the PIN encryption key.
+	VariantPIN Variant = iota
+	// VariantMACRequest is the MAC key of request messages.
+	VariantMACRequest
+	// VariantMACResponse is the MAC key of response messages.
+	VariantMACResponse
+	// VariantDataRequest is the data encryption key of request messages.
+	VariantDataRequest
+	// VariantDataResponse is the data encryption key of response messages.
+	VariantDataResponse
+)
+
+// _keyRegisterMask is combined with keys to derive the left half of IPEK and transaction keys.
+var _keyRegisterMask = []byte{
+	0xC0, 0xC0, 0xC0, 0xC0, 0x00, 0x00, 0x00, 0x00, 0xC0, 0xC0, 0xC0, 0xC0, 0x00, 0x00, 0x00, 0x00,
+}
+
+var _variantMasks = map[Variant][]byte{
+	VariantPIN:          {0, 0, 0, 0, 0, 0, 0, 0xFF, 0, 0, 0, 0, 0, 0, 0, 0xFF},
+	VariantMACRequest:   {0, 0, 0, 0, 0, 0, 0xFF, 0, 0, 0, 0, 0, 0, 0, 0xFF, 0},
+	VariantMACResponse:  {0, 0, 0, 0, 0xFF, 0, 0, 0, 0, 0, 0, 0, 0xFF, 0, 0, 0},
+	VariantDataRequest:  {0, 0, 0, 0, 0, 0xFF, 0, 0, 0, 0, 0, 0, 0, 0xFF, 0, 0},
+	VariantDataResponse: {0, 0, 0, 0xFF, 0, 0, 0, 0, 0, 0, 0, 0xFF, 0, 0, 0, 0},
+}
+
+// IPEK derives the ANSI X9.24-1 initial PIN encryption key of the device identified by ksn from a
+// double length bdk. The transaction counter of ksn is ignored.
+func IPEK(bdk []byte, ksn []byte) ([]byte, error) {
+	if err := checkTDES(bdk, ksn); err != nil {
+		return nil, err
+	}
+
+	serial := make([]byte, 8)
+	copy(serial, ksn[:8])
+	serial[7] &= 0xE0
+
+	left, err := tdesEncrypt(bdk, serial)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := tdesEncrypt(xor(bdk, _keyRegisterMask), serial)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(left, right...), nil
+}
+
+// TransactionKey derives the TDES DUKPT key of the transaction counter of ksn from ipek.
+func TransactionKey(ipek []byte, ksn []byte) ([]byte, error) {
+	if err := checkTDES(ipek, ksn); err != nil {
+		return nil, err
+	}
+
+	// Rightmost 64 bits of the KSN, the counter is set bit by bit from the highest one.
+	register := make([]byte, 8)
+	copy(register, ksn[2:])
+	counter := uint32(register[5]&0x1F)<<16 | uint32(register[6])<<8 | uint32(register[7])
+	register[5] &= 0xE0
+	register[6], register[7] = 0, 0
+
+	key := append([]byte{}, ipek...)
+	for bit := uint32(1) << (_tdesCounterBits - 1); bit > 0; bit >>= 1 {
+		if counter&bit == 0 {
+			continue
+		}
+
+		register[5] |= byte(bit >> 16)
+		register[6] |= byte(bit >> 8)
+		register[7] |= byte(bit)
+
+		var err error
+		if key, err = nonReversibleKey(key, register); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// SessionKey applies variant to a transaction key. Data keys are also enciphered with themselves
+// as indicated by ANSI X9.24-1 2009.
+func SessionKey(transactionKey []byte, variant Variant) ([]byte, error) {
+	mask, ok := _variantMasks[variant]
+	if !ok {
+		return nil, fmt.Errorf("variant %v is not supported", int(variant))
+	}
+
+	if len(transactionKey) != 16 {
+		return nil, fmt.Errorf("%w: TDES key is %v bytes long but should be 16", ErrInvalidKey, len(transactionKey))
+	}
+
+	key := xor(transactionKey, mask)
+	if variant != VariantDataRequest && variant != VariantDataResponse {
+		return key, nil
+	}
+
+	left, err := tdesEncrypt(key, key[:8])
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := tdesEncrypt(key, key[8:])
+	if err != nil {
+		return nil, err
+	}
+
+	return append(left, right...), nil
+}
+
+// DeriveTDES derives the session key of ksn from bdk, it is the host side combination of
+// IPEK, TransactionKey and SessionKey.
+func DeriveTDES(bdk []byte, ksn []byte, variant Variant) ([]byte, error) {
+	ipek, err := IPEK(bdk, ksn)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := TransactionKey(ipek, ksn)
+	if err != nil {
+		return nil, err
+	}
+
+	return SessionKey(key, variant)
+}
+
+// NextTDESKSN returns ksn with the following transaction counter used by originating devices,
+// which skip counters with more than 10 one bits. ErrCounterExhausted is returned after the last one.
+func NextTDESKSN(ksn []byte) ([]byte, error) {
+	if len(ksn) != TDESKSNLength {
+		return nil, fmt.Errorf("%w: TDES KSN is %v bytes long but should be %v", ErrInvalidKSN, len(ksn), TDESKSNLength)
+	}
+
+	counter := uint32(ksn[7]&0x1F)<<16 | uint32(ksn[8])<<8 | uint32(ksn[9])
+	for counter++; counter < 1<<_tdesCounterBits; counter++ {
+		if ones(counter) <= _tdesMaxCounterOnes {
+			next := append([]byte{}, ksn...)
+			next[7] = next[7]&0xE0 | byte(counter>>16)
+			next[8], next[9] = byte(counter>>8), byte(counter)
+
+			return next, nil
+		}
+	}
+
+	return nil, ErrCounterExhausted
+}
+
+// nonReversibleKey is the ANSI X9.24-1 non reversible key generation process of key and the KSN register.
+func nonReversibleKey(key []byte, register []byte) ([]byte, error) {
+	left, err := encryptRegister(xor(key, _keyRegisterMask), register)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := encryptRegister(key, register)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(left, right...), nil
+}
+
+// encryptRegister enciphers the register combined with the right half of key under its left half (single DES).
+func encryptRegister(key []byte, register []byte) ([]byte, error) {
+	block, err := des.NewCipher(key[:8])
+	if err != nil {
+		return nil, err
+	}
+
+	output := xor(register, key[8:])
+	block.Encrypt(output, output)
+
+	return xor(output, key[8:]), nil
+}
+
+func tdesEncrypt(key []byte, data []byte) ([]byte, error) {
+	// Double length keys are used as K1 K2 K1.
+	block, err := des.NewTripleDESCipher(append(append(make([]byte, 0, 24), key...), key[:8]...))
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, len(data))
+	block.Encrypt(output, data)
+
+	return output, nil
+}
+
+func checkTDES(key []byte, ksn []byte) error {
+	if len(key) != 16 {
+		return fmt.Errorf("%w: TDES key is %v bytes long but should be 16", ErrInvalidKey, len(key))
+	}
+
+	if len(ksn) != TDESKSNLength {
+		return fmt.Errorf("%w: TDES KSN is %v bytes long but should be %v", ErrInvalidKSN, len(ksn), TDESKSNLength)
+	}
+
+	return nil
+}
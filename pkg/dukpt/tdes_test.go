@@ -0,0 +1,141 @@
+package dukpt_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/dukpt"
+	"github.com/jattento/go-iso8583/pkg/pinblock"
+)
+
+const _testBDK = "0123456789ABCDEFFEDCBA9876543210"
+
+func decode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func encode(b []byte) string { return strings.ToUpper(hex.EncodeToString(b)) }
+
+func TestIPEK(t *testing.T) {
+	ipek, err := dukpt.IPEK(decode(_testBDK), decode("FFFF9876543210E00008"))
+	assert.Nil(t, err)
+	assert.Equal(t, "6AC292FAA1315B4D858AB3A3D7D5933A", encode(ipek))
+}
+
+func TestDeriveTDES_pin_blocks(t *testing.T) {
+	// ANSI X9.24-1 test vectors: PIN 1234 and PAN 4012345678909 in format 0.
+	testList := []struct {
+		KSN    string
+		Output string
+	}{
+		{KSN: "FFFF9876543210E00001", Output: "1B9C1845EB993A7A"},
+		{KSN: "FFFF9876543210E00002", Output: "10A01C8D02C69107"},
+		{KSN: "FFFF9876543210E00003", Output: "18DC07B94797B466"},
+		{KSN: "FFFF9876543210E00004", Output: "0BC79509D5645DF7"},
+		{KSN: "FFFF9876543210E00005", Output: "5BC0AF22AD87B327"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.KSN, func(t *testing.T) {
+			key, err := dukpt.DeriveTDES(decode(_testBDK), decode(testCase.KSN), dukpt.VariantPIN)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			b, err := pinblock.Encrypt(pinblock.Format0, "1234", "4012345678909", key)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.Output, encode(b))
+		})
+	}
+}
+
+func TestDeriveTDES_variants(t *testing.T) {
+	testList := []struct {
+		Name    string
+		Variant dukpt.Variant
+		Output  string
+	}{
+		{Name: "pin", Variant: dukpt.VariantPIN, Output: "042666B49184CF5C68DE9628D0397B36"},
+		{Name: "mac_request", Variant: dukpt.VariantMACRequest, Output: "042666B4918430A368DE9628D03984C9"},
+		{Name: "data_request", Variant: dukpt.VariantDataRequest, Output: "448D3F076D8304036A55A3D7E0055A78"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			key, err := dukpt.DeriveTDES(decode(_testBDK), decode("FFFF9876543210E00001"), testCase.Variant)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.Output, encode(key))
+		})
+	}
+}
+
+func TestTransactionKey_terminal_and_host(t *testing.T) {
+	// Terminals only know the IPEK, hosts derive it from the BDK: both must agree.
+	ksn := decode("FFFF9876543210E00000")
+	ipek, _ := dukpt.IPEK(decode(_testBDK), ksn)
+
+	for n := 0; n < 20; n++ {
+		var err error
+		ksn, err = dukpt.NextTDESKSN(ksn)
+		if !assert.Nil(t, err) {
+			t.FailNow()
+		}
+
+		transactionKey, err := dukpt.TransactionKey(ipek, ksn)
+		assert.Nil(t, err)
+
+		terminal, err := dukpt.SessionKey(transactionKey, dukpt.VariantMACResponse)
+		assert.Nil(t, err)
+
+		host, err := dukpt.DeriveTDES(decode(_testBDK), ksn, dukpt.VariantMACResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, host, terminal)
+	}
+}
+
+func TestNextTDESKSN(t *testing.T) {
+	testList := []struct {
+		Name        string
+		KSN         string
+		Output      string
+		OutputError error
+	}{
+		{Name: "increment", KSN: "FFFF9876543210E00001", Output: "FFFF9876543210E00002"},
+		{Name: "skip_eleven_ones", KSN: "FFFF9876543210E007FE", Output: "FFFF9876543210E00800"},
+		{Name: "exhausted", KSN: "FFFF9876543210FFFC00", OutputError: dukpt.ErrCounterExhausted},
+		{Name: "invalid_length", KSN: "FFFF9876543210E000", OutputError: dukpt.ErrInvalidKSN},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			next, err := dukpt.NextTDESKSN(decode(testCase.KSN))
+			if testCase.OutputError != nil {
+				assert.True(t, errors.Is(err, testCase.OutputError), err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.Output, encode(next))
+		})
+	}
+}
+
+func TestDeriveTDES_errors(t *testing.T) {
+	_, err := dukpt.DeriveTDES(decode("0123456789ABCDEF"), decode("FFFF9876543210E00001"), dukpt.VariantPIN)
+	assert.EqualError(t, err, "invalid key: TDES key is 8 bytes long but should be 16")
+
+	_, err = dukpt.DeriveTDES(decode(_testBDK), decode("FFFF9876543210E001"), dukpt.VariantPIN)
+	assert.EqualError(t, err, "invalid key serial number: TDES KSN is 9 bytes long but should be 10")
+
+	_, err = dukpt.DeriveTDES(decode(_testBDK), decode("FFFF9876543210E00001"), dukpt.Variant(9))
+	assert.EqualError(t, err, "variant 9 is not supported")
+}
@@ -0,0 +1,188 @@
+package emv
+
+import "strings"
+
+// Source indicates which entity provides a data object.
+type Source string
+
+const (
+	// SourceICC data objects are provided by the card.
+	SourceICC Source = "ICC"
+	// SourceTerminal data objects are provided by the terminal.
+	SourceTerminal Source = "Terminal"
+	// SourceIssuer data objects are provided by the issuer.
+	SourceIssuer Source = "Issuer"
+)
+
+// Definition describes a data object as specified by EMV Book 3 Annex A or by the card schemes.
+// Format is one of:
+// - b: binary data.
+// - n: numeric digits packed in BCD, right justified and padded with leading zeros.
+// - cn: compressed numeric, digits packed in BCD, left justified and padded with trailing 'F'.
+// - a, an, ans: alphabetic, alphanumeric and alphanumeric special characters.
+//
+// Lengths are expressed in bytes, a zero MaxLength indicates that the length is not limited.
+// Mask is the iso8583 mask policy that must be applied to the value when displayed, see iso8583.MaskPolicies.
+type Definition struct {
+	Name      string
+	Source    Source
+	Format    string
+	MinLength int
+	MaxLength int
+	Mask      string
+}
+
+// Dictionary contains the definitions of the EMV Book 3 and common scheme tags, keyed by upper case tag.
+// You can append more definitions for extended functionality.
+var Dictionary = map[string]Definition{
+	"42": {Name: "Issuer Identification Number (IIN)", Source: SourceICC, Format: "n", MinLength: 3, MaxLength: 3},
+	"4F": {Name: "Application Identifier (AID) - card", Source: SourceICC, Format: "b", MinLength: 5, MaxLength: 16},
+	"50": {Name: "Application Label", Source: SourceICC, Format: "ans", MinLength: 1, MaxLength: 16},
+	"56": {Name: "Track 1 Data", Source: SourceICC, Format: "ans", MaxLength: 76, Mask: "full"},
+	"57": {Name: "Track 2 Equivalent Data", Source: SourceICC, Format: "b", MaxLength: 19, Mask: "track"},
+	"5A": {Name: "Application Primary Account Number (PAN)", Source: SourceICC, Format: "cn", MaxLength: 10,
+		Mask: "pan"},
+	"5F20": {Name: "Cardholder Name", Source: SourceICC, Format: "ans", MinLength: 2, MaxLength: 26, Mask: "full"},
+	"5F24": {Name: "Application Expiration Date", Source: SourceICC, Format: "n", MinLength: 3, MaxLength: 3,
+		Mask: "full"},
+	"5F25": {Name: "Application Effective Date", Source: SourceICC, Format: "n", MinLength: 3, MaxLength: 3},
+	"5F28": {Name: "Issuer Country Code", Source: SourceICC, Format: "n", MinLength: 2, MaxLength: 2},
+	"5F2A": {Name: "Transaction Currency Code", Source: SourceTerminal, Format: "n", MinLength: 2, MaxLength: 2},
+	"5F2D": {Name: "Language Preference", Source: SourceICC, Format: "an", MinLength: 2, MaxLength: 8},
+	"5F30": {Name: "Service Code", Source: SourceICC, Format: "n", MinLength: 2, MaxLength: 2},
+	"5F34": {Name: "Application PAN Sequence Number", Source: SourceICC, Format: "n", MinLength: 1, MaxLength: 1},
+	"5F36": {Name: "Transaction Currency Exponent", Source: SourceTerminal, Format: "n", MinLength: 1, MaxLength: 1},
+	"5F50": {Name: "Issuer URL", Source: SourceICC, Format: "ans"},
+	"61":   {Name: "Application Template", Source: SourceICC, Format: "b", MaxLength: 252},
+	"6F":   {Name: "File Control Information (FCI) Template", Source: SourceICC, Format: "b", MaxLength: 252},
+	"70":   {Name: "READ RECORD Response Message Template", Source: SourceICC, Format: "b", MaxLength: 253},
+	"71":   {Name: "Issuer Script Template 1", Source: SourceIssuer, Format: "b"},
+	"72":   {Name: "Issuer Script Template 2", Source: SourceIssuer, Format: "b"},
+	"77":   {Name: "Response Message Template Format 2", Source: SourceICC, Format: "b"},
+	"80":   {Name: "Response Message Template Format 1", Source: SourceICC, Format: "b"},
+	"82":   {Name: "Application Interchange Profile", Source: SourceICC, Format: "b", MinLength: 2, MaxLength: 2},
+	"84":   {Name: "Dedicated File (DF) Name", Source: SourceICC, Format: "b", MinLength: 5, MaxLength: 16},
+	"86":   {Name: "Issuer Script Command", Source: SourceIssuer, Format: "b", MaxLength: 261},
+	"87":   {Name: "Application Priority Indicator", Source: SourceICC, Format: "b", MinLength: 1, MaxLength: 1},
+	"88":   {Name: "Short File Identifier (SFI)", Source: SourceICC, Format: "b", MinLength: 1, MaxLength: 1},
+	"8A":   {Name: "Authorisation Response Code", Source: SourceIssuer, Format: "an", MinLength: 2, MaxLength: 2},
+	"8C": {Name: "Card Risk Management Data Object List 1 (CDOL1)", Source: SourceICC, Format: "b",
+		MaxLength: 252},
+	"8D": {Name: "Card Risk Management Data Object List 2 (CDOL2)", Source: SourceICC, Format: "b",
+		MaxLength: 252},
+	"8E": {Name: "Cardholder Verification Method (CVM) List", Source: SourceICC, Format: "b", MinLength: 10,
+		MaxLength: 252},
+	"8F": {Name: "Certification Authority Public Key Index", Source: SourceICC, Format: "b", MinLength: 1,
+		MaxLength: 1},
+	"90": {Name: "Issuer Public Key Certificate", Source: SourceICC, Format: "b"},
+	"91": {Name: "Issuer Authentication Data", Source: SourceIssuer, Format: "b", MinLength: 8, MaxLength: 16},
+	"92": {Name: "Issuer Public Key Remainder", Source: SourceICC, Format: "b"},
+	"93": {Name: "Signed Static Application Data", Source: SourceICC, Format: "b"},
+	"94": {Name: "Application File Locator (AFL)", Source: SourceICC, Format: "b", MaxLength: 252},
+	"95": {Name: "Terminal Verification Results", Source: SourceTerminal, Format: "b", MinLength: 5, MaxLength: 5},
+	"97": {Name: "Transaction Certificate Data Object List (TDOL)", Source: SourceICC, Format: "b",
+		MaxLength: 252},
+	"98": {Name: "Transaction Certificate (TC) Hash Value", Source: SourceTerminal, Format: "b", MinLength: 20,
+		MaxLength: 20},
+	"99": {Name: "Transaction Personal Identification Number (PIN) Data", Source: SourceTerminal, Format: "b",
+		Mask: "full"},
+	"9A":   {Name: "Transaction Date", Source: SourceTerminal, Format: "n", MinLength: 3, MaxLength: 3},
+	"9B":   {Name: "Transaction Status Information", Source: SourceTerminal, Format: "b", MinLength: 2, MaxLength: 2},
+	"9C":   {Name: "Transaction Type", Source: SourceTerminal, Format: "n", MinLength: 1, MaxLength: 1},
+	"9D":   {Name: "Directory Definition File (DDF) Name", Source: SourceICC, Format: "b", MinLength: 5, MaxLength: 16},
+	"9F01": {Name: "Acquirer Identifier", Source: SourceTerminal, Format: "n", MinLength: 6, MaxLength: 6},
+	"9F02": {Name: "Amount, Authorised (Numeric)", Source: SourceTerminal, Format: "n", MinLength: 6, MaxLength: 6},
+	"9F03": {Name: "Amount, Other (Numeric)", Source: SourceTerminal, Format: "n", MinLength: 6, MaxLength: 6},
+	"9F04": {Name: "Amount, Other (Binary)", Source: SourceTerminal, Format: "b", MinLength: 4, MaxLength: 4},
+	"9F05": {Name: "Application Discretionary Data", Source: SourceICC, Format: "b", MinLength: 1, MaxLength: 32},
+	"9F06": {Name: "Application Identifier (AID) - terminal", Source: SourceTerminal, Format: "b", MinLength: 5,
+		MaxLength: 16},
+	"9F07": {Name: "Application Usage Control", Source: SourceICC, Format: "b", MinLength: 2, MaxLength: 2},
+	"9F08": {Name: "Application Version Number - card", Source: SourceICC, Format: "b", MinLength: 2, MaxLength: 2},
+	"9F09": {Name: "Application Version Number - terminal", Source: SourceTerminal, Format: "b", MinLength: 2,
+		MaxLength: 2},
+	"9F0B": {Name: "Cardholder Name Extended", Source: SourceICC, Format: "ans", MinLength: 27, MaxLength: 45,
+		Mask: "full"},
+	"9F0D": {Name: "Issuer Action Code - Default", Source: SourceICC, Format: "b", MinLength: 5, MaxLength: 5},
+	"9F0E": {Name: "Issuer Action Code - Denial", Source: SourceICC, Format: "b", MinLength: 5, MaxLength: 5},
+	"9F0F": {Name: "Issuer Action Code - Online", Source: SourceICC, Format: "b", MinLength: 5, MaxLength: 5},
+	"9F10": {Name: "Issuer Application Data", Source: SourceICC, Format: "b", MaxLength: 32},
+	"9F11": {Name: "Issuer Code Table Index", Source: SourceICC, Format: "n", MinLength: 1, MaxLength: 1},
+	"9F12": {Name: "Application Preferred Name", Source: SourceICC, Format: "ans", MinLength: 1, MaxLength: 16},
+	"9F13": {Name: "Last Online Application Transaction Counter (ATC) Register", Source: SourceICC, Format: "b",
+		MinLength: 2, MaxLength: 2},
+	"9F14": {Name: "Lower Consecutive Offline Limit", Source: SourceICC, Format: "b", MinLength: 1, MaxLength: 1},
+	"9F15": {Name: "Merchant Category Code", Source: SourceTerminal, Format: "n", MinLength: 2, MaxLength: 2},
+	"9F16": {Name: "Merchant Identifier", Source: SourceTerminal, Format: "ans", MinLength: 15, MaxLength: 15},
+	"9F17": {Name: "Personal Identification Number (PIN) Try Counter", Source: SourceICC, Format: "b",
+		MinLength: 1, MaxLength: 1},
+	"9F18": {Name: "Issuer Script Identifier", Source: SourceIssuer, Format: "b", MinLength: 4, MaxLength: 4},
+	"9F1A": {Name: "Terminal Country Code", Source: SourceTerminal, Format: "n", MinLength: 2, MaxLength: 2},
+	"9F1B": {Name: "Terminal Floor Limit", Source: SourceTerminal, Format: "b", MinLength: 4, MaxLength: 4},
+	"9F1C": {Name: "Terminal Identification", Source: SourceTerminal, Format: "an", MinLength: 8, MaxLength: 8},
+	"9F1D": {Name: "Terminal Risk Management Data", Source: SourceTerminal, Format: "b", MinLength: 1,
+		MaxLength: 8},
+	"9F1E": {Name: "Interface Device (IFD) Serial Number", Source: SourceTerminal, Format: "an", MinLength: 8,
+		MaxLength: 8},
+	"9F1F": {Name: "Track 1 Discretionary Data", Source: SourceICC, Format: "ans", Mask: "full"},
+	"9F20": {Name: "Track 2 Discretionary Data", Source: SourceICC, Format: "cn", Mask: "full"},
+	"9F21": {Name: "Transaction Time", Source: SourceTerminal, Format: "n", MinLength: 3, MaxLength: 3},
+	"9F22": {Name: "Certification Authority Public Key Index - terminal", Source: SourceTerminal, Format: "b",
+		MinLength: 1, MaxLength: 1},
+	"9F23": {Name: "Upper Consecutive Offline Limit", Source: SourceICC, Format: "b", MinLength: 1, MaxLength: 1},
+	"9F26": {Name: "Application Cryptogram", Source: SourceICC, Format: "b", MinLength: 8, MaxLength: 8},
+	"9F27": {Name: "Cryptogram Information Data", Source: SourceICC, Format: "b", MinLength: 1, MaxLength: 1},
+	"9F2D": {Name: "ICC PIN Encipherment Public Key Certificate", Source: SourceICC, Format: "b"},
+	"9F32": {Name: "Issuer Public Key Exponent", Source: SourceICC, Format: "b", MinLength: 1, MaxLength: 3},
+	"9F33": {Name: "Terminal Capabilities", Source: SourceTerminal, Format: "b", MinLength: 3, MaxLength: 3},
+	"9F34": {Name: "Cardholder Verification Method (CVM) Results", Source: SourceTerminal, Format: "b",
+		MinLength: 3, MaxLength: 3},
+	"9F35": {Name: "Terminal Type", Source: SourceTerminal, Format: "n", MinLength: 1, MaxLength: 1},
+	"9F36": {Name: "Application Transaction Counter (ATC)", Source: SourceICC, Format: "b", MinLength: 2,
+		MaxLength: 2},
+	"9F37": {Name: "Unpredictable Number", Source: SourceTerminal, Format: "b", MinLength: 4, MaxLength: 4},
+	"9F38": {Name: "Processing Options Data Object List (PDOL)", Source: SourceICC, Format: "b"},
+	"9F39": {Name: "Point-of-Service (POS) Entry Mode", Source: SourceTerminal, Format: "n", MinLength: 1,
+		MaxLength: 1},
+	"9F3A": {Name: "Amount, Reference Currency", Source: SourceTerminal, Format: "b", MinLength: 4, MaxLength: 4},
+	"9F3B": {Name: "Application Reference Currency", Source: SourceICC, Format: "n", MinLength: 2, MaxLength: 8},
+	"9F3C": {Name: "Transaction Reference Currency Code", Source: SourceTerminal, Format: "n", MinLength: 2,
+		MaxLength: 2},
+	"9F3D": {Name: "Transaction Reference Currency Exponent", Source: SourceTerminal, Format: "n", MinLength: 1,
+		MaxLength: 1},
+	"9F40": {Name: "Additional Terminal Capabilities", Source: SourceTerminal, Format: "b", MinLength: 5,
+		MaxLength: 5},
+	"9F41": {Name: "Transaction Sequence Counter", Source: SourceTerminal, Format: "n", MinLength: 2, MaxLength: 4},
+	"9F42": {Name: "Application Currency Code", Source: SourceICC, Format: "n", MinLength: 2, MaxLength: 2},
+	"9F43": {Name: "Application Reference Currency Exponent", Source: SourceICC, Format: "n", MinLength: 1,
+		MaxLength: 4},
+	"9F44": {Name: "Application Currency Exponent", Source: SourceICC, Format: "n", MinLength: 1, MaxLength: 1},
+	"9F45": {Name: "Data Authentication Code", Source: SourceICC, Format: "b", MinLength: 2, MaxLength: 2},
+	"9F46": {Name: "ICC Public Key Certificate", Source: SourceICC, Format: "b"},
+	"9F47": {Name: "ICC Public Key Exponent", Source: SourceICC, Format: "b", MinLength: 1, MaxLength: 3},
+	"9F48": {Name: "ICC Public Key Remainder", Source: SourceICC, Format: "b"},
+	"9F49": {Name: "Dynamic Data Authentication Data Object List (DDOL)", Source: SourceICC, Format: "b",
+		MaxLength: 252},
+	"9F4A": {Name: "Static Data Authentication Tag List", Source: SourceICC, Format: "b"},
+	"9F4B": {Name: "Signed Dynamic Application Data", Source: SourceICC, Format: "b"},
+	"9F4C": {Name: "ICC Dynamic Number", Source: SourceICC, Format: "b", MinLength: 2, MaxLength: 8},
+	"9F4D": {Name: "Log Entry", Source: SourceICC, Format: "b", MinLength: 2, MaxLength: 2},
+	"9F4E": {Name: "Merchant Name and Location", Source: SourceTerminal, Format: "ans"},
+	"9F4F": {Name: "Log Format", Source: SourceICC, Format: "b"},
+	"9F53": {Name: "Transaction Category Code", Source: SourceTerminal, Format: "an", MinLength: 1, MaxLength: 1},
+	"9F5B": {Name: "Issuer Script Results", Source: SourceTerminal, Format: "b"},
+	"9F66": {Name: "Terminal Transaction Qualifiers (TTQ)", Source: SourceTerminal, Format: "b", MinLength: 4,
+		MaxLength: 4},
+	"9F6B": {Name: "Track 2 Data", Source: SourceICC, Format: "b", MaxLength: 19, Mask: "track"},
+	"9F6C": {Name: "Card Transaction Qualifiers (CTQ)", Source: SourceICC, Format: "b", MinLength: 2,
+		MaxLength: 2},
+	"9F6E": {Name: "Form Factor Indicator / Third Party Data", Source: SourceICC, Format: "b", MinLength: 4,
+		MaxLength: 32},
+	"9F7C": {Name: "Customer Exclusive Data", Source: SourceICC, Format: "b", MaxLength: 32},
+}
+
+// Lookup returns the definition of a tag, the returned bool indicates if the tag is defined.
+func Lookup(tag string) (Definition, bool) {
+	definition, exist := Dictionary[strings.ToUpper(tag)]
+	return definition, exist
+}
@@ -0,0 +1,108 @@
+package emv
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Masker masks a displayed value using the mask policy of its definition,
+// iso8583.MaskValue can be used as implementation. Values are only given to the masker if its
+// definition has a mask policy.
+type Masker func(policy string, value string) (string, error)
+
+// Dump returns a human readable representation of l, one object per line with its dictionary name.
+// Children of constructed objects are indented. Values of a, an and ans formats are displayed as text,
+// the rest in upper case hexadecimal. If mask is nil values are not masked.
+// For example:
+// 	9F26   Application Cryptogram: 1122334455667788
+// 	5A     Application Primary Account Number (PAN): 540000******0011
+func Dump(l List, mask Masker) (string, error) {
+	var buf bytes.Buffer
+	if err := dump(&buf, l, mask, ""); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func dump(buf *bytes.Buffer, l List, mask Masker, indent string) error {
+	for _, object := range l {
+		definition, exist := Lookup(object.Tag)
+		if !exist {
+			definition.Name = "Unknown"
+		}
+
+		if object.IsConstructed() {
+			fmt.Fprintf(buf, "%s%-6s %s\n", indent, object.Tag, definition.Name)
+			if err := dump(buf, object.Children, mask, indent+"  "); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value := fmt.Sprintf("%X", object.Value)
+		if strings.HasPrefix(definition.Format, "a") {
+			value = string(object.Value)
+		}
+
+		value, err := maskValue(mask, definition, value)
+		if err != nil {
+			return fmt.Errorf("tag %s: %w", object.Tag, err)
+		}
+
+		fmt.Fprintf(buf, "%s%-6s %s: %s\n", indent, object.Tag, definition.Name, value)
+	}
+
+	return nil
+}
+
+// MaskedHex returns the BER-TLV representation of l in upper case hexadecimal with the
+// hexadecimal values of sensitive objects masked, tags and lengths are kept.
+// For example with iso8583.MaskValue as masker:
+// 	9F260211225A08540000******0011
+func MaskedHex(l List, mask Masker) (string, error) {
+	var buf bytes.Buffer
+
+	for _, object := range l {
+		tag, err := encodeTag(object.Tag)
+		if err != nil {
+			return "", err
+		}
+
+		value := object.Value
+		if object.IsConstructed() {
+			if value, err = object.Children.Encode(); err != nil {
+				return "", fmt.Errorf("tag %s: %w", object.Tag, err)
+			}
+		}
+
+		fmt.Fprintf(&buf, "%X%X", tag, encodeLength(len(value)))
+
+		if object.IsConstructed() {
+			children, err := MaskedHex(object.Children, mask)
+			if err != nil {
+				return "", fmt.Errorf("tag %s: %w", object.Tag, err)
+			}
+			buf.WriteString(children)
+			continue
+		}
+
+		definition, _ := Lookup(object.Tag)
+		masked, err := maskValue(mask, definition, fmt.Sprintf("%X", value))
+		if err != nil {
+			return "", fmt.Errorf("tag %s: %w", object.Tag, err)
+		}
+		buf.WriteString(masked)
+	}
+
+	return buf.String(), nil
+}
+
+func maskValue(mask Masker, definition Definition, value string) (string, error) {
+	if mask == nil || definition.Mask == "" {
+		return value, nil
+	}
+
+	return mask(definition.Mask, value)
+}
@@ -0,0 +1,87 @@
+package emv_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/emv"
+)
+
+// maskAll is a masker which replaces every character with '*' and fails on unknown policies.
+func maskAll(policy string, value string) (string, error) {
+	if policy == "unknown" {
+		return "", errors.New("unknown policy")
+	}
+
+	return policy + ":" + strings.Repeat("*", len(value)), nil
+}
+
+var dumpTestList = emv.List{
+	{Tag: "9F26", Value: []byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}},
+	{Tag: "70", Children: emv.List{
+		{Tag: "5A", Value: []byte{0x54, 0x00, 0x11}},
+		{Tag: "5F20", Value: []byte("DOE/JOHN")},
+	}},
+	{Tag: "9F1C", Value: []byte("TERM0001")},
+	{Tag: "DF01", Value: []byte{0x01}},
+}
+
+func TestDump(t *testing.T) {
+	o, err := emv.Dump(dumpTestList, maskAll)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "9F26   Application Cryptogram: 1122334455667788\n"+
+		"70     READ RECORD Response Message Template\n"+
+		"  5A     Application Primary Account Number (PAN): pan:******\n"+
+		"  5F20   Cardholder Name: full:********\n"+
+		"9F1C   Terminal Identification: TERM0001\n"+
+		"DF01   Unknown: 01\n", o)
+
+	o, err = emv.Dump(dumpTestList, nil)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Contains(t, o, "  5F20   Cardholder Name: DOE/JOHN\n")
+}
+
+func TestMaskedHex(t *testing.T) {
+	o, err := emv.MaskedHex(dumpTestList, maskAll)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "9F2608112233445566778870"+"10"+"5A03pan:******"+"5F2008full:****************"+
+		"9F1C085445524D30303031"+"DF010101", o)
+
+	_, err = emv.MaskedHex(emv.List{{Tag: "9F", Value: []byte{0x01}}}, maskAll)
+	assert.True(t, errors.Is(err, emv.ErrInvalidTag))
+
+	emv.Dictionary["DF01"] = emv.Definition{Name: "Test", Format: "b", Mask: "unknown"}
+	defer delete(emv.Dictionary, "DF01")
+
+	_, err = emv.MaskedHex(dumpTestList, maskAll)
+	assert.EqualError(t, err, "tag DF01: unknown policy")
+
+	_, err = emv.Dump(dumpTestList, maskAll)
+	assert.EqualError(t, err, "tag DF01: unknown policy")
+}
+
+func TestDictionary_sensitive_tags(t *testing.T) {
+	for tag, mask := range map[string]string{
+		"56":   "full",
+		"57":   "track",
+		"5A":   "pan",
+		"5F20": "full",
+		"5F24": "full",
+		"9F1F": "full",
+		"9F20": "full",
+		"9F6B": "track",
+	} {
+		assert.Equal(t, mask, emv.Dictionary[tag].Mask, "tag %s", tag)
+	}
+}
@@ -0,0 +1,311 @@
+package emv
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	_constructedBit   = 0x20
+	_multiByteTagMask = 0x1F
+	_moreTagBytesBit  = 0x80
+	_longLengthBit    = 0x80
+
+	// _maxLengthBytes is the maximum amount of subsequent length bytes supported.
+	_maxLengthBytes = 4
+)
+
+var (
+	// ErrTruncated exported error for asserting.
+	ErrTruncated = errors.New("truncated data object")
+	// ErrInvalidTag exported error for asserting.
+	ErrInvalidTag = errors.New("invalid tag")
+	// ErrInvalidLength exported error for asserting.
+	ErrInvalidLength = errors.New("invalid length")
+)
+
+// Object is a BER-TLV data object.
+// Tag is represented in upper case hexadecimal, for example "9F26".
+// Constructed objects (like "70" or "77") keep its content in Children and ignore Value.
+type Object struct {
+	Tag      string
+	Value    []byte
+	Children List
+}
+
+// IsConstructed indicates if the tag is of a constructed data object.
+func (o Object) IsConstructed() bool {
+	return IsConstructed(o.Tag)
+}
+
+// IsConstructed indicates if the tag is of a constructed data object.
+// Invalid tags are considered primitive.
+func IsConstructed(tag string) bool {
+	b, err := hex.DecodeString(tag)
+	if err != nil || len(b) == 0 {
+		return false
+	}
+
+	return b[0]&_constructedBit != 0
+}
+
+// List is an ordered sequence of BER-TLV data objects, order is preserved on decoding and encoding.
+type List []Object
+
+// Decode parses BER-TLV data objects. Constructed objects are parsed recursively.
+// Padding bytes (0x00) between objects are ignored.
+func Decode(b []byte) (List, error) {
+	list := make(List, 0)
+
+	for offset := 0; offset < len(b); {
+		if b[offset] == 0x00 {
+			offset++
+			continue
+		}
+
+		object, n, err := decodeObject(b[offset:])
+		if err != nil {
+			return nil, fmt.Errorf("offset %v: %w", offset, err)
+		}
+
+		list = append(list, object)
+		offset += n
+	}
+
+	return list, nil
+}
+
+// decodeObject reads a single data object and returns the amount of consumed bytes.
+func decodeObject(b []byte) (Object, int, error) {
+	tag, tagLength, err := decodeTag(b)
+	if err != nil {
+		return Object{}, 0, err
+	}
+
+	valueLength, lengthLength, err := decodeLength(b[tagLength:])
+	if err != nil {
+		return Object{}, 0, fmt.Errorf("tag %X: %w", tag, err)
+	}
+
+	start := tagLength + lengthLength
+	if len(b)-start < valueLength {
+		return Object{}, 0, fmt.Errorf("tag %X: %w: value should be %v bytes long but only %v bytes are available",
+			tag, ErrTruncated, valueLength, len(b)-start)
+	}
+
+	object := Object{Tag: fmt.Sprintf("%X", tag)}
+	value := b[start : start+valueLength]
+
+	if tag[0]&_constructedBit != 0 {
+		object.Children, err = Decode(value)
+		if err != nil {
+			return Object{}, 0, fmt.Errorf("tag %X: %w", tag, err)
+		}
+	} else {
+		object.Value = make([]byte, len(value))
+		copy(object.Value, value)
+	}
+
+	return object, start + valueLength, nil
+}
+
+// decodeTag reads a single or multi byte tag.
+func decodeTag(b []byte) ([]byte, int, error) {
+	if len(b) == 0 {
+		return nil, 0, fmt.Errorf("%w: missing tag", ErrTruncated)
+	}
+
+	n := 1
+	if b[0]&_multiByteTagMask == _multiByteTagMask {
+		for {
+			if n >= len(b) {
+				return nil, 0, fmt.Errorf("%w: tag %X is incomplete", ErrTruncated, b)
+			}
+
+			n++
+			if b[n-1]&_moreTagBytesBit == 0 {
+				break
+			}
+		}
+	}
+
+	return b[:n], n, nil
+}
+
+// decodeLength reads a short or long form length and returns the amount of length bytes.
+func decodeLength(b []byte) (int, int, error) {
+	if len(b) == 0 {
+		return 0, 0, fmt.Errorf("%w: missing length", ErrTruncated)
+	}
+
+	if b[0]&_longLengthBit == 0 {
+		return int(b[0]), 1, nil
+	}
+
+	lengthBytes := int(b[0] &^ _longLengthBit)
+	if lengthBytes == 0 || lengthBytes > _maxLengthBytes {
+		return 0, 0, fmt.Errorf("%w: unsupported length form %X", ErrInvalidLength, b[0])
+	}
+
+	if len(b) < 1+lengthBytes {
+		return 0, 0, fmt.Errorf("%w: length is incomplete", ErrTruncated)
+	}
+
+	// Accumulated in a uint64 and checked against the available bytes before converting,
+	// as a 4 bytes length does not fit in an int of 32 bits platforms.
+	var length uint64
+	for _, byt := range b[1 : 1+lengthBytes] {
+		length = length<<8 | uint64(byt)
+	}
+
+	if available := len(b) - 1 - lengthBytes; length > uint64(available) {
+		return 0, 0, fmt.Errorf("%w: value should be %v bytes long but only %v bytes are available",
+			ErrTruncated, length, available)
+	}
+
+	return int(length), 1 + lengthBytes, nil
+}
+
+// Encode builds the BER-TLV representation of the list keeping its order.
+func (l List) Encode() ([]byte, error) {
+	output := make([]byte, 0)
+
+	for _, object := range l {
+		b, err := object.Encode()
+		if err != nil {
+			return nil, err
+		}
+
+		output = append(output, b...)
+	}
+
+	return output, nil
+}
+
+// Encode builds the BER-TLV representation of the object.
+func (o Object) Encode() ([]byte, error) {
+	tag, err := encodeTag(o.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	value := o.Value
+	if tag[0]&_constructedBit != 0 {
+		if value, err = o.Children.Encode(); err != nil {
+			return nil, fmt.Errorf("tag %s: %w", o.Tag, err)
+		}
+	}
+
+	return append(append(tag, encodeLength(len(value))...), value...), nil
+}
+
+// encodeTag validates a hexadecimal tag and returns its bytes.
+func encodeTag(tag string) ([]byte, error) {
+	b, err := hex.DecodeString(tag)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("%w: '%s' is not hexadecimal", ErrInvalidTag, tag)
+	}
+
+	decoded, n, err := decodeTag(b)
+	if err != nil || n != len(b) {
+		return nil, fmt.Errorf("%w: '%s' is not a valid BER-TLV tag", ErrInvalidTag, tag)
+	}
+
+	return decoded, nil
+}
+
+// encodeLength returns the short form for lengths up to 127 and the long form for the rest.
+func encodeLength(length int) []byte {
+	if length < _longLengthBit {
+		return []byte{byte(length)}
+	}
+
+	b := make([]byte, 0, _maxLengthBytes)
+	for ; length > 0; length >>= 8 {
+		b = append([]byte{byte(length)}, b...)
+	}
+
+	return append([]byte{_longLengthBit | byte(len(b))}, b...)
+}
+
+// Get returns the value of the first object with the indicated tag, searching constructed objects
+// in depth. The returned bool indicates if the tag was found.
+// The value of constructed objects is its encoded children.
+func (l List) Get(tag string) ([]byte, bool) {
+	object, found := l.Find(tag)
+	if !found {
+		return nil, false
+	}
+
+	if object.IsConstructed() {
+		// Children were already validated while decoding or setting.
+		b, _ := object.Children.Encode()
+		return b, true
+	}
+
+	return object.Value, true
+}
+
+// Find returns the first object with the indicated tag, searching constructed objects in depth.
+func (l List) Find(tag string) (Object, bool) {
+	tag = strings.ToUpper(tag)
+
+	for _, object := range l {
+		if object.Tag == tag {
+			return object, true
+		}
+
+		if object.IsConstructed() {
+			if child, found := object.Children.Find(tag); found {
+				return child, true
+			}
+		}
+	}
+
+	return Object{}, false
+}
+
+// Set replaces the value of the first top level object with the indicated tag keeping its position,
+// if the tag is not present the object is appended. Values of constructed tags are decoded as children.
+func (l *List) Set(tag string, value []byte) error {
+	b, err := encodeTag(tag)
+	if err != nil {
+		return err
+	}
+
+	object := Object{Tag: fmt.Sprintf("%X", b)}
+	if object.IsConstructed() {
+		if object.Children, err = Decode(value); err != nil {
+			return fmt.Errorf("tag %s: %w", object.Tag, err)
+		}
+	} else {
+		object.Value = make([]byte, len(value))
+		copy(object.Value, value)
+	}
+
+	for n := range *l {
+		if (*l)[n].Tag == object.Tag {
+			(*l)[n] = object
+			return nil
+		}
+	}
+
+	*l = append(*l, object)
+	return nil
+}
+
+// Delete removes all top level objects with the indicated tag.
+func (l *List) Delete(tag string) {
+	tag = strings.ToUpper(tag)
+
+	output := (*l)[:0]
+	for _, object := range *l {
+		if object.Tag != tag {
+			output = append(output, object)
+		}
+	}
+
+	*l = output
+}
@@ -0,0 +1,214 @@
+package emv_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/emv"
+)
+
+func TestDecode(t *testing.T) {
+	testList := []struct {
+		name          string
+		input         []byte
+		expectedList  emv.List
+		expectedError error
+	}{
+		{
+			name: "primitive_objects_keep_order",
+			input: []byte{0x9F, 0x26, 0x02, 0x11, 0x22, 0x5F, 0x2A, 0x02, 0x09, 0x78,
+				0x9F, 0x02, 0x06, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00},
+			expectedList: emv.List{
+				{Tag: "9F26", Value: []byte{0x11, 0x22}},
+				{Tag: "5F2A", Value: []byte{0x09, 0x78}},
+				{Tag: "9F02", Value: []byte{0x00, 0x00, 0x00, 0x00, 0x10, 0x00}},
+			},
+		},
+		{
+			name:  "three_bytes_tag",
+			input: []byte{0xDF, 0x81, 0x01, 0x01, 0xFF},
+			expectedList: emv.List{
+				{Tag: "DF8101", Value: []byte{0xFF}},
+			},
+		},
+		{
+			name:  "constructed",
+			input: []byte{0x70, 0x07, 0x5A, 0x02, 0x54, 0x00, 0x9F, 0x36, 0x00, 0x82, 0x01, 0x01},
+			expectedList: emv.List{
+				{Tag: "70", Children: emv.List{
+					{Tag: "5A", Value: []byte{0x54, 0x00}},
+					{Tag: "9F36", Value: []byte{}},
+				}},
+				{Tag: "82", Value: []byte{0x01}},
+			},
+		},
+		{
+			name:  "long_length",
+			input: append([]byte{0x9F, 0x10, 0x81, 0x80}, bytes.Repeat([]byte{0x01}, 128)...),
+			expectedList: emv.List{
+				{Tag: "9F10", Value: bytes.Repeat([]byte{0x01}, 128)},
+			},
+		},
+		{
+			name:  "padding",
+			input: []byte{0x82, 0x01, 0x01, 0x00, 0x00, 0x84, 0x00},
+			expectedList: emv.List{
+				{Tag: "82", Value: []byte{0x01}},
+				{Tag: "84", Value: []byte{}},
+			},
+		},
+		{
+			name:          "truncated_value",
+			input:         []byte{0x9F, 0x26, 0x08, 0x11},
+			expectedError: emv.ErrTruncated,
+		},
+		{
+			name:          "truncated_tag",
+			input:         []byte{0x9F},
+			expectedError: emv.ErrTruncated,
+		},
+		{
+			name:          "truncated_length",
+			input:         []byte{0x9F, 0x10, 0x82, 0x01},
+			expectedError: emv.ErrTruncated,
+		},
+		{
+			name:          "four_bytes_length",
+			input:         []byte{0x9F, 0x10, 0x84, 0xFF, 0xFF, 0xFF, 0xFF, 0x01},
+			expectedError: emv.ErrTruncated,
+		},
+		{
+			name:          "indefinite_length",
+			input:         []byte{0x9F, 0x10, 0x80},
+			expectedError: emv.ErrInvalidLength,
+		},
+		{
+			name:          "truncated_child",
+			input:         []byte{0x70, 0x03, 0x5A, 0x05, 0x01},
+			expectedError: emv.ErrTruncated,
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.name, func(t *testing.T) {
+			list, err := emv.Decode(testCase.input)
+			if testCase.expectedError != nil {
+				assert.True(t, errors.Is(err, testCase.expectedError), "unexpected error: %v", err)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.expectedList, list)
+		})
+	}
+}
+
+func TestList_Encode(t *testing.T) {
+	testList := []struct {
+		name          string
+		input         emv.List
+		expectedBytes []byte
+		expectedError error
+	}{
+		{
+			name: "primitive_and_constructed",
+			input: emv.List{
+				{Tag: "9F26", Value: []byte{0x11, 0x22}},
+				{Tag: "70", Children: emv.List{{Tag: "5a", Value: []byte{0x54}}}},
+			},
+			expectedBytes: []byte{0x9F, 0x26, 0x02, 0x11, 0x22, 0x70, 0x03, 0x5A, 0x01, 0x54},
+		},
+		{
+			name:          "long_length",
+			input:         emv.List{{Tag: "9F10", Value: bytes.Repeat([]byte{0x01}, 256)}},
+			expectedBytes: append([]byte{0x9F, 0x10, 0x82, 0x01, 0x00}, bytes.Repeat([]byte{0x01}, 256)...),
+		},
+		{
+			name:          "not_hexadecimal_tag",
+			input:         emv.List{{Tag: "9G", Value: []byte{0x01}}},
+			expectedError: emv.ErrInvalidTag,
+		},
+		{
+			name:          "incomplete_tag",
+			input:         emv.List{{Tag: "9F", Value: []byte{0x01}}},
+			expectedError: emv.ErrInvalidTag,
+		},
+		{
+			name:          "too_long_tag",
+			input:         emv.List{{Tag: "5A01", Value: []byte{0x01}}},
+			expectedError: emv.ErrInvalidTag,
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.name, func(t *testing.T) {
+			b, err := testCase.input.Encode()
+			if testCase.expectedError != nil {
+				assert.True(t, errors.Is(err, testCase.expectedError), "unexpected error: %v", err)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.expectedBytes, b)
+		})
+	}
+}
+
+func TestList_RoundTrip(t *testing.T) {
+	input := []byte{0x5F, 0x2A, 0x02, 0x09, 0x78, 0x77, 0x08, 0x9F, 0x27, 0x01, 0x80, 0x9F, 0x36, 0x01, 0x05,
+		0x9F, 0x26, 0x01, 0xAA}
+
+	list, err := emv.Decode(input)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	output, err := list.Encode()
+	assert.Nil(t, err)
+	assert.Equal(t, input, output)
+}
+
+func TestList_GetSetDelete(t *testing.T) {
+	list := emv.List{
+		{Tag: "9F26", Value: []byte{0x11}},
+		{Tag: "77", Children: emv.List{{Tag: "9F36", Value: []byte{0x00, 0x05}}}},
+	}
+
+	v, found := list.Get("9f36")
+	assert.True(t, found)
+	assert.Equal(t, []byte{0x00, 0x05}, v)
+
+	v, found = list.Get("77")
+	assert.True(t, found)
+	assert.Equal(t, []byte{0x9F, 0x36, 0x02, 0x00, 0x05}, v)
+
+	_, found = list.Get("9F02")
+	assert.False(t, found)
+
+	assert.Nil(t, list.Set("9F26", []byte{0x22}))
+	assert.Nil(t, list.Set("5F2A", []byte{0x09, 0x78}))
+	assert.Nil(t, list.Set("70", []byte{0x5A, 0x01, 0x54}))
+	assert.True(t, errors.Is(list.Set("ZZ", nil), emv.ErrInvalidTag))
+	assert.True(t, errors.Is(list.Set("71", []byte{0x5A, 0x05}), emv.ErrTruncated))
+
+	assert.Equal(t, emv.List{
+		{Tag: "9F26", Value: []byte{0x22}},
+		{Tag: "77", Children: emv.List{{Tag: "9F36", Value: []byte{0x00, 0x05}}}},
+		{Tag: "5F2A", Value: []byte{0x09, 0x78}},
+		{Tag: "70", Children: emv.List{{Tag: "5A", Value: []byte{0x54}}}},
+	}, list)
+
+	list.Delete("9f26")
+	list.Delete("77")
+	assert.Equal(t, emv.List{
+		{Tag: "5F2A", Value: []byte{0x09, 0x78}},
+		{Tag: "70", Children: emv.List{{Tag: "5A", Value: []byte{0x54}}}},
+	}, list)
+}
@@ -0,0 +1,94 @@
+package emv
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidFormat exported error for asserting.
+var ErrInvalidFormat = errors.New("invalid format")
+
+// Validate checks the primitive objects of l, constructed ones included, against its dictionary
+// definitions and returns every found error, nil is returned if l is valid.
+// Objects which tag is not defined in Dictionary are not checked.
+func Validate(l List) []error {
+	errs := make([]error, 0)
+
+	for _, object := range l {
+		if object.IsConstructed() {
+			errs = append(errs, Validate(object.Children)...)
+			continue
+		}
+
+		definition, exist := Lookup(object.Tag)
+		if !exist {
+			continue
+		}
+
+		if err := validateObject(object, definition); err != nil {
+			errs = append(errs, fmt.Errorf("tag %s (%s): %w", object.Tag, definition.Name, err))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func validateObject(object Object, definition Definition) error {
+	l := len(object.Value)
+	if l < definition.MinLength || (definition.MaxLength != 0 && l > definition.MaxLength) {
+		return fmt.Errorf("%w: value is %v bytes long but should be between %v and %v",
+			ErrInvalidLength, l, definition.MinLength, definition.MaxLength)
+	}
+
+	switch definition.Format {
+	case "n":
+		return checkNibbles(definition.Format, object.Value, func(nibble byte, _ bool) bool { return nibble <= 9 })
+	case "cn":
+		// Trailing padding can not be followed by digits.
+		return checkNibbles(definition.Format, object.Value, func(nibble byte, padded bool) bool {
+			return (nibble <= 9 && !padded) || nibble == 0xF
+		})
+	case "a":
+		return checkCharacters(definition.Format, object.Value, isAlphabetic)
+	case "an":
+		return checkCharacters(definition.Format, object.Value, func(c byte) bool { return isAlphabetic(c) || isDigit(c) })
+	case "ans":
+		return checkCharacters(definition.Format, object.Value, func(c byte) bool { return c >= ' ' && c <= '~' })
+	}
+
+	return nil
+}
+
+// checkNibbles validates each half byte, padded indicates if a 0xF nibble was already found.
+func checkNibbles(format string, b []byte, valid func(nibble byte, padded bool) bool) error {
+	var padded bool
+	for n, byt := range b {
+		for _, nibble := range []byte{byt >> 4, byt & 0x0F} {
+			if !valid(nibble, padded) {
+				return fmt.Errorf("%w: byte %X at position %v is not allowed by format '%s'", ErrInvalidFormat, byt, n, format)
+			}
+			padded = padded || nibble == 0xF
+		}
+	}
+
+	return nil
+}
+
+func checkCharacters(format string, b []byte, valid func(byte) bool) error {
+	for n, c := range b {
+		if !valid(c) {
+			return fmt.Errorf("%w: character %q at position %v is not allowed by format '%s'",
+				ErrInvalidFormat, c, n, format)
+		}
+	}
+
+	return nil
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isAlphabetic(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
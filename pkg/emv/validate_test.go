@@ -0,0 +1,94 @@
+package emv_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/emv"
+)
+
+func TestLookup(t *testing.T) {
+	definition, exist := emv.Lookup("9f36")
+	assert.True(t, exist)
+	assert.Equal(t, emv.Definition{Name: "Application Transaction Counter (ATC)", Source: emv.SourceICC,
+		Format: "b", MinLength: 2, MaxLength: 2}, definition)
+
+	definition, exist = emv.Lookup("9F1A")
+	assert.True(t, exist)
+	assert.Equal(t, "n", definition.Format)
+	assert.Equal(t, emv.SourceTerminal, definition.Source)
+
+	_, exist = emv.Lookup("DF01")
+	assert.False(t, exist)
+}
+
+func TestValidate(t *testing.T) {
+	testList := []struct {
+		name           string
+		input          emv.List
+		expectedErrors []error
+		expectedText   []string
+	}{
+		{
+			name: "valid",
+			input: emv.List{
+				{Tag: "9F36", Value: []byte{0x00, 0x05}},
+				{Tag: "9F1A", Value: []byte{0x00, 0x32}},
+				{Tag: "5A", Value: []byte{0x37, 0x12, 0x34, 0x56, 0x78, 0x90, 0x12, 0x3F}},
+				{Tag: "9F1C", Value: []byte("TERM0001")},
+				{Tag: "9F4E", Value: []byte("SHOP, CITY")},
+				{Tag: "DF01", Value: []byte{0xFF, 0xFF, 0xFF}},
+				{Tag: "77", Children: emv.List{{Tag: "9F27", Value: []byte{0x80}}}},
+			},
+		},
+		{
+			name: "invalid_length",
+			input: emv.List{
+				{Tag: "9F36", Value: []byte{0x05}},
+				{Tag: "77", Children: emv.List{{Tag: "9F26", Value: []byte{0x01}}}},
+			},
+			expectedErrors: []error{emv.ErrInvalidLength, emv.ErrInvalidLength},
+			expectedText: []string{
+				"tag 9F36 (Application Transaction Counter (ATC)): invalid length: value is 1 bytes long but " +
+					"should be between 2 and 2",
+				"tag 9F26 (Application Cryptogram): invalid length: value is 1 bytes long but should be between 8 and 8",
+			},
+		},
+		{
+			name: "invalid_format",
+			input: emv.List{
+				{Tag: "9F1A", Value: []byte{0x00, 0x3A}},
+				{Tag: "5A", Value: []byte{0x54, 0xF0}},
+				{Tag: "9F1C", Value: []byte("TERM-001")},
+			},
+			expectedErrors: []error{emv.ErrInvalidFormat, emv.ErrInvalidFormat, emv.ErrInvalidFormat},
+			expectedText: []string{
+				"tag 9F1A (Terminal Country Code): invalid format: byte 3A at position 1 is not allowed by format 'n'",
+				"tag 5A (Application Primary Account Number (PAN)): invalid format: byte F0 at position 1 " +
+					"is not allowed by format 'cn'",
+				"tag 9F1C (Terminal Identification): invalid format: character '-' at position 4 " +
+					"is not allowed by format 'an'",
+			},
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.name, func(t *testing.T) {
+			errs := emv.Validate(testCase.input)
+			if len(testCase.expectedErrors) == 0 {
+				assert.Nil(t, errs)
+				return
+			}
+
+			if !assert.Len(t, errs, len(testCase.expectedErrors)) {
+				t.FailNow()
+			}
+			for n, err := range errs {
+				assert.True(t, errors.Is(err, testCase.expectedErrors[n]), "unexpected error: %v", err)
+				assert.Equal(t, testCase.expectedText[n], err.Error())
+			}
+		})
+	}
+}
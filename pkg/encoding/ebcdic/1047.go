@@ -1,7 +1,7 @@
 package ebcdic
 
 // V1047 version of EBCDIC encoding
-var V1047 = version{entries: []entry{
+var V1047 = newVersion([]entry{
 	{0x00, 0x00, 0x0},  // "NULL"
 	{0x01, 0x01, 0x0},  // "START OF HEADING"
 	{0x02, 0x02, 0x0},  // "START OF TEXT"
@@ -127,7 +127,7 @@ var V1047 = version{entries: []entry{
 	{0x7A, 0x3A, ':'},  // "COLON"
 	{0x7B, 0x23, '#'},  // "NUMBER SIGN"
 	{0x7C, 0x40, '@'},  // "COMMERCIAL AT"
-	{0x7D, 0x27, '\''}, //, "APOSTROPHE"
+	{0x7D, 0x27, '\''}, // "APOSTROPHE"
 	{0x7E, 0x3D, '='},  // "EQUALS SIGN"
 	{0x7F, 0x22, '"'},  // "QUOTATION MARK"
 	{0x80, 0xD8, 'Ø'},  // "LATIN CAPITAL LETTER O WITH STROKE"
@@ -258,5 +258,4 @@ var V1047 = version{entries: []entry{
 	{0xFD, 0xD9, 'Ù'},  // "LATIN CAPITAL LETTER U WITH GRAVE"
 	{0xFE, 0xDA, 'Ú'},  // "LATIN CAPITAL LETTER U WITH ACUTE"
 	{0xFF, 0x9F, 0x0},  // "APPLICATION PROGRAM COMMAND"
-},
-}
+})
@@ -0,0 +1,261 @@
+package ebcdic
+
+// V1140 version of EBCDIC encoding, code page 037 with euro sign.
+var V1140 = newVersion([]entry{
+	{0x00, 0x00, 0x0},  // "NULL"
+	{0x01, 0x01, 0x0},  // "START OF HEADING"
+	{0x02, 0x02, 0x0},  // "START OF TEXT"
+	{0x03, 0x03, 0x0},  // "END OF TEXT"
+	{0x04, 0x9C, 0x0},  // "STRING TERMINATOR"
+	{0x05, 0x09, 0x0},  // "CHARACTER TABULATION"
+	{0x06, 0x86, 0x0},  // "START OF SELECTED AREA"
+	{0x07, 0x7F, 0x0},  // "DELETE"
+	{0x08, 0x97, 0x0},  // "END OF GUARDED AREA"
+	{0x09, 0x8D, 0x0},  // "REVERSE LINE FEED"
+	{0x0A, 0x8E, 0x0},  // "SINGLE SHIFT TWO"
+	{0x0B, 0x0B, 0x0},  // "LINE TABULATION"
+	{0x0C, 0x0C, 0x0},  // "FORM FEED (FF)"
+	{0x0D, 0x0D, 0x0},  // "CARRIAGE RETURN (CR)"
+	{0x0E, 0x0E, 0x0},  // "SHIFT OUT"
+	{0x0F, 0x0F, 0x0},  // "SHIFT IN"
+	{0x10, 0x10, 0x0},  // "DATA LINK ESCAPE"
+	{0x11, 0x11, 0x0},  // "DEVICE CONTROL ONE"
+	{0x12, 0x12, 0x0},  // "DEVICE CONTROL TWO"
+	{0x13, 0x13, 0x0},  // "DEVICE CONTROL THREE"
+	{0x14, 0x9D, 0x0},  // "OPERATING SYSTEM COMMAND"
+	{0x15, 0x85, 0x0},  // "NEXT LINE (NEL)"
+	{0x16, 0x08, 0x0},  // "BACKSPACE"
+	{0x17, 0x87, 0x0},  // "END OF SELECTED AREA"
+	{0x18, 0x18, 0x0},  // "CANCEL"
+	{0x19, 0x19, 0x0},  // "END OF MEDIUM"
+	{0x1A, 0x92, 0x0},  // "PRIVATE USE TWO"
+	{0x1B, 0x8F, 0x0},  // "SINGLE SHIFT THREE"
+	{0x1C, 0x1C, 0x0},  // "INFORMATION SEPARATOR FOUR"
+	{0x1D, 0x1D, 0x0},  // "INFORMATION SEPARATOR THREE"
+	{0x1E, 0x1E, 0x0},  // "INFORMATION SEPARATOR TWO"
+	{0x1F, 0x1F, 0x0},  // "INFORMATION SEPARATOR ONE"
+	{0x20, 0x80, 0x0},  // "<UNDEFINED>"
+	{0x21, 0x81, 0x0},  // "<UNDEFINED>"
+	{0x22, 0x82, 0x0},  // "BREAK PERMITTED HERE"
+	{0x23, 0x83, 0x0},  // "NO BREAK HERE"
+	{0x24, 0x84, 0x0},  // "<UNDEFINED>"
+	{0x25, 0x0A, 0x0},  // "LINE FEED (LF)"
+	{0x26, 0x17, 0x0},  // "END OF TRANSMISSION BLOCK"
+	{0x27, 0x1B, 0x0},  // "ESCAPE"
+	{0x28, 0x88, 0x0},  // "CHARACTER TABULATION SET"
+	{0x29, 0x89, 0x0},  // "CHARACTER TABULATION WITH JUSTIFICATION"
+	{0x2A, 0x8A, 0x0},  // "LINE TABULATION SET"
+	{0x2B, 0x8B, 0x0},  // "PARTIAL LINE FORWARD"
+	{0x2C, 0x8C, 0x0},  // "PARTIAL LINE BACKWARD"
+	{0x2D, 0x05, 0x0},  // "ENQUIRY"
+	{0x2E, 0x06, 0x0},  // "ACKNOWLEDGE"
+	{0x2F, 0x07, 0x0},  // "BELL"
+	{0x30, 0x90, 0x0},  // "DEVICE CONTROL STRING"
+	{0x31, 0x91, 0x0},  // "PRIVATE USE ONE"
+	{0x32, 0x16, 0x0},  // "SYNCHRONOUS IDLE"
+	{0x33, 0x93, 0x0},  // "SET TRANSMIT STATE"
+	{0x34, 0x94, 0x0},  // "CANCEL CHARACTER"
+	{0x35, 0x95, 0x0},  // "MESSAGE WAITING"
+	{0x36, 0x96, 0x0},  // "START OF GUARDED AREA"
+	{0x37, 0x04, 0x0},  // "END OF TRANSMISSION"
+	{0x38, 0x98, 0x0},  // "START OF STRING"
+	{0x39, 0x99, 0x0},  // "<UNDEFINED>"
+	{0x3A, 0x9A, 0x0},  // "SINGLE CHARACTER INTRODUCER"
+	{0x3B, 0x9B, 0x0},  // "CONTROL SEQUENCE INTRODUCER"
+	{0x3C, 0x14, 0x0},  // "DEVICE CONTROL FOUR"
+	{0x3D, 0x15, 0x0},  // "NEGATIVE ACKNOWLEDGE"
+	{0x3E, 0x9E, 0x0},  // "PRIVACY MESSAGE"
+	{0x3F, 0x1A, 0x0},  // "SUBSTITUTE"
+	{0x40, 0x20, 0x0},  // "SPACE"
+	{0x41, 0xA0, 0x0},  // "NO - BREAK SPACE"
+	{0x42, 0xE2, 'â'},  // "LATIN SMALL LETTER A WITH CIRCUMFLEX"
+	{0x43, 0xE4, 'ä'},  // "LATIN SMALL LETTER A WITH DIAERESIS"
+	{0x44, 0xE0, 'à'},  // "LATIN SMALL LETTER A WITH GRAVE"
+	{0x45, 0xE1, 'á'},  // "LATIN SMALL LETTER A WITH ACUTE"
+	{0x46, 0xE3, 'ã'},  // "LATIN SMALL LETTER A WITH TILDE"
+	{0x47, 0xE5, 'å'},  // "LATIN SMALL LETTER A WITH RING ABOVE"
+	{0x48, 0xE7, 'ç'},  // "LATIN SMALL LETTER C WITH CEDILLA"
+	{0x49, 0xF1, 'ñ'},  // "LATIN SMALL LETTER N WITH TILDE"
+	{0x4A, 0xA2, '¢'},  // "CENT SIGN"
+	{0x4B, 0x2E, '.'},  // "FULL STOP"
+	{0x4C, 0x3C, '<'},  // "LESS-THAN SIGN"
+	{0x4D, 0x28, '('},  // "LEFT PARENTHESIS"
+	{0x4E, 0x2B, '+'},  // "PLUS SIGN"
+	{0x4F, 0x7C, '|'},  // "VERTICAL LINE"
+	{0x50, 0x26, '&'},  // "AMPERSAND"
+	{0x51, 0xE9, 'é'},  // "LATIN SMALL LETTER E WITH ACUTE"
+	{0x52, 0xEA, 'ê'},  // "LATIN SMALL LETTER E WITH CIRCUMFLEX"
+	{0x53, 0xEB, 'ë'},  // "LATIN SMALL LETTER E WITH DIAERESIS"
+	{0x54, 0xE8, 'è'},  // "LATIN SMALL LETTER E WITH GRAVE"
+	{0x55, 0xED, 'í'},  // "LATIN SMALL LETTER I WITH ACUTE"
+	{0x56, 0xEE, 'î'},  // "LATIN SMALL LETTER I WITH CIRCUMFLEX"
+	{0x57, 0xEF, 'ï'},  // "LATIN SMALL LETTER I WITH DIAERESIS"
+	{0x58, 0xEC, 'ì'},  // "LATIN SMALL LETTER I WITH GRAVE"
+	{0x59, 0xDF, 'ß'},  // "LATIN SMALL LETTER SHARP S"
+	{0x5A, 0x21, '!'},  // "EXCLAMATION MARK"
+	{0x5B, 0x24, '$'},  // "DOLLAR SIGN"
+	{0x5C, 0x2A, '*'},  // "ASTERISK"
+	{0x5D, 0x29, ')'},  // "RIGHT PARENTHESIS"
+	{0x5E, 0x3B, ';'},  // "SEMICOLON"
+	{0x5F, 0xAC, '¬'},  // "NOT SIGN"
+	{0x60, 0x2D, 0x0},  // "HYPHEN - MINUS"
+	{0x61, 0x2F, '/'},  // "SOLIDUS"
+	{0x62, 0xC2, 'Â'},  // "LATIN CAPITAL LETTER A WITH CIRCUMFLEX"
+	{0x63, 0xC4, 'Ä'},  // "LATIN CAPITAL LETTER A WITH DIAERESIS"
+	{0x64, 0xC0, 'À'},  // "LATIN CAPITAL LETTER A WITH GRAVE"
+	{0x65, 0xC1, 'Á'},  // "LATIN CAPITAL LETTER A WITH ACUTE"
+	{0x66, 0xC3, 'Ã'},  // "LATIN CAPITAL LETTER A WITH TILDE"
+	{0x67, 0xC5, 'Å'},  // "LATIN CAPITAL LETTER A WITH RING ABOVE"
+	{0x68, 0xC7, 'Ç'},  // "LATIN CAPITAL LETTER C WITH CEDILLA"
+	{0x69, 0xD1, 'Ñ'},  // "LATIN CAPITAL LETTER N WITH TILDE"
+	{0x6A, 0xA6, '¦'},  // "BROKEN BAR"
+	{0x6B, 0x2C, ','},  // "COMMA"
+	{0x6C, 0x25, '%'},  // "PERCENT SIGN"
+	{0x6D, 0x5F, '_'},  // "LOW LINE"
+	{0x6E, 0x3E, '>'},  // "GREATER - THAN SIGN"
+	{0x6F, 0x3F, '?'},  // "QUESTION MARK"
+	{0x70, 0xF8, 'ø'},  // "LATIN SMALL LETTER O WITH STROKE"
+	{0x71, 0xC9, 'É'},  // "LATIN CAPITAL LETTER E WITH ACUTE"
+	{0x72, 0xCA, 'Ê'},  // "LATIN CAPITAL LETTER E WITH CIRCUMFLEX"
+	{0x73, 0xCB, 'Ë'},  // "LATIN CAPITAL LETTER E WITH DIAERESIS"
+	{0x74, 0xC8, 'È'},  // "LATIN CAPITAL LETTER E WITH GRAVE"
+	{0x75, 0xCD, 'Í'},  // "LATIN CAPITAL LETTER I WITH ACUTE"
+	{0x76, 0xCE, 'Î'},  // "LATIN CAPITAL LETTER I WITH CIRCUMFLEX"
+	{0x77, 0xCF, 'Ï'},  // "LATIN CAPITAL LETTER I WITH DIAERESIS"
+	{0x78, 0xCC, 'Ì'},  // "LATIN CAPITAL LETTER I WITH GRAVE"
+	{0x79, 0x60, '`'},  // "GRAVE ACCENT"
+	{0x7A, 0x3A, ':'},  // "COLON"
+	{0x7B, 0x23, '#'},  // "NUMBER SIGN"
+	{0x7C, 0x40, '@'},  // "COMMERCIAL AT"
+	{0x7D, 0x27, '\''}, // "APOSTROPHE"
+	{0x7E, 0x3D, '='},  // "EQUALS SIGN"
+	{0x7F, 0x22, '"'},  // "QUOTATION MARK"
+	{0x80, 0xD8, 'Ø'},  // "LATIN CAPITAL LETTER O WITH STROKE"
+	{0x81, 0x61, 'a'},  // "LATIN SMALL LETTER A"
+	{0x82, 0x62, 'b'},  // "LATIN SMALL LETTER B"
+	{0x83, 0x63, 'c'},  // "LATIN SMALL LETTER C"
+	{0x84, 0x64, 'd'},  // "LATIN SMALL LETTER D"
+	{0x85, 0x65, 'e'},  // "LATIN SMALL LETTER E"
+	{0x86, 0x66, 'f'},  // "LATIN SMALL LETTER F"
+	{0x87, 0x67, 'g'},  // "LATIN SMALL LETTER G"
+	{0x88, 0x68, 'h'},  // "LATIN SMALL LETTER H"
+	{0x89, 0x69, 'i'},  // "LATIN SMALL LETTER I"
+	{0x8A, 0xAB, '«'},  // "LEFT-POINTING DOUBLE ANGLE QUOTATION MARK"
+	{0x8B, 0xBB, '»'},  // "RIGHT-POINTING DOUBLE ANGLE QUOTATION MARK"
+	{0x8C, 0xF0, 'ð'},  // "LATIN SMALL LETTER ETH"
+	{0x8D, 0xFD, 'ý'},  // "LATIN SMALL LETTER Y WITH ACUTE"
+	{0x8E, 0xFE, 'þ'},  // "LATIN SMALL LETTER THORN"
+	{0x8F, 0xB1, '±'},  // "PLUS-MINUS SIGN"
+	{0x90, 0xB0, '°'},  // "DEGREE SIGN"
+	{0x91, 0x6A, 'j'},  // "LATIN SMALL LETTER J"
+	{0x92, 0x6B, 'k'},  // "LATIN SMALL LETTER K"
+	{0x93, 0x6C, 'l'},  // "LATIN SMALL LETTER L"
+	{0x94, 0x6D, 'm'},  // "LATIN SMALL LETTER M"
+	{0x95, 0x6E, 'n'},  // "LATIN SMALL LETTER N"
+	{0x96, 0x6F, 'o'},  // "LATIN SMALL LETTER O"
+	{0x97, 0x70, 'p'},  // "LATIN SMALL LETTER P"
+	{0x98, 0x71, 'q'},  // "LATIN SMALL LETTER Q"
+	{0x99, 0x72, 'r'},  // "LATIN SMALL LETTER R"
+	{0x9A, 0xAA, 'ª'},  // "FEMININE ORDINAL INDICATOR"
+	{0x9B, 0xBA, 'º'},  // "MASCULINE ORDINAL INDICATOR"
+	{0x9C, 0xE6, 'æ'},  // "LATIN SMALL LETTER AE"
+	{0x9D, 0xB8, '¸'},  // "CEDILLA"
+	{0x9E, 0xC6, 'Æ'},  // "LATIN CAPITAL LETTER AE"
+	{0x9F, 0x00, '€'},  // "EURO SIGN"
+	{0xA0, 0xB5, 'µ'},  // "MICRO SIGN"
+	{0xA1, 0x7E, '~'},  // "TILDE"
+	{0xA2, 0x73, 's'},  // "LATIN SMALL LETTER S"
+	{0xA3, 0x74, 't'},  // "LATIN SMALL LETTER T"
+	{0xA4, 0x75, 'u'},  // "LATIN SMALL LETTER U"
+	{0xA5, 0x76, 'v'},  // "LATIN SMALL LETTER V"
+	{0xA6, 0x77, 'w'},  // "LATIN SMALL LETTER W"
+	{0xA7, 0x78, 'x'},  // "LATIN SMALL LETTER X"
+	{0xA8, 0x79, 'y'},  // "LATIN SMALL LETTER Y"
+	{0xA9, 0x7A, 'z'},  // "LATIN SMALL LETTER Z"
+	{0xAA, 0xA1, '¡'},  // "INVERTED EXCLAMATION MARK"
+	{0xAB, 0xBF, '¿'},  // "INVERTED QUESTION MARK"
+	{0xAC, 0xD0, 'Ð'},  // "LATIN CAPITAL LETTER ETH"
+	{0xAD, 0xDD, 'Ý'},  // "LATIN CAPITAL LETTER Y WITH ACUTE"
+	{0xAE, 0xDE, 'Þ'},  // "LATIN CAPITAL LETTER THORN"
+	{0xAF, 0xAE, '®'},  // "REGISTERED SIGN"
+	{0xB0, 0x5E, '^'},  // "CIRCUMFLEX ACCENT"
+	{0xB1, 0xA3, '£'},  // "POUND SIGN"
+	{0xB2, 0xA5, '¥'},  // "YEN SIGN"
+	{0xB3, 0xB7, '·'},  // "MIDDLE DOT"
+	{0xB4, 0xA9, '©'},  // "COPYRIGHT SIGN"
+	{0xB5, 0xA7, '§'},  // "SECTION SIGN"
+	{0xB6, 0xB6, '¶'},  // "PILCROW SIGN"
+	{0xB7, 0xBC, '¼'},  // "VULGAR FRACTION ONE QUARTER"
+	{0xB8, 0xBD, '½'},  // "VULGAR FRACTION ONE HALF"
+	{0xB9, 0xBE, '¾'},  // "VULGAR FRACTION THREE QUARTERS"
+	{0xBA, 0x5B, '['},  // "LEFT SQUARE BRACKET"
+	{0xBB, 0x5D, ']'},  // "RIGHT SQUARE BRACKET"
+	{0xBC, 0xAF, '¯'},  // "MACRON"
+	{0xBD, 0xA8, '¨'},  // "DIAERESIS"
+	{0xBE, 0xB4, '´'},  // "ACUTE ACCENT"
+	{0xBF, 0xD7, '×'},  // "MULTIPLICATION SIGN"
+	{0xC0, 0x7B, '{'},  // "LEFT CURLY BRACKET"
+	{0xC1, 0x41, 'A'},  // "LATIN CAPITAL LETTER A"
+	{0xC2, 0x42, 'B'},  // "LATIN CAPITAL LETTER B"
+	{0xC3, 0x43, 'C'},  // "LATIN CAPITAL LETTER C"
+	{0xC4, 0x44, 'D'},  // "LATIN CAPITAL LETTER D"
+	{0xC5, 0x45, 'E'},  // "LATIN CAPITAL LETTER E"
+	{0xC6, 0x46, 'F'},  // "LATIN CAPITAL LETTER F"
+	{0xC7, 0x47, 'G'},  // "LATIN CAPITAL LETTER G"
+	{0xC8, 0x48, 'H'},  // "LATIN CAPITAL LETTER H"
+	{0xC9, 0x49, 'I'},  // "LATIN CAPITAL LETTER I"
+	{0xCA, 0xAD, 0x0},  // "SOFT HYPHEN"
+	{0xCB, 0xF4, 'ô'},  // "LATIN SMALL LETTER O WITH CIRCUMFLEX"
+	{0xCC, 0xF6, 'ö'},  // "LATIN SMALL LETTER O WITH DIAERESIS"
+	{0xCD, 0xF2, 'ò'},  // "LATIN SMALL LETTER O WITH GRAVE"
+	{0xCE, 0xF3, 'ó'},  // "LATIN SMALL LETTER O WITH ACUTE"
+	{0xCF, 0xF5, 'õ'},  // "LATIN SMALL LETTER O WITH TILDE"
+	{0xD0, 0x7D, '}'},  // "RIGHT CURLY BRACKET"
+	{0xD1, 0x4A, 'J'},  // "LATIN CAPITAL LETTER J"
+	{0xD2, 0x4B, 'K'},  // "LATIN CAPITAL LETTER K"
+	{0xD3, 0x4C, 'L'},  // "LATIN CAPITAL LETTER L"
+	{0xD4, 0x4D, 'M'},  // "LATIN CAPITAL LETTER M"
+	{0xD5, 0x4E, 'N'},  // "LATIN CAPITAL LETTER N"
+	{0xD6, 0x4F, 'O'},  // "LATIN CAPITAL LETTER O"
+	{0xD7, 0x50, 'P'},  // "LATIN CAPITAL LETTER P"
+	{0xD8, 0x51, 'Q'},  // "LATIN CAPITAL LETTER Q"
+	{0xD9, 0x52, 'R'},  // "LATIN CAPITAL LETTER R"
+	{0xDA, 0xB9, '¹'},  // "SUPERSCRIPT ONE"
+	{0xDB, 0xFB, 'û'},  // "LATIN SMALL LETTER U WITH CIRCUMFLEX"
+	{0xDC, 0xFC, 'ü'},  // "LATIN SMALL LETTER U WITH DIAERESIS"
+	{0xDD, 0xF9, 'ù'},  // "LATIN SMALL LETTER U WITH GRAVE"
+	{0xDE, 0xFA, 'ú'},  // "LATIN SMALL LETTER U WITH ACUTE"
+	{0xDF, 0xFF, 'ÿ'},  // "LATIN SMALL LETTER Y WITH DIAERESIS"
+	{0xE0, 0x5C, '\\'}, // "REVERSE SOLIDUS"
+	{0xE1, 0xF7, '÷'},  // "DIVISION SIGN"
+	{0xE2, 0x53, 'S'},  // "LATIN CAPITAL LETTER S"
+	{0xE3, 0x54, 'T'},  // "LATIN CAPITAL LETTER T"
+	{0xE4, 0x55, 'U'},  // "LATIN CAPITAL LETTER U"
+	{0xE5, 0x56, 'V'},  // "LATIN CAPITAL LETTER V"
+	{0xE6, 0x57, 'W'},  // "LATIN CAPITAL LETTER W"
+	{0xE7, 0x58, 'X'},  // "LATIN CAPITAL LETTER X"
+	{0xE8, 0x59, 'Y'},  // "LATIN CAPITAL LETTER Y"
+	{0xE9, 0x5A, 'Z'},  // "LATIN CAPITAL LETTER Z"
+	{0xEA, 0xB2, '²'},  // "SUPERSCRIPT TWO"
+	{0xEB, 0xD4, 'Ô'},  // "LATIN CAPITAL LETTER O WITH CIRCUMFLEX"
+	{0xEC, 0xD6, 'Ö'},  // "LATIN CAPITAL LETTER O WITH DIAERESIS"
+	{0xED, 0xD2, 'Ò'},  // "LATIN CAPITAL LETTER O WITH GRAVE"
+	{0xEE, 0xD3, 'Ó'},  // "LATIN CAPITAL LETTER O WITH ACUTE"
+	{0xEF, 0xD5, 'Õ'},  // "LATIN CAPITAL LETTER O WITH TILDE"
+	{0xF0, 0x30, '0'},  // "DIGIT ZERO"
+	{0xF1, 0x31, '1'},  // "DIGIT ONE"
+	{0xF2, 0x32, '2'},  // "DIGIT TWO"
+	{0xF3, 0x33, '3'},  // "DIGIT THREE"
+	{0xF4, 0x34, '4'},  // "DIGIT FOUR"
+	{0xF5, 0x35, '5'},  // "DIGIT FIVE"
+	{0xF6, 0x36, '6'},  // "DIGIT SIX"
+	{0xF7, 0x37, '7'},  // "DIGIT SEVEN"
+	{0xF8, 0x38, '8'},  // "DIGIT EIGHT"
+	{0xF9, 0x39, '9'},  // "DIGIT NINE"
+	{0xFA, 0xB3, '³'},  // "SUPERSCRIPT THREE"
+	{0xFB, 0xDB, 'Û'},  // "LATIN CAPITAL LETTER U WITH CIRCUMFLEX"
+	{0xFC, 0xDC, 'Ü'},  // "LATIN CAPITAL LETTER U WITH DIAERESIS"
+	{0xFD, 0xD9, 'Ù'},  // "LATIN CAPITAL LETTER U WITH GRAVE"
+	{0xFE, 0xDA, 'Ú'},  // "LATIN CAPITAL LETTER U WITH ACUTE"
+	{0xFF, 0x9F, 0x0},  // "APPLICATION PROGRAM COMMAND"
+})
@@ -1,11 +1,46 @@
 package ebcdic
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnmappable is returned when a character has no translation in the code page, exported error for asserting.
+var ErrUnmappable = errors.New("unmappable character")
+
+// UnmappableError indicates the character that could not be translated and its position in the input.
+type UnmappableError struct {
+	// Rune is set when encoding and Byte when decoding.
+	Rune rune
+	Byte byte
+	// Position is the byte offset of the character.
+	Position int
+
+	decoding bool
+}
+
+func (e *UnmappableError) Error() string {
+	if e.decoding {
+		return fmt.Sprintf("%s: byte 0x%02X at position %v", ErrUnmappable, e.Byte, e.Position)
+	}
+	return fmt.Sprintf("%s: rune %q (%U) at position %v", ErrUnmappable, e.Rune, e.Rune, e.Position)
+}
+
+// Unwrap returns ErrUnmappable.
+func (e *UnmappableError) Unwrap() error { return ErrUnmappable }
+
+// version holds the translation tables of a code page, they are built once when the package is initialized
+// so translations are lookups safe for concurrent use.
 type version struct {
-	entries []entry
+	encoding [256]byte
+	decoding [256]rune
+
+	// Translations are present for runes and bytes set as true.
+	encodable [256]bool
+	decodable [256]bool
 
-	// Maps are cached for better performance
-	encoding map[rune]byte
-	decoding map[byte]rune
+	// extended encodes runes outside Latin-1, like the euro sign of code page 1140.
+	extended map[rune]byte
 }
 
 type entry struct {
@@ -17,65 +52,111 @@ type entry struct {
 // NULL is used for invalid encoding conversions.
 const NULL = 0x0
 
-// FromGoString converts a string to ebcdic bytes.
-func (v *version) FromGoString(s string) []byte {
-	if len(v.encoding) == 0 {
-		v.generateEncodingMap()
+func newVersion(entries []entry) version {
+	v := version{extended: make(map[rune]byte)}
+
+	for _, kv := range entries {
+		r := rune(kv.ASCII)
+		if kv.representation != 0 {
+			r = kv.representation
+		}
+
+		v.decoding[kv.EBCDIC] = r
+		v.decodable[kv.EBCDIC] = true
+		if r < 256 {
+			v.encoding[r] = kv.EBCDIC
+			v.encodable[r] = true
+			continue
+		}
+		v.extended[r] = kv.EBCDIC
 	}
 
-	output := make([]byte, 0)
+	return v
+}
+
+// FromGoString converts a string to ebcdic bytes, runes without translation are encoded as NULL.
+func (v *version) FromGoString(s string) []byte {
+	output := make([]byte, 0, len(s))
 	for _, r := range s {
-		func() {
-			for k, v := range v.encoding {
-				if k == r {
-					output = append(output, v)
-					return
-				}
-			}
-			output = append(output, NULL)
-		}()
+		// Missing runes are encoded as NULL, the zero value.
+		b, _ := v.encodeRune(r)
+		output = append(output, b)
 	}
 	return output
 }
 
-// ToGoString converts a ebcdic bytes to a string.
+// ToGoString converts a ebcdic bytes to a string, bytes without translation are decoded as NULL.
 func (v *version) ToGoString(b []byte) string {
-	if len(v.decoding) == 0 {
-		v.generateDecodingMap()
+	output := make([]rune, len(b))
+	for n, byt := range b {
+		output[n] = v.decoding[byt]
 	}
+	return string(output)
+}
 
-	output := make([]rune, 0)
-	for _, byt := range b {
-		for k, v := range v.decoding {
-			if k == byt {
-				output = append(output, v)
-				break
-			}
+// Encode converts a string to ebcdic bytes, an *UnmappableError is returned for the first rune
+// without translation instead of encoding it as NULL.
+func (v *version) Encode(s string) ([]byte, error) {
+	output := make([]byte, 0, len(s))
+	for n, r := range s {
+		b, ok := v.encodeRune(r)
+		if !ok {
+			return nil, &UnmappableError{Rune: r, Position: n}
 		}
+		output = append(output, b)
 	}
-	return string(output)
+	return output, nil
 }
 
-func (v *version) generateEncodingMap() {
-	v.encoding = make(map[rune]byte)
+// EncodeSubstitute converts a string to ebcdic bytes replacing runes without translation by substitute,
+// which must have one.
+func (v *version) EncodeSubstitute(s string, substitute rune) ([]byte, error) {
+	sub, ok := v.encodeRune(substitute)
+	if !ok {
+		return nil, fmt.Errorf("%w: substitute rune %q (%U)", ErrUnmappable, substitute, substitute)
+	}
 
-	for _, kv := range v.entries {
-		if kv.representation != 0 {
-			v.encoding[kv.representation] = kv.EBCDIC
-			continue
+	output := make([]byte, 0, len(s))
+	for _, r := range s {
+		b, ok := v.encodeRune(r)
+		if !ok {
+			b = sub
 		}
-		v.encoding[rune(kv.ASCII)] = kv.EBCDIC
+		output = append(output, b)
 	}
+	return output, nil
 }
 
-func (v *version) generateDecodingMap() {
-	v.decoding = make(map[byte]rune)
+// Decode converts ebcdic bytes to a string, an *UnmappableError is returned for the first byte
+// without translation.
+func (v *version) Decode(b []byte) (string, error) {
+	output := make([]rune, len(b))
+	for n, byt := range b {
+		if !v.decodable[byt] {
+			return "", &UnmappableError{Byte: byt, Position: n, decoding: true}
+		}
+		output[n] = v.decoding[byt]
+	}
+	return string(output), nil
+}
 
-	for _, kv := range v.entries {
-		if kv.representation != 0 {
-			v.decoding[kv.EBCDIC] = kv.representation
-			continue
+// DecodeSubstitute converts ebcdic bytes to a string replacing bytes without translation by substitute.
+func (v *version) DecodeSubstitute(b []byte, substitute rune) string {
+	output := make([]rune, len(b))
+	for n, byt := range b {
+		output[n] = substitute
+		if v.decodable[byt] {
+			output[n] = v.decoding[byt]
 		}
-		v.decoding[kv.EBCDIC] = rune(kv.ASCII)
 	}
+	return string(output)
+}
+
+func (v *version) encodeRune(r rune) (byte, bool) {
+	if r >= 0 && r < 256 {
+		return v.encoding[r], v.encodable[r]
+	}
+
+	b, ok := v.extended[r]
+	return b, ok
 }
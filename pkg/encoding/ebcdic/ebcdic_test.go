@@ -1,9 +1,12 @@
 package ebcdic_test
 
 import (
+	"errors"
+	"sync"
+	"testing"
+
 	"github.com/jattento/go-iso8583/pkg/encoding/ebcdic"
 	"github.com/stretchr/testify/assert"
-	"testing"
 )
 
 func TestEncoding_Translate(t *testing.T) {
@@ -17,3 +20,97 @@ func TestEncoding_Translate_unknown_rune(t *testing.T) {
 	e := "鲸鱼歌"
 	assert.Equal(t, []byte{ebcdic.NULL, ebcdic.NULL, ebcdic.NULL}, ebcdic.V1047.FromGoString(e))
 }
+
+func TestEncoding_Encode(t *testing.T) {
+	b, err := ebcdic.V1047.Encode("0800")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xF0, 0xF8, 0xF0, 0xF0}, b)
+
+	_, err = ebcdic.V1047.Encode("CAFÉ 鲸")
+	var unmappable *ebcdic.UnmappableError
+	if assert.True(t, errors.As(err, &unmappable)) {
+		assert.True(t, errors.Is(err, ebcdic.ErrUnmappable))
+		assert.Equal(t, '鲸', unmappable.Rune)
+		assert.Equal(t, 6, unmappable.Position)
+		assert.EqualError(t, err, "unmappable character: rune '鲸' (U+9CB8) at position 6")
+	}
+
+	_, err = ebcdic.V1140.Encode("¤")
+	assert.EqualError(t, err, "unmappable character: rune '¤' (U+00A4) at position 0")
+}
+
+func TestEncoding_EncodeSubstitute(t *testing.T) {
+	b, err := ebcdic.V1047.EncodeSubstitute("A鲸B", '?')
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xC1, 0x6F, 0xC2}, b)
+
+	_, err = ebcdic.V1047.EncodeSubstitute("A鲸B", '鱼')
+	assert.True(t, errors.Is(err, ebcdic.ErrUnmappable))
+	assert.EqualError(t, err, "unmappable character: substitute rune '鱼' (U+9C7C)")
+}
+
+func TestEncoding_Decode(t *testing.T) {
+	s, err := ebcdic.V1047.Decode([]byte{0xF0, 0xF8, 0xF1, 0xF0})
+	assert.Nil(t, err)
+	assert.Equal(t, "0810", s)
+
+	assert.Equal(t, "0810", ebcdic.V1047.DecodeSubstitute([]byte{0xF0, 0xF8, 0xF1, 0xF0}, '?'))
+}
+
+func TestEncoding_code_pages(t *testing.T) {
+	testList := []struct {
+		Name    string
+		Version interface {
+			FromGoString(string) []byte
+			ToGoString([]byte) string
+		}
+		Input  string
+		Output []byte
+	}{
+		{Name: "037", Version: &ebcdic.V037, Input: "[!]^", Output: []byte{0xBA, 0x5A, 0xBB, 0xB0}},
+		{Name: "273", Version: &ebcdic.V273, Input: "[!]ÄÖÜ", Output: []byte{0x63, 0x4F, 0xFC, 0x4A, 0xE0, 0x5A}},
+		{Name: "277", Version: &ebcdic.V277, Input: "ÆØÅ$", Output: []byte{0x7B, 0x7C, 0x5B, 0x67}},
+		{Name: "278", Version: &ebcdic.V278, Input: "ÄÖÅ", Output: []byte{0x7B, 0x7C, 0x5B}},
+		{Name: "280", Version: &ebcdic.V280, Input: "àèì", Output: []byte{0xC0, 0xD0, 0xA1}},
+		{Name: "284", Version: &ebcdic.V284, Input: "Ññ", Output: []byte{0x7B, 0x6A}},
+		{Name: "285", Version: &ebcdic.V285, Input: "£$‾", Output: []byte{0x5B, 0x4A, 0xA1}},
+		{Name: "297", Version: &ebcdic.V297, Input: "àçé", Output: []byte{0x7C, 0xE0, 0xC0}},
+		{Name: "500", Version: &ebcdic.V500, Input: "[!]", Output: []byte{0x4A, 0x4F, 0x5A}},
+		{Name: "1047", Version: &ebcdic.V1047, Input: "[^]", Output: []byte{0xAD, 0x5F, 0xBD}},
+		{Name: "1140", Version: &ebcdic.V1140, Input: "€1", Output: []byte{0x9F, 0xF1}},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.Equal(t, testCase.Output, testCase.Version.FromGoString(testCase.Input))
+			assert.Equal(t, testCase.Input, testCase.Version.ToGoString(testCase.Output))
+
+			// Every byte of a code page is decoded to a different rune, so all of them round trip.
+			all := make([]byte, 256)
+			for n := range all {
+				all[n] = byte(n)
+			}
+			assert.Equal(t, all, testCase.Version.FromGoString(testCase.Version.ToGoString(all)))
+		})
+	}
+}
+
+func TestEncoding_code_page_unknown_rune(t *testing.T) {
+	// Code page 1140 replaced the currency sign with the euro sign.
+	assert.Equal(t, []byte{ebcdic.NULL}, ebcdic.V1140.FromGoString("¤"))
+	assert.Equal(t, []byte{0x9F}, ebcdic.V037.FromGoString("¤"))
+}
+
+func TestEncoding_concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				assert.Equal(t, "0800", ebcdic.V1047.ToGoString(ebcdic.V1047.FromGoString("0800")))
+			}
+		}()
+	}
+	wg.Wait()
+}
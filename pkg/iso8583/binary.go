@@ -35,3 +35,37 @@ func (binary *BINARY) UnmarshalISO8583(b []byte, length int, enc string) (int, e
 
 	return length, nil
 }
+
+// MarshalJSON represents the content in upper case hexadecimal.
+func (binary BINARY) MarshalJSON() ([]byte, error) {
+	return marshalHexJSON(binary)
+}
+
+// UnmarshalJSON reads the content from hexadecimal.
+func (binary *BINARY) UnmarshalJSON(data []byte) error {
+	b, err := unmarshalHexJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*binary = b
+	return nil
+}
+
+// ValidateISO8583 checks that the content is exactly length bytes long, only 'b' format is allowed.
+func (binary BINARY) ValidateISO8583(length int, format string) error {
+	if err := checkBinaryFormat(format); err != nil {
+		return err
+	}
+
+	return checkLength(len(binary), length)
+}
+
+// checkBinaryFormat validates that the format of a binary field is empty or 'b'.
+func checkBinaryFormat(format string) error {
+	if format != "" && format != "b" {
+		return fmt.Errorf("%w: binary fields only allow format 'b' but its '%s'", ErrInvalidFormat, format)
+	}
+
+	return nil
+}
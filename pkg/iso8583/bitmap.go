@@ -15,6 +15,7 @@ type BITMAP struct {
 }
 
 // UnmarshalISO8583 wrapps bitmap.FromBytes to match iso8583.Unmarshal interface.
+// The bitmap bytes are decoded with encoding, for example with a codec that represents them in hexadecimal.
 func (b *BITMAP) UnmarshalISO8583(byt []byte, length int, encoding string) (int, error) {
 	const bitsInByte = 8
 
@@ -23,18 +24,28 @@ func (b *BITMAP) UnmarshalISO8583(byt []byte, length int, encoding string) (int,
 	}
 
 	bcap := int(math.Ceil(float64(length) / float64(bitsInByte)))
+	size := encodedLen(encoding, bcap)
 
-	if len(byt) < bcap {
-		return 0, fmt.Errorf("bitmap should be %v bytes long but only %v bytes are avaiable", bcap, len(byt))
+	if len(byt) < size {
+		return 0, fmt.Errorf("bitmap should be %v bytes long but only %v bytes are avaiable", size, len(byt))
 	}
 
-	b.Bitmap = bitmap.FromBytes(byt[:bcap])
-	return bcap, nil
+	decoded, err := applyDecoding(byt[:size], encoding)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(decoded) < bcap {
+		return 0, fmt.Errorf("bitmap should be %v bytes long but only %v bytes were decoded", bcap, len(decoded))
+	}
+
+	b.Bitmap = bitmap.FromBytes(decoded[:bcap])
+	return size, nil
 }
 
-// MarshalISO8583 wrapps bitmap.ToBytes to match iso8583.Marshal interface.
+// MarshalISO8583 wrapps bitmap.ToBytes to match iso8583.Marshal interface, the bytes are encoded with encoding.
 func (b BITMAP) MarshalISO8583(length int, encoding string) ([]byte, error) {
-	return bitmap.ToBytes(b.Bitmap), nil
+	return applyEncoding(bitmap.ToBytes(b.Bitmap), encoding)
 }
 
 // Bits returns which bits are on, key values are between 1 and 64, both included.
@@ -43,15 +54,31 @@ func (b BITMAP) Bits() (map[int]bool, error) {
 	return b.Bitmap, nil
 }
 
-// MarshalISO8583Bitmap returns a empty slice if all bytes are 0x0
+// MarshalISO8583Bitmap returns a empty slice if all bytes are 0x0, otherwise the bytes encoded with encoding.
 func (b BITMAP) MarshalISO8583Bitmap(m map[int]bool, encoding string) ([]byte, error) {
 	bytes := bitmap.ToBytes(m)
 	for _, b := range bytes {
 		if b != 0x0 {
 			// Only if some byte has information, they are returned
-			return bytes, nil
+			return applyEncoding(bytes, encoding)
 		}
 	}
 
 	return []byte{}, nil
 }
+
+// MarshalJSON represents the bitmap bytes in upper case hexadecimal.
+func (b BITMAP) MarshalJSON() ([]byte, error) {
+	return marshalHexJSON(bitmap.ToBytes(b.Bitmap))
+}
+
+// UnmarshalJSON reads the bitmap bytes from hexadecimal.
+func (b *BITMAP) UnmarshalJSON(data []byte) error {
+	byt, err := unmarshalHexJSON(data)
+	if err != nil {
+		return err
+	}
+
+	b.Bitmap = bitmap.FromBytes(byt)
+	return nil
+}
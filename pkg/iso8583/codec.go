@@ -0,0 +1,131 @@
+package iso8583
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Codec translates the content of inbuilt fields to and from its representation in the message,
+// it is selected by the name it was registered with in the encoding tag.
+// Codecs may change the content width, for example BCD packs two digits in each byte. Fixed length fields
+// and length indicators count characters, not bytes, so the width is used to know how many bytes to read.
+type Codec interface {
+	Encode(b []byte) ([]byte, error)
+	// Decode returns the content including any padding added by Encode, which must be at the end:
+	// it is removed using the indicated amount of characters.
+	Decode(b []byte) ([]byte, error)
+	// EncodedLen returns the length in the message of n characters of content.
+	EncodedLen(n int) int
+	// DecodedLen returns the amount of characters represented by n bytes of the message, including padding.
+	DecodedLen(n int) int
+}
+
+// _codecs is the codec registry, it is initialized with the inbuilt codecs.
+var _codecs = struct {
+	sync.RWMutex
+	m map[string]Codec
+}{m: inbuiltCodecs()}
+
+// RegisterCodec makes c available to inbuilt fields as the encoding name, replacing any codec with the same name.
+// Unlike MarshalEncodings and UnmarshalDecodings, it is safe to call while messages are being marshaled.
+func RegisterCodec(name string, c Codec) {
+	if c == nil {
+		panic(fmt.Sprintf("iso8583: codec '%s' is nil", name))
+	}
+
+	_codecs.Lock()
+	defer _codecs.Unlock()
+
+	_codecs.m[name] = c
+}
+
+// LookupCodec returns the codec registered as name.
+func LookupCodec(name string) (Codec, bool) {
+	_codecs.RLock()
+	defer _codecs.RUnlock()
+
+	c, ok := _codecs.m[name]
+	return c, ok
+}
+
+// applyEncoding encodes content with enc, functions of MarshalEncodings take precedence over registered codecs.
+func applyEncoding(content []byte, enc string) ([]byte, error) {
+	return applyCodec(content, enc, MarshalEncodings, Codec.Encode)
+}
+
+// applyDecoding decodes b with enc, functions of UnmarshalDecodings take precedence over registered codecs.
+func applyDecoding(b []byte, enc string) ([]byte, error) {
+	return applyCodec(b, enc, UnmarshalDecodings, Codec.Decode)
+}
+
+func applyCodec(bytes []byte, enc string, encodings map[string]func([]byte) ([]byte, error),
+	method func(Codec, []byte) ([]byte, error)) ([]byte, error) {
+	b := make([]byte, len(bytes))
+	copy(b, bytes)
+
+	if enc == "" {
+		return b, nil
+	}
+
+	encoder, exist := encodings[enc]
+	if !exist {
+		c, ok := LookupCodec(enc)
+		if !ok {
+			return nil, fmt.Errorf("encoder '%s' does not exist", enc)
+		}
+
+		encoder = func(b []byte) ([]byte, error) { return method(c, b) }
+	}
+
+	b, err := encoder(b)
+	if err != nil {
+		return nil, fmt.Errorf("encoder '%s' returned error: %w", enc, err)
+	}
+
+	return b, nil
+}
+
+// encodedLen returns the length in the message of n bytes of content encoded with enc,
+// encodings without a registered codec do not change the width.
+func encodedLen(enc string, n int) int {
+	if c, ok := LookupCodec(enc); ok {
+		return c.EncodedLen(n)
+	}
+
+	return n
+}
+
+// decodedLen returns the amount of characters represented by n bytes of the message encoded with enc,
+// encodings without a registered codec do not change the width.
+func decodedLen(enc string, n int) int {
+	if c, ok := LookupCodec(enc); ok {
+		return c.DecodedLen(n)
+	}
+
+	return n
+}
+
+// decodeCharacters reads the bytes that represent n characters encoded with enc from b and decodes them
+// removing the codec padding, it returns also the amount of read bytes.
+func decodeCharacters(b []byte, n int, enc string) ([]byte, int, error) {
+	size := encodedLen(enc, n)
+	if len(b) < size {
+		return nil, 0, fmt.Errorf("message remain (%v bytes) is shorter than indicated length: %v", len(b), size)
+	}
+
+	content, err := applyDecoding(b[:size], enc)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return trimPadding(content, decodedLen(enc, size)-n), size, nil
+}
+
+// trimPadding removes the padding characters added at the end of content by a codec.
+func trimPadding(content []byte, padding int) []byte {
+	if padding <= 0 || padding > len(content) {
+		return content
+	}
+
+	return content[:len(content)-padding]
+}
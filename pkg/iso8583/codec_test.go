@@ -0,0 +1,195 @@
+package iso8583_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jattento/go-iso8583/pkg/encoding/ebcdic"
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hexCodec represents each byte with two hexadecimal characters.
+type hexCodec struct{}
+
+func (hexCodec) Encode(b []byte) ([]byte, error) {
+	return []byte(strings.ToUpper(hex.EncodeToString(b))), nil
+}
+func (hexCodec) Decode(b []byte) ([]byte, error) { return hex.DecodeString(string(b)) }
+func (hexCodec) EncodedLen(n int) int            { return 2 * n }
+func (hexCodec) DecodedLen(n int) int            { return n / 2 }
+
+func TestRegisterCodec(t *testing.T) {
+	iso8583.RegisterCodec("test_hex", hexCodec{})
+
+	c, ok := iso8583.LookupCodec("test_hex")
+	assert.True(t, ok)
+	assert.Equal(t, hexCodec{}, c)
+
+	_, ok = iso8583.LookupCodec("test_missing")
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { iso8583.RegisterCodec("test_nil", nil) })
+}
+
+func TestCodec_fixed_length_width(t *testing.T) {
+	b, err := iso8583.NUMERIC(1234).MarshalISO8583(6, "bcd")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x00, 0x12, 0x34}, b)
+
+	var n iso8583.NUMERIC
+	read, err := n.UnmarshalISO8583([]byte{0x00, 0x12, 0x34, 0xFF}, 6, "bcd")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, read)
+	assert.Equal(t, iso8583.NUMERIC(1234), n)
+
+	// Odd lengths are padded at the end, which is removed on unmarshal.
+	b, err = iso8583.VAR("12345").MarshalISO8583(5, "bcd")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x12, 0x34, 0x5F}, b)
+
+	var v iso8583.VAR
+	read, err = v.UnmarshalISO8583(append(b, 0x99), 5, "bcd")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, read)
+	assert.Equal(t, iso8583.VAR("12345"), v)
+
+	_, err = v.UnmarshalISO8583([]byte{0x00}, 4, "bcd")
+	assert.EqualError(t, err, "message remain (1 bytes) is shorter than indicated length: 2")
+}
+
+func TestCodec_length_indicator_characters(t *testing.T) {
+	iso8583.RegisterCodec("test_hex", hexCodec{})
+
+	testList := []struct {
+		Name        string
+		V           iso8583.LLVAR
+		Length      int
+		Encoding    string
+		OutputBytes []byte
+	}{
+		{
+			Name:        "odd_bcd_content",
+			V:           "5400000000000000011",
+			Length:      2,
+			Encoding:    "ascii/bcd",
+			OutputBytes: []byte{'1', '9', 0x54, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x1F},
+		},
+		{
+			Name:        "bcd_indicator",
+			V:           "ABCDEFGHIJKL",
+			Length:      1,
+			Encoding:    "bcd/ascii",
+			OutputBytes: append([]byte{0x12}, "ABCDEFGHIJKL"...),
+		},
+		{
+			Name:        "wider_content",
+			V:           "AB",
+			Length:      2,
+			Encoding:    "ascii/test_hex",
+			OutputBytes: []byte("024142"),
+		},
+		{
+			Name:        "multibyte_ebcdic_content",
+			V:           "CAFÉ",
+			Length:      2,
+			Encoding:    "ascii/ebcdic",
+			OutputBytes: []byte{'0', '4', 0xC3, 0xC1, 0xC6, 0x71},
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			b, err := testCase.V.MarshalISO8583(testCase.Length, testCase.Encoding)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputBytes, b)
+
+			var v iso8583.LLVAR
+			read, err := v.UnmarshalISO8583(append(b, 0xFF), testCase.Length, testCase.Encoding)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, len(b), read)
+			assert.Equal(t, testCase.V, v)
+		})
+	}
+}
+
+func TestCodec_odd_bcd_indicator(t *testing.T) {
+	b, err := iso8583.LLLVAR("123456789012").MarshalISO8583(2, "bcd")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x01, 0x2F, 0x12, 0x34, 0x56, 0x78, 0x90, 0x12}, b)
+
+	var v iso8583.LLLVAR
+	read, err := v.UnmarshalISO8583(b, 2, "bcd")
+	assert.Nil(t, err)
+	assert.Equal(t, 8, read)
+	assert.Equal(t, iso8583.LLLVAR("123456789012"), v)
+
+	_, err = v.UnmarshalISO8583([]byte{0x01, 0x2F, 0x12}, 2, "bcd")
+	assert.EqualError(t, err, "message remain (1 bytes) is shorter than LLL indicated length (6)")
+}
+
+func TestCodec_legacy_maps(t *testing.T) {
+	previous, _ := iso8583.LookupCodec("ebcdic")
+	defer iso8583.RegisterCodec("ebcdic", previous)
+
+	// Inbuilt map entries use the registered codec.
+	iso8583.RegisterCodec("ebcdic", hexCodec{})
+	b, err := iso8583.MarshalEncodings["ebcdic"]([]byte{0x12})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("12"), b)
+
+	b, err = iso8583.UnmarshalDecodings["ebcdic"]([]byte("12"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x12}, b)
+
+	// Map entries take precedence over registered codecs.
+	iso8583.MarshalEncodings["test_hex"] = func(b []byte) ([]byte, error) { return nil, errors.New("map_encoding") }
+	defer delete(iso8583.MarshalEncodings, "test_hex")
+	iso8583.RegisterCodec("test_hex", hexCodec{})
+
+	_, err = iso8583.VAR("12").MarshalISO8583(2, "test_hex")
+	assert.EqualError(t, err, "encoder 'test_hex' returned error: map_encoding")
+}
+
+func TestCodec_ebcdic(t *testing.T) {
+	c, ok := iso8583.LookupCodec("ebcdic037")
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+
+	b, err := c.Encode([]byte("[0800]"))
+	assert.Nil(t, err)
+	assert.Equal(t, ebcdic.V037.FromGoString("[0800]"), b)
+	assert.Equal(t, 6, c.EncodedLen(6))
+	assert.Equal(t, 6, c.DecodedLen(6))
+
+	_, err = c.Encode([]byte("鲸"))
+	assert.True(t, errors.Is(err, ebcdic.ErrUnmappable))
+}
+
+func TestRegisterCodec_concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(2)
+		go func(n int) {
+			defer wg.Done()
+			iso8583.RegisterCodec(fmt.Sprintf("test_concurrent_%v", n), hexCodec{})
+		}(n)
+		go func() {
+			defer wg.Done()
+			b, err := iso8583.VAR("0800").MarshalISO8583(4, "ebcdic")
+			assert.Nil(t, err)
+			assert.Len(t, b, 4)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,326 @@
+package iso8583
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/jattento/go-iso8583/pkg/bitmap"
+)
+
+var (
+	marshalerType   = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+)
+
+// isComposite indicates if v is a struct (or a pointer to one) that does not implement Marshaler or Unmarshaler,
+// these fields are composites: its subfields are marshaled and unmarshaled by position one after the other.
+func isComposite(v reflect.Value) bool {
+	t := v.Type()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	ptr := reflect.PtrTo(t)
+	return !t.Implements(marshalerType) && !ptr.Implements(marshalerType) && !ptr.Implements(unmarshalerType)
+}
+
+// readSubfields returns the tagged fields of a composite sorted by position, nil pointers are included.
+// The bitmap of bitmapped composites is always the first one.
+// An error is returned if no field is tagged with sub, as the struct would be marshaled without content.
+func readSubfields(strct reflect.Value) ([]messageField, error) {
+	for strct.Kind() == reflect.Ptr {
+		strct = strct.Elem()
+	}
+
+	subfields := make([]messageField, 0)
+	processed := make(map[string]bool)
+	var hasSub bool
+	for index := 0; index < strct.NumField(); index++ {
+		structFieldValue, tag, err := getStructFieldData(strct, index)
+		if errors.Is(err, errUnexportedField) || errors.Is(err, errAnonymousField) || errors.Is(err, errTagsNotFound) ||
+			tag.Disesteem {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if n, err := strconv.Atoi(tag.Field); (err != nil || n < 1) && tag.Field != _tagBITMAP {
+			return nil, fmt.Errorf("invalid subfield name: %s", tag.Field)
+		}
+
+		if processed[tag.Field] {
+			return nil, fmt.Errorf("subfield %s is repeated", tag.Field)
+		}
+		processed[tag.Field] = true
+		hasSub = hasSub || tag.Sub
+
+		subfields = append(subfields, messageField{
+			Name:  strct.Type().Field(index).Name,
+			Value: structFieldValue,
+			tags:  tag,
+		})
+	}
+
+	if !hasSub {
+		return nil, fmt.Errorf("%s does not implement Marshaler and Unmarshaler nor contains sub tagged subfields",
+			strct.Type())
+	}
+
+	sortFieldsStable(subfields, func(index int) string { return subfields[index].Field })
+
+	return subfields, nil
+}
+
+// marshalComposite returns the subfields of v marshaled by position.
+func marshalComposite(v reflect.Value, tag tags) ([]byte, error) {
+	prefixEncoding, contentEncoding := ReadSplitEncodings(tag.Encoding)
+
+	subfields, err := readSubfields(v)
+	if err != nil {
+		return nil, err
+	}
+
+	present := make([]messageField, 0, len(subfields))
+	for _, sub := range subfields {
+		if isNil(sub.Value) || (sub.OmitEmpty && sub.Value.IsZero()) {
+			continue
+		}
+
+		present = append(present, sub)
+	}
+
+	content := make([]byte, 0)
+	if len(subfields) > 0 && subfields[0].Field == _tagBITMAP {
+		if content, present, err = marshalSubBitmap(subfields[0], present); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, sub := range present {
+
+		if sub.Encoding == "" {
+			sub.Encoding = contentEncoding
+		}
+
+		b, err := marshalSubfield(sub.Value, sub.tags)
+		if err != nil {
+			return nil, fmt.Errorf("subfield %s: %w", sub.Field, err)
+		}
+
+		content = append(content, b...)
+	}
+
+	if tag.Prefix > 0 {
+		return LengthMarshal(tag.Prefix, content, prefixEncoding)
+	}
+
+	if tag.Length != 0 && len(content) != tag.Length {
+		return nil, fmt.Errorf("composite content is %v bytes long but should be %v", len(content), tag.Length)
+	}
+
+	return content, nil
+}
+
+// marshalSubfield marshals a subfield, which can be a composite too.
+func marshalSubfield(v reflect.Value, tag tags) ([]byte, error) {
+	if marshaler, isMarshaler := withTagLayout(v.Interface(), tag).(Marshaler); isMarshaler {
+		return marshaler.MarshalISO8583(tag.Length, tag.Encoding)
+	}
+
+	if isComposite(v) {
+		return marshalComposite(v, tag)
+	}
+
+	return nil, errors.New("does not implement Marshaler interface but does have iso8583 tags")
+}
+
+// unmarshalComposite loads the subfields of the struct pointed by v and returns the amount of consumed bytes.
+func unmarshalComposite(v reflect.Value, b []byte, tag tags) (int, error) {
+	prefixEncoding, contentEncoding := ReadSplitEncodings(tag.Encoding)
+
+	content, consumed := b, 0
+	switch {
+	case tag.Prefix > 0:
+		n, prefixed, err := LengthUnmarshal(tag.Prefix, b, tag.Prefix, prefixEncoding)
+		if err != nil {
+			return 0, err
+		}
+		content, consumed = prefixed, n
+	case tag.Length > 0:
+		if len(b) < tag.Length {
+			return 0, fmt.Errorf("message remain (%v bytes) is shorter than indicated length: %v", len(b), tag.Length)
+		}
+		content, consumed = b[:tag.Length], tag.Length
+	}
+
+	subfields, err := readSubfields(v)
+	if err != nil {
+		return 0, err
+	}
+
+	var offset int
+	var bits map[int]bool
+	for _, sub := range subfields {
+		// Trailing subfields of variable composites can be absent.
+		if tag.Prefix > 0 && offset == len(content) && sub.Field != _tagBITMAP {
+			break
+		}
+
+		// Only the subfields indicated by the bitmap of bitmapped composites are present.
+		if bits != nil {
+			n, _ := strconv.Atoi(sub.Field)
+			if !bits[n] {
+				continue
+			}
+			delete(bits, n)
+		}
+
+		if sub.Encoding == "" && sub.Field != _tagBITMAP {
+			sub.Encoding = contentEncoding
+		}
+
+		n, err := unmarshalSubfield(sub.Value, content[offset:], sub.tags)
+		if err != nil {
+			return 0, fmt.Errorf("subfield %s: %w", sub.Field, err)
+		}
+
+		if n > len(content)-offset {
+			return 0, fmt.Errorf("subfield %s: Unmarshaler returned a n higher than unconsumed bytes", sub.Field)
+		}
+
+		offset += n
+
+		if sub.Field == _tagBITMAP {
+			if bits, err = subBitmapBits(sub); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	for n, on := range bits {
+		if on {
+			return 0, fmt.Errorf("subfield %v is indicated by the bitmap but it is not declared", n)
+		}
+	}
+
+	if tag.Prefix == 0 && tag.Length == 0 {
+		return offset, nil
+	}
+
+	if offset != len(content) {
+		return 0, fmt.Errorf("subfields consumed %v bytes but composite content is %v bytes long",
+			offset, len(content))
+	}
+
+	return consumed, nil
+}
+
+// unmarshalSubfield unmarshals a subfield, which can be a composite too. Nil pointers are allocated.
+func unmarshalSubfield(v reflect.Value, b []byte, tag tags) (int, error) {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	if v.Kind() != reflect.Ptr {
+		v = v.Addr()
+	}
+
+	if unmarshaler, isUnmarshaler := withTagLayout(v.Interface(), tag).(Unmarshaler); isUnmarshaler {
+		if tag.Field == _tagBITMAP {
+			tag.Length = bitmapLength(tag)
+		}
+
+		return unmarshaler.UnmarshalISO8583(b, tag.Length, tag.Encoding)
+	}
+
+	if isComposite(v) {
+		return unmarshalComposite(v, b, tag)
+	}
+
+	return 0, errors.New("does not implement Unmarshaler interface")
+}
+
+// marshalSubBitmap returns the bitmap of a bitmapped composite, which indicates the present subfields,
+// and the present subfields without the bitmap. The bitmap is always marshaled, even if no subfield is present.
+func marshalSubBitmap(bitmapField messageField, present []messageField) ([]byte, []messageField, error) {
+	v := bitmapField.Value
+	if isNil(v) {
+		v = reflect.New(v.Type().Elem())
+	}
+
+	marshaler, isMarshaler := v.Interface().(MarshalerBitmap)
+	if !isMarshaler {
+		return nil, nil, errors.New("bitmap subfield does not implement MarshalerBitmap")
+	}
+
+	length := bitmapLength(bitmapField.tags)
+
+	bits := make(map[int]bool)
+	for n := 1; n <= length; n++ {
+		bits[n] = false
+	}
+
+	subfields := make([]messageField, 0, len(present))
+	for _, sub := range present {
+		if sub.Field == _tagBITMAP {
+			continue
+		}
+
+		// Already checked by readSubfields.
+		n, _ := strconv.Atoi(sub.Field)
+		if n > length {
+			return nil, nil, fmt.Errorf("subfield %s can not be indicated by a %v bits bitmap", sub.Field, length)
+		}
+
+		bits[n] = true
+		subfields = append(subfields, sub)
+	}
+
+	b, err := marshaler.MarshalISO8583Bitmap(bits, bitmapField.Encoding)
+	if err != nil {
+		return nil, nil, fmt.Errorf("subfield bitmap: %w", err)
+	}
+
+	// BITMAP returns an empty slice when no bit is on, but the bitmap of a composite is always present.
+	if len(b) == 0 {
+		if b, err = applyEncoding(bitmap.ToBytes(bits), bitmapField.Encoding); err != nil {
+			return nil, nil, fmt.Errorf("subfield bitmap: %w", err)
+		}
+	}
+
+	return b, subfields, nil
+}
+
+// subBitmapBits returns the bits indicated by the unmarshaled bitmap of a bitmapped composite.
+func subBitmapBits(bitmapField messageField) (map[int]bool, error) {
+	v := bitmapField.Value
+	if v.Kind() != reflect.Ptr {
+		v = v.Addr()
+	}
+
+	unmarshaler, isBitmap := v.Interface().(UnmarshalerBitmap)
+	if !isBitmap {
+		return nil, errors.New("bitmap subfield does not implement UnmarshalerBitmap")
+	}
+
+	bits, err := unmarshaler.Bits()
+	if err != nil {
+		return nil, fmt.Errorf("subfield bitmap: %w", err)
+	}
+
+	// A copy is returned because bits are deleted while subfields are read.
+	output := make(map[int]bool)
+	for n, on := range bits {
+		output[n] = on
+	}
+
+	return output, nil
+}
@@ -0,0 +1,428 @@
+package iso8583_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/bitmap"
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+type posEntryMode struct {
+	PANEntryMode iso8583.VAR `iso8583:"sub:1,length:2,format:n"`
+	PINEntry     iso8583.VAR `iso8583:"sub:2,length:1,format:n"`
+}
+
+type nameLocation struct {
+	Name    iso8583.VAR `iso8583:"sub:1,length:23"`
+	City    iso8583.VAR `iso8583:"sub:2,length:13"`
+	State   iso8583.VAR `iso8583:"sub:3,length:3"`
+	Country iso8583.VAR `iso8583:"sub:4,length:1"`
+}
+
+type posData struct {
+	Attendance  iso8583.VAR   `iso8583:"sub:1,length:1"`
+	Terminal    iso8583.VAR   `iso8583:"sub:2,length:1"`
+	PostalCode  iso8583.LLVAR `iso8583:"sub:3,length:2,omitempty"`
+	Unexported  string
+	Disesteemed iso8583.VAR `iso8583:"sub:4,-"`
+}
+
+type originalTransmission struct {
+	Date iso8583.VAR `iso8583:"sub:1,length:4"`
+	Time iso8583.VAR `iso8583:"sub:2,length:6"`
+}
+
+type originalData struct {
+	MTI          iso8583.VAR           `iso8583:"sub:1,length:4"`
+	STAN         iso8583.VAR           `iso8583:"sub:2,length:6"`
+	Transmission *originalTransmission `iso8583:"sub:3,length:10"`
+}
+
+type compositeTestMessage struct {
+	MTI          iso8583.MTI    `iso8583:"mti,length:4"`
+	Bitmap       iso8583.BITMAP `iso8583:"bitmap"`
+	Secondary    iso8583.BITMAP `iso8583:"1"`
+	EntryMode    posEntryMode   `iso8583:"22,length:3,omitempty"`
+	NameLocation nameLocation   `iso8583:"43,length:40,encoding:ebcdic,omitempty"`
+	POSData      *posData       `iso8583:"61,prefix:3,omitempty"`
+	Original     originalData   `iso8583:"90,length:20,omitempty"`
+}
+
+func TestComposite_MarshalUnmarshal(t *testing.T) {
+	input := compositeTestMessage{
+		MTI:       iso8583.MTI{MTI: "0100"},
+		EntryMode: posEntryMode{PANEntryMode: "05", PINEntry: "1"},
+		NameLocation: nameLocation{
+			Name:    "ACME                   ",
+			City:    "BUENOS AIRES ",
+			State:   "BUE",
+			Country: "A",
+		},
+		POSData: &posData{Attendance: "0", Terminal: "1"},
+		Original: originalData{MTI: "0100", STAN: "000123",
+			Transmission: &originalTransmission{Date: "1231", Time: "235959"}},
+	}
+
+	ebcdicNameLocation, _ := iso8583.MarshalEncodings["ebcdic"]([]byte("ACME                   BUENOS AIRES BUEA"))
+	expected := appendBytes(
+		[]byte("0100"),
+		bitmap.ToBytes(map[int]bool{1: true, 22: true, 43: true, 61: true, 64: false}),
+		bitmap.ToBytes(map[int]bool{26: true, 64: false}),
+		[]byte("051"),
+		ebcdicNameLocation,
+		[]byte("00201"),
+		[]byte("01000001231231235959"),
+	)
+
+	b, err := iso8583.Marshal(input)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, expected, b)
+
+	var output compositeTestMessage
+	n, err := iso8583.Unmarshal(b, &output)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, len(b), n)
+	assert.Equal(t, input.EntryMode, output.EntryMode)
+	assert.Equal(t, input.NameLocation, output.NameLocation)
+	assert.Equal(t, input.POSData, output.POSData)
+	assert.Equal(t, input.Original, output.Original)
+}
+
+func TestComposite_prefixed_optional_subfields(t *testing.T) {
+	type message struct {
+		MTI     iso8583.MTI    `iso8583:"mti,length:4"`
+		Bitmap  iso8583.BITMAP `iso8583:"bitmap"`
+		POSData posData        `iso8583:"61,prefix:3,encoding:ascii"`
+	}
+
+	b, err := iso8583.Marshal(message{MTI: iso8583.MTI{MTI: "0100"},
+		POSData: posData{Attendance: "0", Terminal: "1", PostalCode: "1414"}})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, appendBytes([]byte("0100"), bitmap.ToBytes(map[int]bool{61: true, 64: false}),
+		[]byte("008"+"01"+"041414")), b)
+
+	var output message
+	_, err = iso8583.Unmarshal(b, &output)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, posData{Attendance: "0", Terminal: "1", PostalCode: "1414"}, output.POSData)
+}
+
+func TestComposite_errors(t *testing.T) {
+	type invalidSubfield struct {
+		Value iso8583.VAR `iso8583:"sub:name,length:1"`
+	}
+
+	type repeatedSubfield struct {
+		A iso8583.VAR `iso8583:"sub:1,length:1"`
+		B iso8583.VAR `iso8583:"sub:1,length:1"`
+	}
+
+	type notMarshaler struct {
+		Value int `iso8583:"sub:1,length:1"`
+	}
+
+	type withoutSubfields struct {
+		Value iso8583.VAR `iso8583:"1,length:1"`
+	}
+
+	testList := []struct {
+		Name        string
+		Input       interface{}
+		OutputError string
+	}{
+		{
+			Name: "length_mismatch",
+			Input: &struct {
+				MTI       iso8583.MTI    `iso8583:"mti,length:4"`
+				Bitmap    iso8583.BITMAP `iso8583:"bitmap"`
+				EntryMode posEntryMode   `iso8583:"22,length:3"`
+			}{MTI: iso8583.MTI{MTI: "0100"}, EntryMode: posEntryMode{PANEntryMode: "5", PINEntry: "1"}},
+			OutputError: "iso8583.marshal: field 22 cant be marshaled: composite content is 2 bytes long but should be 3",
+		},
+		{
+			Name: "invalid_subfield_name",
+			Input: &struct {
+				MTI    iso8583.MTI     `iso8583:"mti,length:4"`
+				Bitmap iso8583.BITMAP  `iso8583:"bitmap"`
+				Field  invalidSubfield `iso8583:"2,length:1"`
+			}{MTI: iso8583.MTI{MTI: "0100"}},
+			OutputError: "iso8583.marshal: field 2 cant be marshaled: invalid subfield name: name",
+		},
+		{
+			Name: "repeated_subfield",
+			Input: &struct {
+				MTI    iso8583.MTI      `iso8583:"mti,length:4"`
+				Bitmap iso8583.BITMAP   `iso8583:"bitmap"`
+				Field  repeatedSubfield `iso8583:"2,length:2"`
+			}{MTI: iso8583.MTI{MTI: "0100"}},
+			OutputError: "iso8583.marshal: field 2 cant be marshaled: subfield 1 is repeated",
+		},
+		{
+			Name: "subfield_not_marshaler",
+			Input: &struct {
+				MTI    iso8583.MTI    `iso8583:"mti,length:4"`
+				Bitmap iso8583.BITMAP `iso8583:"bitmap"`
+				Field  notMarshaler   `iso8583:"2,length:1"`
+			}{MTI: iso8583.MTI{MTI: "0100"}},
+			OutputError: "iso8583.marshal: field 2 cant be marshaled: subfield 1: " +
+				"does not implement Marshaler interface but does have iso8583 tags",
+		},
+		{
+			Name: "without_subfields",
+			Input: &struct {
+				MTI    iso8583.MTI      `iso8583:"mti,length:4"`
+				Bitmap iso8583.BITMAP   `iso8583:"bitmap"`
+				Field  withoutSubfields `iso8583:"2,length:1"`
+			}{MTI: iso8583.MTI{MTI: "0100"}, Field: withoutSubfields{Value: "X"}},
+			OutputError: "iso8583.marshal: field 2 cant be marshaled: iso8583_test.withoutSubfields does not " +
+				"implement Marshaler and Unmarshaler nor contains sub tagged subfields",
+		},
+		{
+			Name: "time_field",
+			Input: &struct {
+				MTI    iso8583.MTI    `iso8583:"mti,length:4"`
+				Bitmap iso8583.BITMAP `iso8583:"bitmap"`
+				Time   time.Time      `iso8583:"7,length:10"`
+			}{MTI: iso8583.MTI{MTI: "0100"}, Time: time.Date(2020, 10, 18, 0, 0, 0, 0, time.UTC)},
+			OutputError: "iso8583.marshal: field 7 cant be marshaled: time.Time does not " +
+				"implement Marshaler and Unmarshaler nor contains sub tagged subfields",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := iso8583.Marshal(testCase.Input)
+			assert.EqualError(t, err, testCase.OutputError)
+		})
+	}
+
+	var msg struct {
+		MTI    iso8583.MTI      `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP   `iso8583:"bitmap"`
+		Field  withoutSubfields `iso8583:"2,length:1"`
+	}
+	_, err := iso8583.Unmarshal(appendBytes([]byte("0100"), bitmap.ToBytes(map[int]bool{2: true, 64: false}),
+		[]byte("X")), &msg)
+	assert.EqualError(t, err, "iso8583.unmarshal: cant unmarshal field 2: iso8583_test.withoutSubfields does not "+
+		"implement Marshaler and Unmarshaler nor contains sub tagged subfields")
+}
+
+func TestComposite_unmarshal_errors(t *testing.T) {
+	header := appendBytes([]byte("0100"), bitmap.ToBytes(map[int]bool{22: true, 61: true, 64: false}))
+
+	testList := []struct {
+		Name        string
+		Input       []byte
+		OutputError string
+	}{
+		{
+			Name:        "short_message",
+			Input:       appendBytes(header, []byte("05")),
+			OutputError: "iso8583.unmarshal: cant unmarshal field 22: message remain (2 bytes) is shorter than indicated length: 3",
+		},
+		{
+			Name:  "subfield_error",
+			Input: appendBytes(header, []byte("051"), []byte("004"+"01"+"09")),
+			OutputError: "iso8583.unmarshal: cant unmarshal field 61: subfield 3: " +
+				"message remain (0 bytes) is shorter than LL indicated length (9)",
+		},
+		{
+			Name:  "content_not_consumed",
+			Input: appendBytes(header, []byte("051"), []byte("009"+"01"+"0212"+"XXX")),
+			OutputError: "iso8583.unmarshal: cant unmarshal field 61: subfields consumed 6 bytes " +
+				"but composite content is 9 bytes long",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var msg compositeTestMessage
+			_, err := iso8583.Unmarshal(testCase.Input, &msg)
+			assert.EqualError(t, err, testCase.OutputError)
+		})
+	}
+}
+
+func TestComposite_Validate_and_Dump(t *testing.T) {
+	msg := compositeTestMessage{
+		MTI:       iso8583.MTI{MTI: "0100"},
+		EntryMode: posEntryMode{PANEntryMode: "0A", PINEntry: "1"},
+	}
+
+	assert.Equal(t, []string{
+		"field 22.1: invalid format: character 'A' at position 1 is not allowed by format 'n'",
+	}, errorMessages(iso8583.Validate(msg)))
+
+	o, err := iso8583.Dump(msg)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Contains(t, o, "22     EntryMode: 0A1\n")
+}
+
+type customPaymentService struct {
+	Bitmap                       iso8583.BITMAP `iso8583:"bitmap,length:8"`
+	AuthorizationCharacteristics iso8583.VAR    `iso8583:"sub:1,length:1,omitempty"`
+	TransactionID                iso8583.VAR    `iso8583:"sub:2,length:15,omitempty"`
+	ValidationCode               iso8583.VAR    `iso8583:"sub:3,length:4,omitempty"`
+	MarketIndicator              iso8583.VAR    `iso8583:"sub:4,length:1,omitempty"`
+}
+
+type privateUse struct {
+	Bitmap     iso8583.BITMAP  `iso8583:"bitmap,length:24"`
+	Indicator  iso8583.VAR     `iso8583:"sub:1,length:1,omitempty"`
+	Reference  iso8583.LLVAR   `iso8583:"sub:13,length:2,omitempty"`
+	Additional *iso8583.LLLVAR `iso8583:"sub:24,length:3"`
+	Undeclared iso8583.BINARY  `iso8583:"sub:20,length:1,-"`
+	Composite  *posEntryMode   `iso8583:"sub:9,length:3"`
+}
+
+type bitmappedTestMessage struct {
+	MTI     iso8583.MTI          `iso8583:"mti,length:4"`
+	Bitmap  iso8583.BITMAP       `iso8583:"bitmap"`
+	CPS     customPaymentService `iso8583:"62,prefix:2,omitempty"`
+	Private privateUse           `iso8583:"63,prefix:3,encoding:ascii,omitempty"`
+}
+
+func TestComposite_bitmapped(t *testing.T) {
+	additional := iso8583.LLLVAR("ADD")
+	input := bitmappedTestMessage{
+		MTI: iso8583.MTI{MTI: "0100"},
+		CPS: customPaymentService{AuthorizationCharacteristics: "Y", TransactionID: "123456789012345",
+			MarketIndicator: "H"},
+		Private: privateUse{Indicator: "1", Additional: &additional,
+			Composite: &posEntryMode{PANEntryMode: "05", PINEntry: "1"}},
+	}
+
+	expected := appendBytes(
+		[]byte("0100"),
+		bitmap.ToBytes(map[int]bool{62: true, 63: true, 64: false}),
+		[]byte("18"), []byte{0xD0}, []byte("Y"+"123456789012345"+"H"),
+		[]byte("013"), []byte{0x80, 0x80, 0x01}, []byte("1"+"051"+"003ADD"),
+	)
+
+	b, err := iso8583.Marshal(input)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, expected, b)
+
+	var output bitmappedTestMessage
+	n, err := iso8583.Unmarshal(b, &output)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, len(b), n)
+
+	bits, _ := output.CPS.Bitmap.Bits()
+	assert.Equal(t, map[int]bool{1: true, 2: true, 3: false, 4: true, 5: false, 6: false, 7: false, 8: false}, bits)
+
+	output.CPS.Bitmap, output.Private.Bitmap = iso8583.BITMAP{}, iso8583.BITMAP{}
+	assert.Equal(t, input.CPS, output.CPS)
+	assert.Equal(t, input.Private, output.Private)
+}
+
+func TestComposite_bitmapped_without_subfields(t *testing.T) {
+	type message struct {
+		MTI    iso8583.MTI          `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP       `iso8583:"bitmap"`
+		CPS    customPaymentService `iso8583:"62,prefix:2"`
+	}
+
+	b, err := iso8583.Marshal(message{MTI: iso8583.MTI{MTI: "0100"}})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, appendBytes([]byte("0100"), bitmap.ToBytes(map[int]bool{62: true, 64: false}),
+		[]byte("01"), []byte{0x00}), b)
+
+	var output message
+	_, err = iso8583.Unmarshal(b, &output)
+	assert.Nil(t, err)
+}
+
+func TestComposite_bitmapped_encoded_bitmap(t *testing.T) {
+	iso8583.RegisterCodec("test_hex", hexCodec{})
+
+	type hexBitmapped struct {
+		Bitmap iso8583.BITMAP `iso8583:"bitmap,length:8,encoding:test_hex"`
+		Value  iso8583.VAR    `iso8583:"sub:2,length:1,omitempty"`
+	}
+
+	type message struct {
+		MTI    iso8583.MTI    `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP `iso8583:"bitmap"`
+		Field  hexBitmapped   `iso8583:"62,prefix:2"`
+	}
+
+	header := appendBytes([]byte("0100"), bitmap.ToBytes(map[int]bool{62: true, 64: false}))
+
+	testList := []struct {
+		Name        string
+		Input       message
+		OutputBytes []byte
+	}{
+		{
+			Name:        "empty",
+			Input:       message{MTI: iso8583.MTI{MTI: "0100"}},
+			OutputBytes: appendBytes(header, []byte("02"+"00")),
+		},
+		{
+			Name:        "with_subfield",
+			Input:       message{MTI: iso8583.MTI{MTI: "0100"}, Field: hexBitmapped{Value: "X"}},
+			OutputBytes: appendBytes(header, []byte("03"+"40"+"X")),
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			b, err := iso8583.Marshal(testCase.Input)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputBytes, b)
+
+			var output message
+			n, err := iso8583.Unmarshal(b, &output)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, len(b), n)
+			assert.Equal(t, testCase.Input.Field.Value, output.Field.Value)
+		})
+	}
+}
+
+func TestComposite_bitmapped_errors(t *testing.T) {
+	type tooLong struct {
+		Bitmap iso8583.BITMAP `iso8583:"bitmap,length:8"`
+		Value  iso8583.VAR    `iso8583:"sub:9,length:1"`
+	}
+
+	_, err := iso8583.Marshal(struct {
+		MTI    iso8583.MTI    `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP `iso8583:"bitmap"`
+		Field  tooLong        `iso8583:"62,prefix:2"`
+	}{MTI: iso8583.MTI{MTI: "0100"}})
+	assert.EqualError(t, err, "iso8583.marshal: field 62 cant be marshaled: "+
+		"subfield 9 can not be indicated by a 8 bits bitmap")
+
+	var msg bitmappedTestMessage
+	_, err = iso8583.Unmarshal(appendBytes([]byte("0100"), bitmap.ToBytes(map[int]bool{62: true, 64: false}),
+		[]byte("02"), []byte{0x08}, []byte("X")), &msg)
+	assert.EqualError(t, err, "iso8583.unmarshal: cant unmarshal field 62: "+
+		"subfield 5 is indicated by the bitmap but it is not declared")
+}
@@ -0,0 +1,333 @@
+package iso8583
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// The following variables are read by date and time fields on every marshal and unmarshal, so they must be set
+// during initialization: modifying them while messages are being processed is a data race.
+// Fields with other settings in the same process can be implemented as types with their own methods.
+var (
+	// TimeLocation is the time zone in which date and time fields are marshaled and unmarshaled, UTC by default.
+	// Values are converted to it on marshal, so the same instant is represented regardless of its location.
+	TimeLocation = time.UTC
+
+	// TimeNow returns the reference time used to infer the year or date omitted by date and time fields,
+	// it can be replaced to unmarshal messages of the past.
+	TimeNow = time.Now
+
+	// YearWindow is the amount of years before the current one that two digits years can refer to,
+	// following years up to complete a century are in the future. For example with the default 50
+	// on 2026 "76" is 1976 and "75" is 2075.
+	YearWindow = 50
+)
+
+// Layouts of date and time fields in time package format.
+const (
+	_layoutMMDDHHMMSS = "0102150405"
+	_layoutMMDD       = "0102"
+	_layoutYYMM       = "0601"
+	_layoutHHMMSS     = "150405"
+)
+
+// MMDDHHMMSS is a date and time field like the transmission date and time (DE7), for example:
+// 	TransmissionDateAndTime iso8583.MMDDHHMMSS `iso8583:"7,encoding:ebcdic"`
+//
+// On unmarshal the omitted year is the one that puts the date closest to TimeNow,
+// so a message of 31 December received on 1 January belongs to the previous year.
+type MMDDHHMMSS struct {
+	time.Time
+}
+
+// MarshalISO8583 formats the time as MMDDhhmmss in TimeLocation.
+func (t MMDDHHMMSS) MarshalISO8583(length int, enc string) ([]byte, error) {
+	return marshalTime(t.Time, _layoutMMDDHHMMSS, enc)
+}
+
+// UnmarshalISO8583 parses MMDDhhmmss in TimeLocation inferring the year.
+func (t *MMDDHHMMSS) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	return unmarshalTime(b, &t.Time, _layoutMMDDHHMMSS, inferYear, enc)
+}
+
+// String returns the time as it is represented in the message.
+func (t MMDDHHMMSS) String() string { return formatTime(t.Time, _layoutMMDDHHMMSS) }
+
+// MarshalJSON represents the time as it is represented in the message.
+func (t MMDDHHMMSS) MarshalJSON() ([]byte, error) { return json.Marshal(t.String()) }
+
+// UnmarshalJSON reads the time as it is represented in the message.
+func (t *MMDDHHMMSS) UnmarshalJSON(data []byte) error {
+	return unmarshalTimeJSON(data, &t.Time, _layoutMMDDHHMMSS, inferYear)
+}
+
+// ValidateISO8583 checks that the length tag, if present, is the length of MMDDhhmmss.
+func (t MMDDHHMMSS) ValidateISO8583(length int, format string) error {
+	return checkLength(len(_layoutMMDDHHMMSS), length)
+}
+
+// MMDD is a date field like the local transaction (DE13), settlement (DE15) and capture (DE17) dates, for example:
+// 	DateLocalTransaction iso8583.MMDD `iso8583:"13,encoding:ebcdic"`
+//
+// On unmarshal the omitted year is the one that puts the date closest to TimeNow.
+type MMDD struct {
+	time.Time
+}
+
+// MarshalISO8583 formats the date as MMDD in TimeLocation.
+func (t MMDD) MarshalISO8583(length int, enc string) ([]byte, error) {
+	return marshalTime(t.Time, _layoutMMDD, enc)
+}
+
+// UnmarshalISO8583 parses MMDD in TimeLocation inferring the year.
+func (t *MMDD) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	return unmarshalTime(b, &t.Time, _layoutMMDD, inferYear, enc)
+}
+
+// String returns the date as it is represented in the message.
+func (t MMDD) String() string { return formatTime(t.Time, _layoutMMDD) }
+
+// MarshalJSON represents the date as it is represented in the message.
+func (t MMDD) MarshalJSON() ([]byte, error) { return json.Marshal(t.String()) }
+
+// UnmarshalJSON reads the date as it is represented in the message.
+func (t *MMDD) UnmarshalJSON(data []byte) error {
+	return unmarshalTimeJSON(data, &t.Time, _layoutMMDD, inferYear)
+}
+
+// ValidateISO8583 checks that the length tag, if present, is the length of MMDD.
+func (t MMDD) ValidateISO8583(length int, format string) error {
+	return checkLength(len(_layoutMMDD), length)
+}
+
+// YYMM is a year and month field like the expiration date (DE14), for example:
+// 	DateExpiration iso8583.YYMM `iso8583:"14,encoding:ebcdic"`
+//
+// On unmarshal the century is inferred using YearWindow and the day is the first of the month.
+type YYMM struct {
+	time.Time
+}
+
+// MarshalISO8583 formats the date as YYMM in TimeLocation.
+func (t YYMM) MarshalISO8583(length int, enc string) ([]byte, error) {
+	return marshalTime(t.Time, _layoutYYMM, enc)
+}
+
+// UnmarshalISO8583 parses YYMM in TimeLocation inferring the century.
+func (t *YYMM) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	return unmarshalTime(b, &t.Time, _layoutYYMM, inferCentury, enc)
+}
+
+// String returns the date as it is represented in the message.
+func (t YYMM) String() string { return formatTime(t.Time, _layoutYYMM) }
+
+// MarshalJSON represents the date as it is represented in the message.
+func (t YYMM) MarshalJSON() ([]byte, error) { return json.Marshal(t.String()) }
+
+// UnmarshalJSON reads the date as it is represented in the message.
+func (t *YYMM) UnmarshalJSON(data []byte) error {
+	return unmarshalTimeJSON(data, &t.Time, _layoutYYMM, inferCentury)
+}
+
+// ValidateISO8583 checks that the length tag, if present, is the length of YYMM
+// and that the year can be inferred back from its two digits.
+func (t YYMM) ValidateISO8583(length int, format string) error {
+	if err := checkLength(len(_layoutYYMM), length); err != nil {
+		return err
+	}
+
+	year := inLocation(t.Time).Year()
+	first := TimeNow().In(TimeLocation).Year() - YearWindow
+	if year < first || year > first+99 {
+		return fmt.Errorf("%w: year %v is out of the window from %v to %v", ErrInvalidFormat, year, first, first+99)
+	}
+
+	return nil
+}
+
+// HHMMSS is a time field like the local transaction time (DE12), for example:
+// 	TimeLocalTransaction iso8583.HHMMSS `iso8583:"12,encoding:ebcdic"`
+//
+// On unmarshal the omitted date is the one that puts the time closest to TimeNow.
+type HHMMSS struct {
+	time.Time
+}
+
+// MarshalISO8583 formats the time as hhmmss in TimeLocation.
+func (t HHMMSS) MarshalISO8583(length int, enc string) ([]byte, error) {
+	return marshalTime(t.Time, _layoutHHMMSS, enc)
+}
+
+// UnmarshalISO8583 parses hhmmss in TimeLocation inferring the date.
+func (t *HHMMSS) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	return unmarshalTime(b, &t.Time, _layoutHHMMSS, inferDate, enc)
+}
+
+// String returns the time as it is represented in the message.
+func (t HHMMSS) String() string { return formatTime(t.Time, _layoutHHMMSS) }
+
+// MarshalJSON represents the time as it is represented in the message.
+func (t HHMMSS) MarshalJSON() ([]byte, error) { return json.Marshal(t.String()) }
+
+// UnmarshalJSON reads the time as it is represented in the message.
+func (t *HHMMSS) UnmarshalJSON(data []byte) error {
+	return unmarshalTimeJSON(data, &t.Time, _layoutHHMMSS, inferDate)
+}
+
+// ValidateISO8583 checks that the length tag, if present, is the length of hhmmss.
+func (t HHMMSS) ValidateISO8583(length int, format string) error {
+	return checkLength(len(_layoutHHMMSS), length)
+}
+
+// inference completes the parts of a date omitted by a layout.
+type inference func(content string, layout string) (time.Time, error)
+
+func marshalTime(t time.Time, layout string, enc string) ([]byte, error) {
+	return applyEncoding([]byte(formatTime(t, layout)), enc)
+}
+
+// formatTime formats t in TimeLocation.
+func formatTime(t time.Time, layout string) string {
+	return inLocation(t).Format(layout)
+}
+
+// inLocation returns t in TimeLocation, zero values are kept as they are so they are represented as zeros.
+func inLocation(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	return t.In(TimeLocation)
+}
+
+func unmarshalTime(b []byte, t *time.Time, layout string, infer inference, enc string) (int, error) {
+	if b == nil {
+		return 0, errors.New("bytes input is nil")
+	}
+
+	content, n, err := decodeCharacters(b, len(layout), enc)
+	if err != nil {
+		return 0, err
+	}
+
+	if *t, err = parseTime(string(content), layout, infer); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func unmarshalTimeJSON(data []byte, t *time.Time, layout string, infer inference) error {
+	var content string
+	if err := json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+
+	parsed, err := parseTime(content, layout, infer)
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
+
+// parseTime checks that content only contains digits, as time package allows signs and spaces in some elements,
+// and infers the omitted parts of the date.
+func parseTime(content string, layout string, infer inference) (time.Time, error) {
+	if len(content) != len(layout) {
+		return time.Time{}, fmt.Errorf("date '%s' should be %v digits long", content, len(layout))
+	}
+
+	for position, r := range content {
+		if !isDigit(r) {
+			return time.Time{}, fmt.Errorf("character %q at position %v of date '%s' is not a digit",
+				r, position, content)
+		}
+	}
+
+	return infer(content, layout)
+}
+
+// inferYear parses a date without year and uses the year that puts it closest to TimeNow.
+// Impossible dates like 0230 are rejected, as are 0229 if no leap year is close.
+func inferYear(content string, layout string) (time.Time, error) {
+	// Year 2000 is a leap year so 29 of February can be parsed.
+	parsed, err := time.ParseInLocation("2006"+layout, "2000"+content, TimeLocation)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date '%s': %w", content, err)
+	}
+
+	now := TimeNow().In(TimeLocation)
+
+	var closest time.Time
+	for year := now.Year() - 1; year <= now.Year()+1; year++ {
+		candidate := parsed.AddDate(year-parsed.Year(), 0, 0)
+		if candidate.Day() != parsed.Day() {
+			// 29 of February on a non leap year is normalized to 1 of March.
+			continue
+		}
+
+		if closest.IsZero() || absDuration(candidate.Sub(now)) < absDuration(closest.Sub(now)) {
+			closest = candidate
+		}
+	}
+
+	if closest.IsZero() {
+		return time.Time{}, fmt.Errorf("invalid date '%s': day does not exist in years close to %v", content, now.Year())
+	}
+
+	return closest, nil
+}
+
+// inferCentury parses a date with two digits year and uses the century indicated by YearWindow.
+func inferCentury(content string, layout string) (time.Time, error) {
+	first := TimeNow().In(TimeLocation).Year() - YearWindow
+
+	// Already checked digits.
+	yy, _ := strconv.Atoi(content[:2])
+	year := first - first%100 + yy
+	if year < first {
+		year += 100
+	}
+
+	parsed, err := time.ParseInLocation("2006"+layout[2:], strconv.Itoa(year)+content[2:], TimeLocation)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date '%s': %w", content, err)
+	}
+
+	return parsed, nil
+}
+
+// inferDate parses a time of the day and uses the date that puts it closest to TimeNow.
+func inferDate(content string, layout string) (time.Time, error) {
+	parsed, err := time.ParseInLocation(layout, content, TimeLocation)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time '%s': %w", content, err)
+	}
+
+	now := TimeNow().In(TimeLocation)
+
+	var closest time.Time
+	for day := -1; day <= 1; day++ {
+		candidate := time.Date(now.Year(), now.Month(), now.Day()+day,
+			parsed.Hour(), parsed.Minute(), parsed.Second(), 0, TimeLocation)
+
+		if closest.IsZero() || absDuration(candidate.Sub(now)) < absDuration(closest.Sub(now)) {
+			closest = candidate
+		}
+	}
+
+	return closest, nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
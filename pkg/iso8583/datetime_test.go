@@ -0,0 +1,277 @@
+package iso8583_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+// fixTimeNow replaces iso8583.TimeNow and returns a function that restores it.
+func fixTimeNow(now time.Time) func() {
+	original := iso8583.TimeNow
+	iso8583.TimeNow = func() time.Time { return now }
+	return func() { iso8583.TimeNow = original }
+}
+
+func TestMMDDHHMMSS_MarshalISO8583(t *testing.T) {
+	v := iso8583.MMDDHHMMSS{Time: time.Date(2026, 10, 18, 21, 17, 4, 0, time.UTC)}
+
+	o, err := v.MarshalISO8583(10, "ascii")
+	if assert.Nil(t, err) {
+		assert.Equal(t, []byte("1018211704"), o)
+	}
+
+	o, err = v.MarshalISO8583(10, "ebcdic")
+	if assert.Nil(t, err) {
+		assert.Equal(t, []byte{0xF1, 0xF0, 0xF1, 0xF8, 0xF2, 0xF1, 0xF1, 0xF7, 0xF0, 0xF4}, o)
+	}
+}
+
+func TestMMDDHHMMSS_UnmarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		Now         time.Time
+		InputBytes  []byte
+		OutputTime  time.Time
+		OutputError string
+	}{
+		{
+			Name:       "same_year",
+			Now:        time.Date(2026, 10, 18, 21, 20, 0, 0, time.UTC),
+			InputBytes: []byte("1018211704FF"),
+			OutputTime: time.Date(2026, 10, 18, 21, 17, 4, 0, time.UTC),
+		},
+		{
+			Name:       "previous_year_rollover",
+			Now:        time.Date(2027, 1, 1, 0, 0, 5, 0, time.UTC),
+			InputBytes: []byte("1231235959"),
+			OutputTime: time.Date(2026, 12, 31, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			Name:       "next_year_rollover",
+			Now:        time.Date(2026, 12, 31, 23, 59, 59, 0, time.UTC),
+			InputBytes: []byte("0101000001"),
+			OutputTime: time.Date(2027, 1, 1, 0, 0, 1, 0, time.UTC),
+		},
+		{
+			Name:        "impossible_date",
+			Now:         time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			InputBytes:  []byte("0230120000"),
+			OutputError: `invalid date '0230120000': parsing time "20000230120000": day out of range`,
+		},
+		{
+			Name:        "invalid_hour",
+			Now:         time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			InputBytes:  []byte("1018251704"),
+			OutputError: `invalid date '1018251704': parsing time "20001018251704": hour out of range`,
+		},
+		{
+			Name:        "non_digit",
+			Now:         time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			InputBytes:  []byte("10-8211704"),
+			OutputError: "character '-' at position 2 of date '10-8211704' is not a digit",
+		},
+		{
+			Name:        "short",
+			Now:         time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			InputBytes:  []byte("1018"),
+			OutputError: "message remain (4 bytes) is shorter than indicated length: 10",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			defer fixTimeNow(testCase.Now)()
+
+			var v iso8583.MMDDHHMMSS
+			n, err := v.UnmarshalISO8583(testCase.InputBytes, 10, "ascii")
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, 10, n)
+			assert.True(t, testCase.OutputTime.Equal(v.Time), v.Time.String())
+		})
+	}
+}
+
+func TestMMDD_UnmarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		Now         time.Time
+		Input       string
+		OutputTime  time.Time
+		OutputError string
+	}{
+		{
+			Name:       "leap_year",
+			Now:        time.Date(2028, 3, 1, 0, 0, 0, 0, time.UTC),
+			Input:      "0229",
+			OutputTime: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:       "leap_day_next_year",
+			Now:        time.Date(2027, 12, 20, 0, 0, 0, 0, time.UTC),
+			Input:      "0229",
+			OutputTime: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:        "leap_day_without_leap_year",
+			Now:         time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			Input:       "0229",
+			OutputError: "invalid date '0229': day does not exist in years close to 2026",
+		},
+		{
+			Name:        "invalid_month",
+			Now:         time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			Input:       "1301",
+			OutputError: `invalid date '1301': parsing time "20001301": month out of range`,
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			defer fixTimeNow(testCase.Now)()
+
+			var v iso8583.MMDD
+			_, err := v.UnmarshalISO8583([]byte(testCase.Input), 4, "ascii")
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.True(t, testCase.OutputTime.Equal(v.Time), v.Time.String())
+			assert.Equal(t, testCase.Input, v.String())
+		})
+	}
+}
+
+func TestYYMM_UnmarshalISO8583(t *testing.T) {
+	defer fixTimeNow(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))()
+
+	testList := []struct {
+		Name        string
+		Window      int
+		Input       string
+		OutputTime  time.Time
+		OutputError string
+	}{
+		{Name: "future", Window: 50, Input: "3112", OutputTime: time.Date(2031, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "window_end", Window: 50, Input: "7512", OutputTime: time.Date(2075, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "window_start", Window: 50, Input: "7601", OutputTime: time.Date(1976, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "small_window", Window: 10, Input: "1601", OutputTime: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "small_window_future", Window: 10, Input: "1501", OutputTime: time.Date(2115, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "invalid_month", Window: 50, Input: "2500",
+			OutputError: `invalid date '2500': parsing time "202500": month out of range`},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			original := iso8583.YearWindow
+			iso8583.YearWindow = testCase.Window
+			defer func() { iso8583.YearWindow = original }()
+
+			var v iso8583.YYMM
+			_, err := v.UnmarshalISO8583([]byte(testCase.Input), 4, "ascii")
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.True(t, testCase.OutputTime.Equal(v.Time), v.Time.String())
+		})
+	}
+}
+
+func TestHHMMSS_UnmarshalISO8583(t *testing.T) {
+	defer fixTimeNow(time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC))()
+
+	var v iso8583.HHMMSS
+	_, err := v.UnmarshalISO8583([]byte("000010"), 6, "ascii")
+	if assert.Nil(t, err) {
+		assert.True(t, time.Date(2026, 10, 19, 0, 0, 10, 0, time.UTC).Equal(v.Time), v.Time.String())
+	}
+
+	_, err = v.UnmarshalISO8583([]byte("235800"), 6, "ascii")
+	if assert.Nil(t, err) {
+		assert.True(t, time.Date(2026, 10, 18, 23, 58, 0, 0, time.UTC).Equal(v.Time), v.Time.String())
+	}
+
+	_, err = v.UnmarshalISO8583([]byte("126000"), 6, "ascii")
+	assert.EqualError(t, err, `invalid time '126000': parsing time "126000": minute out of range`)
+}
+
+func TestDateTime_location(t *testing.T) {
+	defer fixTimeNow(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))()
+
+	location := time.FixedZone("UTC-3", -3*60*60)
+
+	original := iso8583.TimeLocation
+	iso8583.TimeLocation = location
+	defer func() { iso8583.TimeLocation = original }()
+
+	var v iso8583.MMDDHHMMSS
+	if _, err := v.UnmarshalISO8583([]byte("1018090000"), 10, "ascii"); !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.True(t, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC).Equal(v.Time), v.Time.String())
+	assert.Equal(t, location, v.Location())
+
+	// Marshal converts values to TimeLocation.
+	o, err := iso8583.MMDDHHMMSS{Time: v.UTC()}.MarshalISO8583(10, "ascii")
+	if assert.Nil(t, err) {
+		assert.Equal(t, []byte("1018090000"), o)
+	}
+	assert.Equal(t, "1018090000", iso8583.MMDDHHMMSS{Time: v.UTC()}.String())
+
+	// Conversion may change the date.
+	o, err = iso8583.MMDD{Time: time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC)}.MarshalISO8583(4, "ascii")
+	if assert.Nil(t, err) {
+		assert.Equal(t, []byte("1017"), o)
+	}
+}
+
+func TestDateTime_JSON(t *testing.T) {
+	defer fixTimeNow(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))()
+
+	v := iso8583.MMDD{Time: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)}
+
+	b, err := json.Marshal(v)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, `"1018"`, string(b))
+
+	var replayed iso8583.MMDD
+	if assert.Nil(t, json.Unmarshal(b, &replayed)) {
+		assert.True(t, v.Equal(replayed.Time))
+	}
+
+	assert.EqualError(t, json.Unmarshal([]byte(`"101"`), &replayed), "date '101' should be 4 digits long")
+}
+
+func TestDateTime_ValidateISO8583(t *testing.T) {
+	defer fixTimeNow(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))()
+
+	assert.Nil(t, iso8583.MMDDHHMMSS{}.ValidateISO8583(10, ""))
+	assert.True(t, errors.Is(iso8583.MMDD{}.ValidateISO8583(6, ""), iso8583.ErrInvalidLength))
+	assert.Nil(t, iso8583.HHMMSS{}.ValidateISO8583(0, ""))
+	assert.Nil(t, iso8583.YYMM{Time: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}.ValidateISO8583(4, ""))
+	assert.True(t, errors.Is(iso8583.YYMM{Time: time.Date(2090, 1, 1, 0, 0, 0, 0, time.UTC)}.ValidateISO8583(4, ""),
+		iso8583.ErrInvalidFormat))
+}
@@ -20,8 +20,10 @@ import (
 // - encoding: arrives to the UnmarshalISO8583 method through parameter.
 // For example: `iso8583:"encoding:ascii"`
 //
-// If you want to add a new encoding to use in the inbuilt types, just add them to the iso8583.UnmarshalDecodings
-// variable.
+// Struct fields that do not implement Unmarshaler are composites, its subfields are unmarshaled by position,
+// see Marshaler.
+//
+// If you want to add a new encoding to use in the inbuilt types, just register a Codec with iso8583.RegisterCodec.
 //
 // If you want to add a completely new field, just copy the most similar from the existing one
 // and modify what ever you want.
@@ -61,6 +63,9 @@ var errStructFieldNonExistent = errors.New("non existent struct field")
 // returns the amount of bytes consumed from original message. If unused bytes remain from input
 // its not considerate an error.
 // If an error is encountered a counter with consumed bytes up to the moment is returned.
+//
+// If ValidateOnUnmarshal is true, v is checked with Validate after unmarshaling,
+// otherwise only the rules registered for its type are checked.
 func Unmarshal(data []byte, v interface{}) (int, error) {
 	strctInput := reflect.ValueOf(v)
 	// bitnapN works like an index that allows to know which fields are already
@@ -145,6 +150,10 @@ func Unmarshal(data []byte, v interface{}) (int, error) {
 		}
 	}
 
+	if errs := validateMessage(v, ValidateOnUnmarshal); len(errs) > 0 {
+		return buffer.UntilNowConsumed(), fmt.Errorf("iso8583.unmarshal: %w", ValidationErrors(errs))
+	}
+
 	// Return the amount of consumed fields.
 	return buffer.UntilNowConsumed(), nil
 }
@@ -225,8 +234,22 @@ func unmarshalField(strct reflect.Value, fieldName string, bytes []byte) (int, t
 		return 0, tags{}, fmt.Errorf("iso8583.unmarshal: %w", err)
 	}
 
-	// founded field must implement Unmarshaler, otherwise an error is returned.
-	fieldInterface, isValidUnmarshaler := fieldValue.Interface().(Unmarshaler)
+	// Nil pointer fields are allocated, so present fields are distinguished from absent ones even with zero values.
+	if fieldValue.IsNil() {
+		fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+	}
+
+	// founded field must implement Unmarshaler or be a composite, otherwise an error is returned.
+	fieldInterface, isValidUnmarshaler := withTagLayout(fieldValue.Interface(), tag).(Unmarshaler)
+	if !isValidUnmarshaler && isComposite(fieldValue) {
+		consumed, err := unmarshalComposite(fieldValue, bytes, tag)
+		if err != nil {
+			return 0, tags{}, fmt.Errorf("iso8583.unmarshal: cant unmarshal field %v: %w", tag.Field, err)
+		}
+
+		return consumed, tag, nil
+	}
+
 	if !isValidUnmarshaler {
 		return 0, tags{}, fmt.Errorf(
 			"iso8583.unmarshal: field %s is present but does not implement Unmarshaler interface", fieldName)
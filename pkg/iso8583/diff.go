@@ -0,0 +1,260 @@
+package iso8583
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// DiffKind indicates how a field differs between two messages.
+type DiffKind string
+
+const (
+	// DiffOnlyInA the field is only present in the first message.
+	DiffOnlyInA DiffKind = "only_in_a"
+	// DiffOnlyInB the field is only present in the second message.
+	DiffOnlyInB DiffKind = "only_in_b"
+	// DiffLength the field is present in both messages but its length differs.
+	DiffLength DiffKind = "length"
+	// DiffValue the field is present in both messages with the same length but its value differs.
+	DiffValue DiffKind = "value"
+	// DiffBitmap the bitmap indicates different fields.
+	DiffBitmap DiffKind = "bitmap"
+	// DiffMTI the message type identifier differs.
+	DiffMTI DiffKind = "mti"
+)
+
+// VolatileFields are fields that usually change between two executions of the same transaction:
+// transmission date and time (7), system trace audit number (11) and retrieval reference number (37).
+// For example:
+// 	iso8583.Diff(a, b, template.MasterCardISO87{}, iso8583.VolatileFields...)
+var VolatileFields = []string{"7", "11", "37"}
+
+// Difference represents a difference of a field between two messages.
+// Values are masked as in Dump, lengths are the amount of characters or bytes of the field content.
+type Difference struct {
+	Field   string
+	Kind    DiffKind
+	A       string
+	B       string
+	ALength int
+	BLength int
+}
+
+// String returns a human readable representation of the difference.
+func (d Difference) String() string {
+	switch d.Kind {
+	case DiffOnlyInA:
+		return fmt.Sprintf("field %s: only in a: %s", d.Field, d.A)
+	case DiffOnlyInB:
+		return fmt.Sprintf("field %s: only in b: %s", d.Field, d.B)
+	case DiffLength:
+		return fmt.Sprintf("field %s: length %v != %v: %s != %s", d.Field, d.ALength, d.BLength, d.A, d.B)
+	default:
+		return fmt.Sprintf("field %s: %s != %s", d.Field, d.A, d.B)
+	}
+}
+
+// Diff unmarshals both messages using the type of spec as layout and returns its differences
+// in message order. Fields listed in ignore are not compared, neither are its bits in the bitmaps.
+// Spec can be a struct or a pointer to one, its content is not used.
+func Diff(a, b []byte, spec interface{}, ignore ...string) ([]Difference, error) {
+	specType := reflect.TypeOf(spec)
+	for specType != nil && specType.Kind() == reflect.Ptr {
+		specType = specType.Elem()
+	}
+
+	if specType == nil || specType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("iso8583.diff: spec is not a struct or is pointing to one")
+	}
+
+	aFields, err := readDiffMessage(a, specType)
+	if err != nil {
+		return nil, fmt.Errorf("iso8583.diff: message a: %w", err)
+	}
+
+	bFields, err := readDiffMessage(b, specType)
+	if err != nil {
+		return nil, fmt.Errorf("iso8583.diff: message b: %w", err)
+	}
+
+	ignored := make(map[string]bool)
+	for _, field := range ignore {
+		ignored[field] = true
+	}
+
+	aPresent, bPresent := presentFields(aFields), presentFields(bFields)
+
+	differences := make([]Difference, 0)
+	for n := range aFields {
+		fieldA, fieldB := aFields[n], bFields[n]
+		if ignored[fieldA.Field] {
+			continue
+		}
+
+		d, err := newDifference(fieldA, fieldB)
+		if err != nil {
+			return nil, fmt.Errorf("iso8583.diff: field %s: %w", fieldA.Field, err)
+		}
+
+		inA, inB := aPresent[fieldA.Field], bPresent[fieldA.Field]
+		switch {
+		case !inA && !inB:
+			continue
+		case inA && !inB:
+			d.Kind = DiffOnlyInA
+		case !inA && inB:
+			d.Kind = DiffOnlyInB
+		case fieldA.Field == _tagMTI:
+			if d.A == d.B {
+				continue
+			}
+			d.Kind = DiffMTI
+		case isBitmapField(fieldA):
+			if !bitmapsDiffer(fieldA, fieldB, aFields, ignored) {
+				continue
+			}
+			d.Kind = DiffBitmap
+		case d.ALength != d.BLength:
+			d.Kind = DiffLength
+		// Raw values are compared as masking could hide differences, like in PANs with the same BIN and last 4.
+		case rawValue(fieldA.Value) != rawValue(fieldB.Value):
+			d.Kind = DiffValue
+		default:
+			continue
+		}
+
+		differences = append(differences, d)
+	}
+
+	return differences, nil
+}
+
+// readDiffMessage unmarshals a message in a new instance of t and returns its fields.
+func readDiffMessage(data []byte, t reflect.Type) ([]messageField, error) {
+	v := reflect.New(t)
+	if _, err := Unmarshal(data, v.Interface()); err != nil {
+		return nil, err
+	}
+
+	// Zero value fields are kept to allow comparing both messages by index.
+	return readMessageFields(v.Interface())
+}
+
+// newDifference returns a difference with masked values and lengths of both fields.
+func newDifference(a, b messageField) (Difference, error) {
+	policy := maskPolicy(a.tags)
+
+	aDisplay, err := fieldDisplay(policy, a.Value)
+	if err != nil {
+		return Difference{}, err
+	}
+
+	bDisplay, err := fieldDisplay(policy, b.Value)
+	if err != nil {
+		return Difference{}, err
+	}
+
+	return Difference{
+		Field:   a.Field,
+		A:       aDisplay,
+		B:       bDisplay,
+		ALength: contentLength(a.Value),
+		BLength: contentLength(b.Value),
+	}, nil
+}
+
+// contentLength returns the amount of characters or bytes of a field.
+func contentLength(v reflect.Value) int {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.String || v.Kind() == reflect.Slice {
+		return v.Len()
+	}
+
+	return len(rawValue(v))
+}
+
+// bitsOf returns the bits of a bitmap field, nil if the field is not a bitmap.
+func bitsOf(f messageField) map[int]bool {
+	bitmapField, isBitmap := f.Value.Interface().(interface{ Bits() (map[int]bool, error) })
+	if !isBitmap {
+		return nil
+	}
+
+	bits, err := bitmapField.Bits()
+	if err != nil {
+		return nil
+	}
+
+	return bits
+}
+
+func isBitmapField(f messageField) bool {
+	_, isBitmap := f.Value.Interface().(interface{ Bits() (map[int]bool, error) })
+	return isBitmap
+}
+
+// presentFields returns which fields are present in an unmarshaled message according to its bitmaps.
+func presentFields(fields []messageField) map[string]bool {
+	present := map[string]bool{_tagBITMAP: true}
+
+	var bitmapN int
+	for _, f := range fields {
+		if f.Field == _tagMTI {
+			present[_tagMTI] = !f.Value.IsZero()
+			continue
+		}
+
+		if !isBitmapField(f) {
+			continue
+		}
+
+		for bit, on := range bitsOf(f) {
+			if on {
+				present[strconv.Itoa(bit+bitmapN)] = true
+			}
+		}
+
+		bitmapN += bitmapLength(f.tags)
+	}
+
+	return present
+}
+
+// bitmapsDiffer compares two bitmaps of the same position ignoring the bits of ignored fields.
+func bitmapsDiffer(a, b messageField, fields []messageField, ignored map[string]bool) bool {
+	// Calculate which is the first field represented by the bitmap.
+	var bitmapN int
+	for _, f := range fields {
+		if f.Field == a.Field {
+			break
+		}
+		if isBitmapField(f) {
+			bitmapN += bitmapLength(f.tags)
+		}
+	}
+
+	aBits, bBits := bitsOf(a), bitsOf(b)
+	for bit := 1; bit <= bitmapLength(a.tags); bit++ {
+		if aBits[bit] != bBits[bit] && !ignored[strconv.Itoa(bit+bitmapN)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bitmapLength returns the amount of representative bits of a bitmap, if length is not indicated: we assume its 64.
+func bitmapLength(tag tags) int {
+	if tag.Length == 0 {
+		return 64
+	}
+
+	return tag.Length
+}
@@ -0,0 +1,161 @@
+package iso8583_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+	"github.com/jattento/go-iso8583/pkg/template"
+)
+
+func newDiffTestMessage() template.MasterCardISO87 {
+	return template.MasterCardISO87{
+		MessageTypeIdentifier:    iso8583.MTI{MTI: "0100"},
+		PrimaryAccountNumber:     "5400000000000011",
+		ProcessingCode:           "000000",
+		AmountTransaction:        "000000000100",
+		TransmissionDateAndTime:  iso8583.MMDDHHMMSS{Time: time.Date(2020, 10, 18, 21, 17, 4, 0, time.UTC)},
+		SystemTraceAuditNumber:   "000001",
+		RetrievalReferenceNumber: "000000000001",
+		CardAcceptorTerminalID:   "TERM0001",
+	}
+}
+
+func marshalDiffTestMessage(t *testing.T, msg template.MasterCardISO87) []byte {
+	b, err := iso8583.Marshal(msg)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	return b
+}
+
+func TestDiff(t *testing.T) {
+	a := newDiffTestMessage()
+
+	b := newDiffTestMessage()
+	b.MessageTypeIdentifier = iso8583.MTI{MTI: "0110"}
+	b.PrimaryAccountNumber = "5400000000000000011"
+	b.AmountTransaction = "000000000200"
+	b.SystemTraceAuditNumber = "000002"
+	b.CardAcceptorTerminalID = ""
+	b.ResponseCode = "00"
+	b.NetworkManagementInformationCode = "301"
+
+	differences, err := iso8583.Diff(marshalDiffTestMessage(t, a), marshalDiffTestMessage(t, b),
+		template.MasterCardISO87{})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, []iso8583.Difference{
+		{Field: "mti", Kind: iso8583.DiffMTI, A: "0100", B: "0110", ALength: 4, BLength: 4},
+		{Field: "bitmap", Kind: iso8583.DiffBitmap, A: "7220000008800000", B: "F22000000A000000",
+			ALength: 16, BLength: 16},
+		{Field: "1", Kind: iso8583.DiffOnlyInB, A: "", B: "0400000000000000", ALength: 0, BLength: 16},
+		{Field: "2", Kind: iso8583.DiffLength, A: "540000******0011", B: "540000*********0011",
+			ALength: 16, BLength: 19},
+		{Field: "4", Kind: iso8583.DiffValue, A: "000000000100", B: "000000000200", ALength: 12, BLength: 12},
+		{Field: "11", Kind: iso8583.DiffValue, A: "000001", B: "000002", ALength: 6, BLength: 6},
+		{Field: "39", Kind: iso8583.DiffOnlyInB, A: "", B: "00", ALength: 0, BLength: 2},
+		{Field: "41", Kind: iso8583.DiffOnlyInA, A: "TERM0001", B: "", ALength: 8, BLength: 0},
+		{Field: "70", Kind: iso8583.DiffOnlyInB, A: "", B: "301", ALength: 0, BLength: 3},
+	}, differences)
+
+	assert.Equal(t, "field 2: length 16 != 19: 540000******0011 != 540000*********0011", differences[3].String())
+	assert.Equal(t, "field 41: only in a: TERM0001", differences[7].String())
+	assert.Equal(t, "field 39: only in b: 00", differences[6].String())
+	assert.Equal(t, "field mti: 0100 != 0110", differences[0].String())
+}
+
+func TestDiff_ignore_volatile_fields(t *testing.T) {
+	a := newDiffTestMessage()
+
+	b := newDiffTestMessage()
+	b.TransmissionDateAndTime = iso8583.MMDDHHMMSS{Time: time.Date(2020, 10, 19, 0, 0, 0, 0, time.UTC)}
+	b.SystemTraceAuditNumber = "000002"
+	b.RetrievalReferenceNumber = ""
+
+	differences, err := iso8583.Diff(marshalDiffTestMessage(t, a), marshalDiffTestMessage(t, b),
+		&template.MasterCardISO87{}, iso8583.VolatileFields...)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Empty(t, differences)
+}
+
+func TestDiff_equal_messages(t *testing.T) {
+	msg := marshalDiffTestMessage(t, newDiffTestMessage())
+
+	differences, err := iso8583.Diff(msg, msg, template.MasterCardISO87{})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Empty(t, differences)
+}
+
+func TestDiff_masked_types(t *testing.T) {
+	type message struct {
+		MTI    iso8583.MTI    `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP `iso8583:"bitmap"`
+		PAN    iso8583.PAN    `iso8583:"2,length:2"`
+		Track2 iso8583.Track2 `iso8583:"35,length:2"`
+	}
+
+	a := message{MTI: iso8583.MTI{MTI: "0100"}, PAN: "5400000000020011",
+		Track2: iso8583.Track2{PAN: "5400000000020011", Expiration: "2512", ServiceCode: "101"}}
+	b := message{MTI: iso8583.MTI{MTI: "0100"}, PAN: "5400005000010011",
+		Track2: iso8583.Track2{PAN: "5400000000020011", Expiration: "2612", ServiceCode: "101"}}
+
+	aBytes, err := iso8583.Marshal(a)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	bBytes, err := iso8583.Marshal(b)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	differences, err := iso8583.Diff(aBytes, bBytes, message{})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, []iso8583.Difference{
+		{Field: "2", Kind: iso8583.DiffValue, A: "540000******0011", B: "540000******0011",
+			ALength: 16, BLength: 16},
+		{Field: "35", Kind: iso8583.DiffValue, A: "540000******0011=*******", B: "540000******0011=*******",
+			ALength: 24, BLength: 24},
+	}, differences)
+}
+
+func TestDiff_errors(t *testing.T) {
+	msg := marshalDiffTestMessage(t, newDiffTestMessage())
+
+	_, err := iso8583.Diff(msg, msg, "spec")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "iso8583.diff: spec is not a struct or is pointing to one", err.Error())
+	}
+
+	_, err = iso8583.Diff(msg, msg, nil)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "iso8583.diff: spec is not a struct or is pointing to one", err.Error())
+	}
+
+	_, err = iso8583.Diff(msg[:10], msg, template.MasterCardISO87{})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "iso8583.diff: message a: iso8583.unmarshal: cant unmarshal field bitmap: "+
+			"bitmap should be 8 bytes long but only 6 bytes are avaiable", err.Error())
+	}
+
+	_, err = iso8583.Diff(msg, msg[:10], template.MasterCardISO87{})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "iso8583.diff: message b: iso8583.unmarshal: cant unmarshal field bitmap: "+
+			"bitmap should be 8 bytes long but only 6 bytes are avaiable", err.Error())
+	}
+}
@@ -0,0 +1,127 @@
+package iso8583
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/jattento/go-iso8583/pkg/emv"
+)
+
+// dumpField represents a field ready to be displayed, sensitive data is already masked.
+type dumpField struct {
+	messageField
+	display string
+}
+
+// Dump returns a human readable representation of the fields of v, one field per line and
+// sorted in message order. Zero value fields are not displayed.
+//
+// Sensitive fields are masked using the mask tag or in its absence the DefaultMasks policies,
+// which makes the output safe for logging.
+func Dump(v interface{}) (string, error) {
+	fields, err := readDumpFields(v)
+	if err != nil {
+		return "", fmt.Errorf("iso8583.dump: %w", err)
+	}
+
+	var buf bytes.Buffer
+	for _, f := range fields {
+		fmt.Fprintf(&buf, "%-6s %s: %s\n", f.Field, f.Name, f.display)
+	}
+
+	return buf.String(), nil
+}
+
+// GoDump works like Dump but returns v in Go syntax, as expected from a fmt.GoStringer implementation.
+// For example:
+// 	template.MasterCardISO87{MessageTypeIdentifier:"0100", PrimaryAccountNumber:"540000******0011"}
+func GoDump(v interface{}) (string, error) {
+	fields, err := readDumpFields(v)
+	if err != nil {
+		return "", fmt.Errorf("iso8583.dump: %w", err)
+	}
+
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(t.String() + "{")
+	for n, f := range fields {
+		if n > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "%s:%q", f.Name, f.display)
+	}
+	buf.WriteString("}")
+
+	return buf.String(), nil
+}
+
+// DumpJSON works like Dump but returns a JSON object keyed by field number, "mti" and "bitmap".
+// For example:
+// 	{"mti":"0100","bitmap":"4000000000000000","2":"540000******0011"}
+func DumpJSON(v interface{}) ([]byte, error) {
+	fields, err := readDumpFields(v)
+	if err != nil {
+		return nil, fmt.Errorf("iso8583.dump: %w", err)
+	}
+
+	keys := make([]string, 0, len(fields))
+	values := make([]json.RawMessage, 0, len(fields))
+	for _, f := range fields {
+		// Strings can not fail to be marshaled.
+		value, _ := json.Marshal(f.display)
+
+		keys = append(keys, f.Field)
+		values = append(values, value)
+	}
+
+	return encodeJSONObject(keys, values), nil
+}
+
+// DumpChipData returns a human readable representation of BER-TLV chip data, like the content of a
+// LLLBINARY field 55, one tag per line with its emv.Dictionary name and sensitive tags masked.
+// For example:
+// 	9F26   Application Cryptogram: 1122334455667788
+// 	5A     Application Primary Account Number (PAN): 540000******0011
+func DumpChipData(b []byte) (string, error) {
+	list, err := emv.Decode(b)
+	if err != nil {
+		return "", fmt.Errorf("iso8583.dump: %w", err)
+	}
+
+	output, err := emv.Dump(list, MaskValue)
+	if err != nil {
+		return "", fmt.Errorf("iso8583.dump: %w", err)
+	}
+
+	return output, nil
+}
+
+// readDumpFields returns all non zero fields of v with its masked display value.
+func readDumpFields(v interface{}) ([]dumpField, error) {
+	fields, err := readMessageFields(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpFields := make([]dumpField, 0, len(fields))
+	for _, f := range fields {
+		if f.Value.IsZero() {
+			continue
+		}
+
+		display, err := fieldDisplay(maskPolicy(f.tags), f.Value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Field, err)
+		}
+
+		dumpFields = append(dumpFields, dumpField{messageField: f, display: display})
+	}
+
+	return dumpFields, nil
+}
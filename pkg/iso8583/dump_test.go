@@ -0,0 +1,145 @@
+package iso8583_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/bitmap"
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+	"github.com/jattento/go-iso8583/pkg/template"
+)
+
+type dumpTestMessage struct {
+	MTI     iso8583.MTI    `iso8583:"mti,length:4"`
+	Bitmap  iso8583.BITMAP `iso8583:"bitmap"`
+	PAN     iso8583.LLVAR  `iso8583:"2,length:2"`
+	Code    iso8583.VAR    `iso8583:"3,length:6"`
+	Expiry  iso8583.VAR    `iso8583:"14,length:4"`
+	PIN     iso8583.BINARY `iso8583:"52,length:8"`
+	Visible iso8583.LLVAR  `iso8583:"35,length:2,mask:none"`
+	Hidden  iso8583.VAR    `iso8583:"41,length:8,mask:full"`
+	Skipped iso8583.VAR    `iso8583:"-"`
+}
+
+func newDumpTestMessage() dumpTestMessage {
+	return dumpTestMessage{
+		MTI:     iso8583.MTI{MTI: "0100"},
+		Bitmap:  iso8583.BITMAP{Bitmap: bitmap.FromBytes([]byte{0x60, 0x04, 0, 0, 0x20, 0x80, 0x10, 0})},
+		PAN:     "5400000000000011",
+		Code:    "000000",
+		Expiry:  "2512",
+		PIN:     []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD, 0xEF},
+		Visible: "5400000000000011=2512",
+		Hidden:  "TERMINAL",
+		Skipped: "skipped",
+	}
+}
+
+func TestDump(t *testing.T) {
+	o, err := iso8583.Dump(newDumpTestMessage())
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "mti    MTI: 0100\n"+
+		"bitmap Bitmap: 6004000020801000\n"+
+		"2      PAN: 540000******0011\n"+
+		"3      Code: 000000\n"+
+		"14     Expiry: ****\n"+
+		"35     Visible: 5400000000000011=2512\n"+
+		"41     Hidden: ********\n"+
+		"52     PIN: ****************\n", o)
+}
+
+func TestDump_omits_zero_values(t *testing.T) {
+	o, err := iso8583.Dump(&dumpTestMessage{MTI: iso8583.MTI{MTI: "0800"}})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "mti    MTI: 0800\n", o)
+}
+
+func TestDump_errors(t *testing.T) {
+	_, err := iso8583.Dump(nil)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "iso8583.dump: nil input", err.Error())
+	}
+
+	_, err = iso8583.Dump("0100")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "iso8583.dump: input is not a struct or is pointing to one", err.Error())
+	}
+
+	_, err = iso8583.Dump(struct {
+		Field iso8583.VAR `iso8583:"2,mask:whale_song"`
+	}{Field: "1234"})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "iso8583.dump: field 2: mask policy 'whale_song' does not exist", err.Error())
+	}
+}
+
+func TestGoDump(t *testing.T) {
+	o, err := iso8583.GoDump(&dumpTestMessage{MTI: iso8583.MTI{MTI: "0100"}, PAN: "5400000000000011"})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, `iso8583_test.dumpTestMessage{MTI:"0100", PAN:"540000******0011"}`, o)
+}
+
+func TestDumpJSON(t *testing.T) {
+	o, err := iso8583.DumpJSON(newDumpTestMessage())
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, `{"mti":"0100","bitmap":"6004000020801000","2":"540000******0011","3":"000000",`+
+		`"14":"****","35":"5400000000000011=2512","41":"********","52":"****************"}`, string(o))
+}
+
+func TestDump_template_formatters(t *testing.T) {
+	msg := template.MasterCardISO87{
+		MessageTypeIdentifier: iso8583.MTI{MTI: "0100"},
+		PrimaryAccountNumber:  "5400000000000011",
+		DateExpiration:        iso8583.YYMM{Time: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)},
+		Track2Data: iso8583.Track2{PAN: "5400000000000011", Expiration: "2512", ServiceCode: "101",
+			Discretionary: "0000012300000"},
+		PersonalIDNumberData: []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD, 0xEF},
+		IntegratedCircuitCardSystemRelatedData: []byte{0x9F, 0x26, 0x01, 0xFF, 0x5A, 0x08, 0x54, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x11},
+	}
+
+	for _, format := range []string{"%v", "%+v", "%s", "%#v"} {
+		o := fmt.Sprintf(format, msg)
+		assert.NotContains(t, o, "5400000000000011", format)
+		assert.NotContains(t, o, "2512", format)
+		assert.NotContains(t, o, "0123456789ABCDEF", format)
+		assert.Contains(t, o, "9F2601FF5A08540000******0011", format)
+		assert.Contains(t, o, "540000******0011", format)
+	}
+
+	assert.Equal(t, `template.MasterCardISO87{MessageTypeIdentifier:"0100", PrimaryAccountNumber:"540000******0011", `+
+		`DateExpiration:"****", Track2Data:"540000******0011=********************", `+
+		`PersonalIDNumberData:"****************", IntegratedCircuitCardSystemRelatedData:"9F2601FF5A08540000******0011"}`,
+		fmt.Sprintf("%#v", msg))
+}
+
+func TestDumpChipData(t *testing.T) {
+	o, err := iso8583.DumpChipData([]byte{0x9F, 0x36, 0x02, 0x00, 0x05, 0x5A, 0x08, 0x54, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x11, 0x9F, 0x1C, 0x03, 'T', 'R', 'M'})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "9F36   Application Transaction Counter (ATC): 0005\n"+
+		"5A     Application Primary Account Number (PAN): 540000******0011\n"+
+		"9F1C   Terminal Identification: TRM\n", o)
+
+	_, err = iso8583.DumpChipData([]byte{0x9F, 0x36, 0x02})
+	assert.EqualError(t, err, "iso8583.dump: offset 0: tag 9F36: truncated data object: "+
+		"value should be 2 bytes long but only 0 bytes are available")
+}
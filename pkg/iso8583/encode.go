@@ -25,9 +25,44 @@ import (
 // For example: `iso8583:"omitempty"`
 // - disesteem: if present will be ignores by Marshal().
 // For example: `iso8583:"-"`
+// - mask: policy used to hide sensitive data when the field is displayed by Dump, GoDump and DumpJSON.
+// It does not affect the marshaled message. For example: `iso8583:"mask:pan"`
+// - format: data element attribute checked by Validate, see ValidateFormat. For example: `iso8583:"format:an"`
+// - required: the field must be present, optionally only for the indicated MTI patterns separated by '/'.
+// It is checked by Validate. For example: `iso8583:"required:x1x0/0800"`
+// - min and max: limits of the content length, in characters or bytes for binary fields, checked by Validate
+// on present fields. For example: `iso8583:"2,length:2,min:12,max:19"`
+// - layout: tag and length digits of a TLVASCII separated by a slash. For example: `iso8583:"sub:2,layout:4/3"`
+// - sub: name of a subfield of a composite. For example: `iso8583:"sub:1,length:23"`
+// - prefix: amount of digits of the LL or LLL indicator that wraps a composite. For example: `iso8583:"61,prefix:3"`
 //
-// If you want to add a new encoding to use in the inbuilt types, just add them to the iso8583.MarshalEncodings
-// variable.
+// Struct fields that do not implement Marshaler are composites: its subfields are marshaled by position one
+// after the other using the same tags, for example:
+// 	type CardAcceptorNameLocation struct {
+// 		Name    iso8583.VAR `iso8583:"sub:1,length:23"`
+// 		City    iso8583.VAR `iso8583:"sub:2,length:13"`
+// 		State   iso8583.VAR `iso8583:"sub:3,length:3"`
+// 		Country iso8583.VAR `iso8583:"sub:4,length:1"`
+// 	}
+//
+// 	NameLocation CardAcceptorNameLocation `iso8583:"43,length:40,encoding:ebcdic"`
+//
+// Length of a composite is the total length of its content, if present it is checked. Composites with prefix
+// tag are wrapped in a LL (prefix:2) or LLL (prefix:3) indicator and its trailing subfields can be absent.
+// Subfields without encoding inherit the encoding of the composite, as in LLVAR the indicator and the content
+// encodings can be separated with a slash.
+//
+// Composites with a bitmap subfield are bitmapped sub-messages, like Visa fields 62, 63 and 126: the bitmap
+// indicates which subfields are present and its length tag the amount of representative bits, for example:
+// 	type CustomPaymentService struct {
+// 		Bitmap                       iso8583.BITMAP `iso8583:"bitmap,length:8"`
+// 		AuthorizationCharacteristics iso8583.VAR    `iso8583:"sub:1,length:1,omitempty"`
+// 		TransactionID                iso8583.BINARY `iso8583:"sub:2,length:8,omitempty"`
+// 	}
+//
+// 	CPS CustomPaymentService `iso8583:"62,prefix:2,encoding:ebcdic/ascii"`
+//
+// If you want to add a new encoding to use in the inbuilt types, just register a Codec with iso8583.RegisterCodec.
 //
 // If you want to add a completely new field, just copy the most similar from the existing one
 // and modify what ever you want.
@@ -56,7 +91,15 @@ type MarshalerBitmap interface {
 //
 // If field is a string with valid tags and does not implement Marshaler ascii encoding is assumed.
 // If field is a []byte with valid tags and does not implement Marshaler, its content is used as value.
+//
+// If ValidateOnMarshal is true, v is checked with Validate before marshaling,
+// otherwise only the rules registered for its type are checked.
 func Marshal(v interface{}) ([]byte, error) {
+	return marshal(v, nil)
+}
+
+// marshal implements Marshal, if mac is not nil the MAC field is filled as described by MarshalMAC.
+func marshal(v interface{}, mac MACFunc) ([]byte, error) {
 	processedFields := make(map[string]struct{})
 
 	if v == nil {
@@ -74,7 +117,12 @@ func Marshal(v interface{}) ([]byte, error) {
 		return nil, errors.New("iso8583.marshal: input is not a struct or is pointing to one")
 	}
 
+	if errs := validateMessage(v, ValidateOnMarshal); len(errs) > 0 {
+		return nil, fmt.Errorf("iso8583.marshal: %w", ValidationErrors(errs))
+	}
+
 	msg := newMarshalerMessage()
+	msg.mac = mac
 
 	// Iterate over all fields of input struct.
 	for index := 0; index < inputValue.Type().NumField(); index++ {
@@ -123,7 +171,17 @@ func Marshal(v interface{}) ([]byte, error) {
 
 // resolveMarshalFieldValue resolves Marshal return value of a field that must not necessary be a marshaler.
 func resolveMarshalFieldValue(v reflect.Value, tag tags) ([]byte, error) {
-	marshaler, isMarshaler := v.Interface().(Marshaler)
+	marshaler, isMarshaler := withTagLayout(v.Interface(), tag).(Marshaler)
+
+	// Structs that do not implement Marshaler are composites marshaled by its subfields.
+	if !isMarshaler && isComposite(v) {
+		b, err := marshalComposite(v, tag)
+		if err != nil {
+			return nil, fmt.Errorf("iso8583.marshal: field %s cant be marshaled: %w", tag.Field, err)
+		}
+
+		return b, nil
+	}
 
 	// Priority of marshaling order is marshaler -> bytes -> string
 	if !isMarshaler {
@@ -164,6 +222,8 @@ type field struct {
 type marshalerMessage struct {
 	Bitmaps []isoMarshalerBitmap
 	Fields  []isoMarshalerField
+
+	mac MACFunc
 }
 
 // isoMarshalerField represents a iso8583 field before its marshaled
@@ -236,6 +296,12 @@ func (m *marshalerMessage) Bytes() (messageBytes []byte, returnErr error) {
 	// Sort fields
 	sortFieldsStable(fields, func(index int) string { return fields[index].name })
 
+	if m.mac != nil {
+		if err := m.fillMAC(fields); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build message
 	messageBytes = make([]byte, 0)
 	for _, f := range fields {
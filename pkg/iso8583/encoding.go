@@ -1,29 +1,160 @@
 package iso8583
 
 import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
 	"github.com/jattento/go-iso8583/pkg/encoding/ebcdic"
 )
 
 // UnmarshalDecodings is the unmarshal encodings map used by inbuilt ISO fields, you can append more encoding for extended functionality.
-var UnmarshalDecodings = map[string]func([]byte) ([]byte, error){
-	"ebcdic": errWrapper(func(bytes []byte) []byte { return []byte(ebcdic.V1047.ToGoString(bytes)) }),
-	"ascii":  nop,
-}
+// "ebcdic" is code page 1047, other EBCDIC code pages are selected by number, for example "ebcdic037".
+// "ebcdic-lenient" is code page 1047 replacing characters without translation by '?', see LenientEBCDICCodec.
+// Its inbuilt entries use the registered codec of its name, see RegisterCodec which should be preferred
+// as modifying this map is not safe while messages are being unmarshaled.
+var UnmarshalDecodings = legacyEncodings(Codec.Decode)
 
 // MarshalEncodings is the marshal encodings map used by inbuilt ISO fields, you can append more encoding for extended functionality.
-var MarshalEncodings = map[string]func([]byte) ([]byte, error){
-	"ebcdic": errWrapper(func(bytes []byte) []byte {
-		return ebcdic.V1047.FromGoString(string(bytes))
-	}),
-	"ascii": nop,
+// "ebcdic" is code page 1047, other EBCDIC code pages are selected by number, for example "ebcdic037".
+// "ebcdic-lenient" is code page 1047 replacing characters without translation by '?', see LenientEBCDICCodec.
+// Its inbuilt entries use the registered codec of its name, see RegisterCodec which should be preferred
+// as modifying this map is not safe while messages are being marshaled.
+var MarshalEncodings = legacyEncodings(Codec.Encode)
+
+func inbuiltCodecs() map[string]Codec {
+	return map[string]Codec{
+		"ascii":          asciiCodec{},
+		"bcd":            bcdCodec{},
+		"ebcdic":         ebcdicCodec{v: &ebcdic.V1047},
+		"ebcdic-lenient": ebcdicCodec{v: &ebcdic.V1047, substitute: '?'},
+		"ebcdic037":      ebcdicCodec{v: &ebcdic.V037},
+		"ebcdic273":      ebcdicCodec{v: &ebcdic.V273},
+		"ebcdic277":      ebcdicCodec{v: &ebcdic.V277},
+		"ebcdic278":      ebcdicCodec{v: &ebcdic.V278},
+		"ebcdic280":      ebcdicCodec{v: &ebcdic.V280},
+		"ebcdic284":      ebcdicCodec{v: &ebcdic.V284},
+		"ebcdic285":      ebcdicCodec{v: &ebcdic.V285},
+		"ebcdic297":      ebcdicCodec{v: &ebcdic.V297},
+		"ebcdic500":      ebcdicCodec{v: &ebcdic.V500},
+		"ebcdic1047":     ebcdicCodec{v: &ebcdic.V1047},
+		"ebcdic1140":     ebcdicCodec{v: &ebcdic.V1140},
+	}
 }
 
-func errWrapper(Func func([]byte) []byte) func([]byte) ([]byte, error) {
-	return func(bytes []byte) ([]byte, error) {
-		return Func(bytes), nil
+// LenientEBCDICCodec returns the inbuilt EBCDIC codec named name in lenient mode: characters without translation
+// are replaced by substitute instead of failing with ebcdic.ErrUnmappable. Substitute must be translatable by
+// the code page. The codec is used by registering it, for example:
+// 	c, err := iso8583.LenientEBCDICCodec("ebcdic037", '?')
+// 	iso8583.RegisterCodec("ebcdic037-lenient", c)
+func LenientEBCDICCodec(name string, substitute rune) (Codec, error) {
+	c, ok := inbuiltCodecs()[name].(ebcdicCodec)
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not an inbuilt EBCDIC codec", name)
 	}
+
+	if _, err := c.v.EncodeSubstitute("", substitute); err != nil {
+		return nil, err
+	}
+
+	c.substitute = substitute
+	return c, nil
 }
 
-func nop(bytes []byte) ([]byte, error) {
-	return bytes, nil
+// legacyEncodings returns a function map with an entry for each inbuilt codec which applies method
+// of the currently registered codec of its name.
+func legacyEncodings(method func(Codec, []byte) ([]byte, error)) map[string]func([]byte) ([]byte, error) {
+	encodings := make(map[string]func([]byte) ([]byte, error))
+	for name := range inbuiltCodecs() {
+		name := name
+		encodings[name] = func(b []byte) ([]byte, error) {
+			c, ok := LookupCodec(name)
+			if !ok {
+				return nil, fmt.Errorf("codec '%s' is not registered", name)
+			}
+			return method(c, b)
+		}
+	}
+
+	return encodings
 }
+
+// asciiCodec leaves content as it is.
+type asciiCodec struct{}
+
+func (asciiCodec) Encode(b []byte) ([]byte, error) { return b, nil }
+func (asciiCodec) Decode(b []byte) ([]byte, error) { return b, nil }
+func (asciiCodec) EncodedLen(n int) int            { return n }
+func (asciiCodec) DecodedLen(n int) int            { return n }
+
+// bcdCodec packs digits in nibbles, odd content is padded with a 'F' nibble at the end.
+// The track 2 separator, '=' or 'D', is packed as the 'D' nibble.
+type bcdCodec struct{}
+
+func (bcdCodec) Encode(b []byte) ([]byte, error) {
+	s := strings.Replace(string(b), "=", "D", -1)
+	if len(s)%2 != 0 {
+		s += "F"
+	}
+
+	output := make([]byte, len(s)/2)
+	for n := 0; n < len(s); n++ {
+		var nibble byte
+		switch c := s[n]; {
+		case c >= '0' && c <= '9':
+			nibble = c - '0'
+		case c == 'D', c == 'F' && n == len(s)-1:
+			nibble = c - 'A' + 10
+		default:
+			return nil, fmt.Errorf("character %q at position %v can not be packed in a nibble", c, n)
+		}
+
+		output[n/2] |= nibble << (4 * uint(1-n%2))
+	}
+
+	return output, nil
+}
+
+func (bcdCodec) Decode(b []byte) ([]byte, error) {
+	return []byte(strings.ToUpper(hex.EncodeToString(b))), nil
+}
+
+func (bcdCodec) EncodedLen(n int) int { return (n + 1) / 2 }
+func (bcdCodec) DecodedLen(n int) int { return 2 * n }
+
+// ebcdicCodePage is implemented by the ebcdic package versions.
+type ebcdicCodePage interface {
+	Encode(s string) ([]byte, error)
+	EncodeSubstitute(s string, substitute rune) ([]byte, error)
+	Decode(b []byte) (string, error)
+	DecodeSubstitute(b []byte, substitute rune) string
+}
+
+// ebcdicCodec translates between Go strings and a EBCDIC code page, one byte per character.
+// Characters without translation are replaced by substitute if it is not zero.
+type ebcdicCodec struct {
+	v          ebcdicCodePage
+	substitute rune
+}
+
+func (c ebcdicCodec) Encode(b []byte) ([]byte, error) {
+	if c.substitute != 0 {
+		return c.v.EncodeSubstitute(string(b), c.substitute)
+	}
+	return c.v.Encode(string(b))
+}
+
+func (c ebcdicCodec) Decode(b []byte) ([]byte, error) {
+	if c.substitute != 0 {
+		return []byte(c.v.DecodeSubstitute(b, c.substitute)), nil
+	}
+
+	s, err := c.v.Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+func (ebcdicCodec) EncodedLen(n int) int { return n }
+func (ebcdicCodec) DecodedLen(n int) int { return n }
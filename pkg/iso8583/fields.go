@@ -0,0 +1,124 @@
+package iso8583
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/jattento/go-iso8583/pkg/bitmap"
+)
+
+// messageField represents a tagged struct field of a message.
+type messageField struct {
+	Name  string
+	Value reflect.Value
+	tags
+}
+
+// readMessageFields returns all tagged fields of v sorted in message order (MTI -> BITMAP -> 1 -> n).
+// Untagged, unexported, anonymous and disesteemed fields are ignored, nil fields are returned as zero values.
+func readMessageFields(v interface{}) ([]messageField, error) {
+	if v == nil {
+		return nil, errors.New("nil input")
+	}
+
+	inputValue := reflect.ValueOf(v)
+	for inputValue.Kind() == reflect.Ptr {
+		inputValue = inputValue.Elem()
+	}
+
+	if inputValue.Kind() != reflect.Struct {
+		return nil, errors.New("input is not a struct or is pointing to one")
+	}
+
+	fields := make([]messageField, 0)
+	for index := 0; index < inputValue.Type().NumField(); index++ {
+		structFieldValue, tag, err := getStructFieldData(inputValue, index)
+		if errors.Is(err, errUnexportedField) || errors.Is(err, errAnonymousField) || errors.Is(err, errTagsNotFound) ||
+			tag.Disesteem {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, messageField{
+			Name:  inputValue.Type().Field(index).Name,
+			Value: structFieldValue,
+			tags:  tag,
+		})
+	}
+
+	sortFieldsStable(fields, func(index int) string { return fields[index].Field })
+
+	return fields, nil
+}
+
+// displayValue returns a human readable representation of a field value.
+// Byte slices are represented in upper case hexadecimal, bitmaps by its bytes in hexadecimal and composites
+// by its subfields one after the other. Sensitive types like tracks are displayed masked by its String method.
+func displayValue(v reflect.Value) string { return formatValue(v, false) }
+
+// rawValue works like displayValue but uses the Raw method of sensitive types, so it must be masked before
+// being displayed.
+func rawValue(v reflect.Value) string { return formatValue(v, true) }
+
+// fieldDisplay returns the value of a field masked with policy, without policy the display value is used.
+func fieldDisplay(policy string, v reflect.Value) (string, error) {
+	if policy == "" {
+		return displayValue(v), nil
+	}
+
+	return MaskValue(policy, rawValue(v))
+}
+
+func formatValue(v reflect.Value, raw bool) string {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if rawer, ok := v.Interface().(interface{ Raw() string }); ok && raw {
+		return rawer.Raw()
+	}
+
+	switch value := v.Interface().(type) {
+	case fmt.Stringer:
+		return value.String()
+	case interface{ Bits() (map[int]bool, error) }:
+		bits, err := value.Bits()
+		if err != nil {
+			return ""
+		}
+		return fmt.Sprintf("%X", bitmap.ToBytes(bits))
+	}
+
+	switch {
+	case isComposite(v):
+		return formatComposite(v, raw)
+	case v.Kind() == reflect.String:
+		return v.String()
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return fmt.Sprintf("%X", v.Bytes())
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// formatComposite returns the values of the present subfields of a composite one after the other.
+func formatComposite(v reflect.Value, raw bool) string {
+	subfields, err := readSubfields(v)
+	if err != nil {
+		return ""
+	}
+
+	var display string
+	for _, sub := range subfields {
+		display += formatValue(sub.Value, raw)
+	}
+
+	return display
+}
@@ -0,0 +1,120 @@
+package iso8583
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// MarshalJSONMessage returns a JSON document of v keyed by field name and sorted in message order,
+// zero value fields are not included.
+// For example:
+// 	{"mti":"0100","bitmap":"4000000000000000","2":"5400000000000011"}
+//
+// Each field value is obtained with encoding/json, inbuilt types are represented as strings
+// and binary types as upper case hexadecimal. Unlike DumpJSON, values are NOT masked.
+func MarshalJSONMessage(v interface{}) ([]byte, error) {
+	fields, err := readMessageFields(v)
+	if err != nil {
+		return nil, fmt.Errorf("iso8583.marshaljson: %w", err)
+	}
+
+	keys := make([]string, 0, len(fields))
+	values := make([]json.RawMessage, 0, len(fields))
+	for _, f := range fields {
+		if f.Value.IsZero() {
+			continue
+		}
+
+		value, err := json.Marshal(f.Value.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("iso8583.marshaljson: field %s cant be marshaled: %w", f.Field, err)
+		}
+
+		keys = append(keys, f.Field)
+		values = append(values, value)
+	}
+
+	return encodeJSONObject(keys, values), nil
+}
+
+// UnmarshalJSONMessage parses a JSON document generated by MarshalJSONMessage and stores the result
+// in the struct pointed by v. Every key of the document must exist as field name in v.
+func UnmarshalJSONMessage(data []byte, v interface{}) error {
+	strct := reflect.ValueOf(v)
+	if !isPointerToStruct(strct) {
+		return errors.New("iso8583.unmarshaljson: interface input is not a pointer to a structure")
+	}
+
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("iso8583.unmarshaljson: %w", err)
+	}
+
+	for fieldName, value := range document {
+		fieldValue, tag, err := searchStructField(strct, fieldName)
+		if err != nil {
+			if errors.Is(err, errStructFieldNonExistent) {
+				err = fmt.Errorf("unknown field in document '%v'", fieldName)
+			}
+
+			return fmt.Errorf("iso8583.unmarshaljson: %w", err)
+		}
+
+		// Pointer fields are initialized before unmarshal.
+		if fieldValue.IsNil() {
+			fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+		}
+
+		if err := json.Unmarshal(value, withTagLayout(fieldValue.Interface(), tag)); err != nil {
+			return fmt.Errorf("iso8583.unmarshaljson: field %s cant be unmarshaled: %w", fieldName, err)
+		}
+	}
+
+	return nil
+}
+
+// encodeJSONObject builds a JSON object keeping the keys order.
+func encodeJSONObject(keys []string, values []json.RawMessage) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("{")
+	for n := range keys {
+		if n > 0 {
+			buf.WriteString(",")
+		}
+
+		// Strings can not fail to be marshaled.
+		key, _ := json.Marshal(keys[n])
+
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(values[n])
+	}
+	buf.WriteString("}")
+
+	return buf.Bytes()
+}
+
+// marshalHexJSON returns b as a JSON string in upper case hexadecimal.
+func marshalHexJSON(b []byte) ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%X", b))
+}
+
+// unmarshalHexJSON reads a JSON string in hexadecimal.
+func unmarshalHexJSON(data []byte) ([]byte, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hexadecimal value: %w", err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,166 @@
+package iso8583_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/bitmap"
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+	"github.com/jattento/go-iso8583/pkg/template"
+)
+
+type jsonTestMessage struct {
+	MTI       iso8583.MTI       `iso8583:"mti,length:4"`
+	Bitmap    iso8583.BITMAP    `iso8583:"bitmap"`
+	Secondary iso8583.BITMAP    `iso8583:"1,omitempty"`
+	PAN       iso8583.LLVAR     `iso8583:"2,length:2"`
+	Code      *iso8583.VAR      `iso8583:"3,length:6"`
+	Data      iso8583.LLLVAR    `iso8583:"48,length:3"`
+	PIN       iso8583.BINARY    `iso8583:"52,length:8"`
+	ICC       iso8583.LLLBINARY `iso8583:"55,length:3"`
+	Record    iso8583.LLBINARY  `iso8583:"120,length:2"`
+}
+
+func TestMarshalJSONMessage(t *testing.T) {
+	code := iso8583.VAR("000000")
+	o, err := iso8583.MarshalJSONMessage(jsonTestMessage{
+		MTI:    iso8583.MTI{MTI: "0100"},
+		Bitmap: iso8583.BITMAP{Bitmap: bitmap.FromBytes([]byte{0x60, 0, 0, 0, 0, 0x01, 0x10, 0})},
+		PAN:    "5400000000000011",
+		Code:   &code,
+		Data:   "R",
+		PIN:    []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef},
+		ICC:    []byte{0x9f, 0x26},
+		Record: []byte{0x01},
+	})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, `{"mti":"0100","bitmap":"6000000000011000","2":"5400000000000011","3":"000000",`+
+		`"48":"R","52":"0123456789ABCDEF","55":"9F26","120":"01"}`, string(o))
+}
+
+func TestUnmarshalJSONMessage(t *testing.T) {
+	var msg jsonTestMessage
+
+	err := iso8583.UnmarshalJSONMessage([]byte(`{"mti":"0100","2":"5400000000000011","3":"000000",`+
+		`"52":"0123456789abcdef","55":"9F26","1":"8000000000000000"}`), &msg)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	code := iso8583.VAR("000000")
+	assert.Equal(t, jsonTestMessage{
+		MTI:       iso8583.MTI{MTI: "0100"},
+		Secondary: iso8583.BITMAP{Bitmap: bitmap.FromBytes([]byte{0x80, 0, 0, 0, 0, 0, 0, 0})},
+		PAN:       "5400000000000011",
+		Code:      &code,
+		PIN:       []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef},
+		ICC:       []byte{0x9f, 0x26},
+	}, msg)
+}
+
+func TestUnmarshalJSONMessage_errors(t *testing.T) {
+	testList := []struct {
+		Name        string
+		Input       string
+		Output      interface{}
+		OutputError string
+	}{
+		{
+			Name:        "not_a_pointer",
+			Input:       `{}`,
+			Output:      jsonTestMessage{},
+			OutputError: "iso8583.unmarshaljson: interface input is not a pointer to a structure",
+		},
+		{
+			Name:        "unknown_field",
+			Input:       `{"99":"1"}`,
+			Output:      &jsonTestMessage{},
+			OutputError: "iso8583.unmarshaljson: unknown field in document '99'",
+		},
+		{
+			Name:        "invalid_hex",
+			Input:       `{"52":"XX"}`,
+			Output:      &jsonTestMessage{},
+			OutputError: "iso8583.unmarshaljson: field 52 cant be unmarshaled: invalid hexadecimal value: encoding/hex: invalid byte: U+0058 'X'",
+		},
+		{
+			Name:        "invalid_mti",
+			Input:       `{"mti":"01A0"}`,
+			Output:      &jsonTestMessage{},
+			OutputError: `iso8583.unmarshaljson: field mti cant be unmarshaled: mti characters arent numbers: strconv.Atoi: parsing "01A0": invalid syntax`,
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := iso8583.UnmarshalJSONMessage([]byte(testCase.Input), testCase.Output)
+			if assert.NotNil(t, err) {
+				assert.Equal(t, testCase.OutputError, err.Error())
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONMessage_invalid_document(t *testing.T) {
+	err := iso8583.UnmarshalJSONMessage([]byte(`["0100"]`), &jsonTestMessage{})
+	if assert.NotNil(t, err) {
+		assert.True(t, strings.HasPrefix(err.Error(), "iso8583.unmarshaljson: json: cannot unmarshal array"))
+	}
+}
+
+func TestJSONMessage_replay_into_marshal(t *testing.T) {
+	original := template.MasterCardISO87{
+		MessageTypeIdentifier:                  iso8583.MTI{MTI: "0100"},
+		PrimaryAccountNumber:                   "5400000000000011",
+		ProcessingCode:                         "000000",
+		AmountTransaction:                      "000000000100",
+		PersonalIDNumberData:                   []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef},
+		IntegratedCircuitCardSystemRelatedData: []byte{0x9f, 0x26, 0x01, 0xff},
+		RecordData:                             "record",
+	}
+
+	fixture, err := iso8583.MarshalJSONMessage(original)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	var replayed template.MasterCardISO87
+	if !assert.Nil(t, iso8583.UnmarshalJSONMessage(fixture, &replayed)) {
+		t.FailNow()
+	}
+
+	expected, err := iso8583.Marshal(original)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	o, err := iso8583.Marshal(replayed)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, expected, o)
+}
+
+func TestBuiltinTypes_JSON(t *testing.T) {
+	b, err := json.Marshal(struct {
+		MTI    iso8583.MTI
+		Bitmap iso8583.BITMAP
+		Binary iso8583.BINARY
+	}{
+		MTI:    iso8583.MTI{MTI: "0810"},
+		Bitmap: iso8583.BITMAP{Bitmap: map[int]bool{1: true, 8: true}},
+		Binary: []byte{0xca, 0xfe},
+	})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, `{"MTI":"0810","Bitmap":"81","Binary":"CAFE"}`, string(b))
+}
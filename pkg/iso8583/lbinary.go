@@ -0,0 +1,92 @@
+package iso8583
+
+import (
+	"errors"
+)
+
+// LBINARY is a []byte implementation of a field with a length indicator before, which amount of digits
+// is indicated by the length tag so any indicator width can be used without a new field type.
+// The indicator can be encoded using encode tag, for example a field with a 6 digits length indicator:
+// 	`iso8583:"127,length:6,encoding:ascii"`
+type LBINARY []byte
+
+// MarshalISO8583 returns a copy of binary content after the length indicator.
+func (binary LBINARY) MarshalISO8583(length int, enc string) ([]byte, error) {
+	return marshalLengthBinary(length, binary, enc)
+}
+
+// UnmarshalISO8583 reads the length indicated amount of bytes from b and load the LBINARY field with it.
+func (binary *LBINARY) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	n, content, err := unmarshalLengthBinary(length, b, length, enc)
+	if err != nil {
+		return 0, err
+	}
+
+	*binary = content
+	return n, nil
+}
+
+// MarshalJSON represents the content in upper case hexadecimal.
+func (binary LBINARY) MarshalJSON() ([]byte, error) {
+	return marshalHexJSON(binary)
+}
+
+// UnmarshalJSON reads the content from hexadecimal.
+func (binary *LBINARY) UnmarshalJSON(data []byte) error {
+	b, err := unmarshalHexJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*binary = b
+	return nil
+}
+
+// ValidateISO8583 checks that the content fits in the length indicator, only 'b' format is allowed.
+func (binary LBINARY) ValidateISO8583(length int, format string) error {
+	if err := checkLengthDigits(length); err != nil {
+		return err
+	}
+
+	if err := checkBinaryFormat(format); err != nil {
+		return err
+	}
+
+	return checkMaxLength(len(binary), length)
+}
+
+// marshalLengthBinary returns a copy of v after a length indicator of l digits.
+func marshalLengthBinary(l int, v []byte, enc string) ([]byte, error) {
+	if err := checkLengthDigits(l); err != nil {
+		return nil, err
+	}
+
+	binaryCopy := make([]byte, len(v))
+	copy(binaryCopy, v)
+
+	lEncoding, _ := ReadSplitEncodings(enc)
+	return LengthMarshal(l, binaryCopy, lEncoding)
+}
+
+// unmarshalLengthBinary reads a l digits length indicator contained in length bytes and copies the indicated content.
+func unmarshalLengthBinary(l int, b []byte, length int, enc string) (int, []byte, error) {
+	if b == nil {
+		return 0, nil, errors.New("bytes input is nil")
+	}
+
+	if err := checkLengthDigits(l); err != nil {
+		return 0, nil, err
+	}
+
+	lEncoding, _ := ReadSplitEncodings(enc)
+
+	n, b, err := LengthUnmarshal(l, b, length, lEncoding)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	content := make([]byte, len(b))
+	copy(content, b)
+
+	return n, content, nil
+}
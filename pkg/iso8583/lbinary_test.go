@@ -0,0 +1,41 @@
+package iso8583_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+func TestLBINARY_MarshalISO8583(t *testing.T) {
+	o, err := iso8583.LBINARY{0x01, 0xAB}.MarshalISO8583(5, "ascii")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, []byte{'0', '0', '0', '0', '2', 0x01, 0xAB}, o)
+
+	_, err = iso8583.LBINARY{0x01}.MarshalISO8583(0, "ascii")
+	assert.EqualError(t, err, "length tag must indicate the amount of length indicator digits")
+}
+
+func TestLBINARY_UnmarshalISO8583(t *testing.T) {
+	var v iso8583.LBINARY
+
+	n, err := v.UnmarshalISO8583([]byte{0xF0, 0xF2, 0x01, 0xAB, 0xFF}, 2, "ebcdic")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, 4, n)
+	assert.Equal(t, iso8583.LBINARY{0x01, 0xAB}, v)
+
+	_, err = v.UnmarshalISO8583(nil, 2, "ascii")
+	assert.EqualError(t, err, "bytes input is nil")
+}
+
+func TestLBINARY_ValidateISO8583(t *testing.T) {
+	assert.Nil(t, iso8583.LBINARY{0x01}.ValidateISO8583(1, "b"))
+	assert.True(t, errors.Is(iso8583.LBINARY(make([]byte, 10)).ValidateISO8583(1, ""), iso8583.ErrInvalidLength))
+	assert.True(t, errors.Is(iso8583.LBINARY{0x01}.ValidateISO8583(1, "n"), iso8583.ErrInvalidFormat))
+}
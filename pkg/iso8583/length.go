@@ -8,52 +8,24 @@ import (
 
 // LengthMarshal receives the expected amount of "L" the content (already encoded) that comes after L and a encoding
 // it returns the result bytes after combines the L and the value.
+// The L value is the amount of bytes of the content, variable length fields use the amount of characters instead.
 func LengthMarshal(l int, v []byte, enc string) ([]byte, error) {
-	varContent := v
-	llEncoding := enc
-
-	llValue := strconv.Itoa(len(varContent))
-	if len(llValue) > l {
-		return nil, fmt.Errorf("content length exceeded the %s limit for %s elements",
-			strings.Repeat("9", l), strings.Repeat("L", l))
-	}
-
-	for len(llValue) < l {
-		llValue = "0" + llValue
-	}
-
-	llContent, err := applyEncoding([]byte(llValue), llEncoding, MarshalEncodings)
+	llContent, err := marshalLengthIndicator(l, len(v), enc)
 	if err != nil {
 		return nil, err
 	}
 
-	return append(llContent, varContent...), nil
+	return append(llContent, v...), nil
 }
 
 // LengthUnmarshal receives the amount of "L", the source bytes, the amount of bytes to read, and a encoding;
 // it returns the amount of bytes readed, the actually value bytes and a error.
 func LengthUnmarshal(l int, b []byte, length int, enc string) (int, []byte, error) {
-	if len(b) < length {
-		return 0, nil, fmt.Errorf("message remain (%v bytes) is shorter than %s byte length (%v)",
-			len(b), strings.Repeat("L", l), length)
-	}
-
-	llContent := make([]byte, length)
-	copy(llContent, b[:length])
-
-	llEncoding := enc
-
-	llContent, err := applyEncoding(llContent, llEncoding, UnmarshalDecodings)
+	llValue, err := unmarshalLengthIndicator(l, b, length, enc)
 	if err != nil {
 		return 0, nil, err
 	}
 
-	llValue, err := strconv.Atoi(string(llContent))
-	if err != nil {
-		return 0, nil, fmt.Errorf("obtained %s after decoding is not a valid integer: %v",
-			strings.Repeat("L", l), string(llContent))
-	}
-
 	if len(b)-length < llValue {
 		return 0, nil, fmt.Errorf("message remain (%v bytes) is shorter than %s indicated length (%v)",
 			len(b)-length, strings.Repeat("L", l), llValue)
@@ -75,3 +47,44 @@ func ReadSplitEncodings(str string) (string, string) {
 
 	return str, str
 }
+
+// marshalLengthIndicator returns the l digits indicator of n encoded with enc.
+func marshalLengthIndicator(l int, n int, enc string) ([]byte, error) {
+	llValue := strconv.Itoa(n)
+	if len(llValue) > l {
+		return nil, fmt.Errorf("content length exceeded the %s limit for %s elements",
+			strings.Repeat("9", l), strings.Repeat("L", l))
+	}
+
+	return applyEncoding([]byte(strings.Repeat("0", l-len(llValue))+llValue), enc)
+}
+
+// unmarshalLengthIndicator decodes the l digits indicator contained in the first length bytes of b.
+func unmarshalLengthIndicator(l int, b []byte, length int, enc string) (int, error) {
+	if len(b) < length {
+		return 0, fmt.Errorf("message remain (%v bytes) is shorter than %s byte length (%v)",
+			len(b), strings.Repeat("L", l), length)
+	}
+
+	llContent, err := applyDecoding(b[:length], enc)
+	if err != nil {
+		return 0, err
+	}
+
+	// Codecs that pack digits pad odd indicators.
+	if decodedLen(enc, length) != length && len(llContent) > l {
+		llContent = llContent[:l]
+	}
+
+	llValue, err := strconv.Atoi(string(llContent))
+	if err != nil {
+		return 0, fmt.Errorf("obtained %s after decoding is not a valid integer: %v",
+			strings.Repeat("L", l), string(llContent))
+	}
+
+	if llValue < 0 {
+		return 0, fmt.Errorf("obtained %s after decoding is a negative length: %v", strings.Repeat("L", l), llValue)
+	}
+
+	return llValue, nil
+}
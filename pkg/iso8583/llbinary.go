@@ -37,3 +37,28 @@ func (binary *LLBINARY) UnmarshalISO8583(b []byte, length int, enc string) (int,
 
 	return n, nil
 }
+
+// MarshalJSON represents the content in upper case hexadecimal.
+func (binary LLBINARY) MarshalJSON() ([]byte, error) {
+	return marshalHexJSON(binary)
+}
+
+// UnmarshalJSON reads the content from hexadecimal.
+func (binary *LLBINARY) UnmarshalJSON(data []byte) error {
+	b, err := unmarshalHexJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*binary = b
+	return nil
+}
+
+// ValidateISO8583 checks that the content fits in the LL indicator, only 'b' format is allowed.
+func (binary LLBINARY) ValidateISO8583(length int, format string) error {
+	if err := checkBinaryFormat(format); err != nil {
+		return err
+	}
+
+	return checkMaxLength(len(binary), 2)
+}
@@ -37,3 +37,33 @@ func (binary *LLLBINARY) UnmarshalISO8583(b []byte, length int, enc string) (int
 
 	return n, nil
 }
+
+// MarshalJSON represents the content in upper case hexadecimal.
+func (binary LLLBINARY) MarshalJSON() ([]byte, error) {
+	return marshalHexJSON(binary)
+}
+
+// UnmarshalJSON reads the content from hexadecimal.
+func (binary *LLLBINARY) UnmarshalJSON(data []byte) error {
+	b, err := unmarshalHexJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*binary = b
+	return nil
+}
+
+// ValidateISO8583 checks that the content fits in the LLL indicator, only 'b' and 'emv' formats are allowed.
+// With 'emv' format the content must be BER-TLV chip data which satisfies the emv.Dictionary definitions.
+func (binary LLLBINARY) ValidateISO8583(length int, format string) error {
+	if format == _formatEMV {
+		if err := validateChipData(binary); err != nil {
+			return err
+		}
+	} else if err := checkBinaryFormat(format); err != nil {
+		return err
+	}
+
+	return checkMaxLength(len(binary), 3)
+}
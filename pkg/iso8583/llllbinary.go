@@ -0,0 +1,47 @@
+package iso8583
+
+// LLLLBINARY is a []byte implementation of a field with a LLLL indicator before which can be encoded using encode tag,
+// it does not contain any special behaviour more than unload all bytes on marshaling and
+// reading the specified length on unmarshaling.
+type LLLLBINARY []byte
+
+// MarshalISO8583 returns a copy of binary content after the LLLL indicator.
+func (binary LLLLBINARY) MarshalISO8583(length int, enc string) ([]byte, error) {
+	return marshalLengthBinary(4, binary, enc)
+}
+
+// UnmarshalISO8583 reads the length indicated amount of bytes from b and load the LLLLBINARY field with it.
+func (binary *LLLLBINARY) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	n, content, err := unmarshalLengthBinary(4, b, length, enc)
+	if err != nil {
+		return 0, err
+	}
+
+	*binary = content
+	return n, nil
+}
+
+// MarshalJSON represents the content in upper case hexadecimal.
+func (binary LLLLBINARY) MarshalJSON() ([]byte, error) {
+	return marshalHexJSON(binary)
+}
+
+// UnmarshalJSON reads the content from hexadecimal.
+func (binary *LLLLBINARY) UnmarshalJSON(data []byte) error {
+	b, err := unmarshalHexJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*binary = b
+	return nil
+}
+
+// ValidateISO8583 checks that the content fits in the LLLL indicator, only 'b' format is allowed.
+func (binary LLLLBINARY) ValidateISO8583(length int, format string) error {
+	if err := checkBinaryFormat(format); err != nil {
+		return err
+	}
+
+	return checkMaxLength(len(binary), 4)
+}
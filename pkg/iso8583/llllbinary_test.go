@@ -0,0 +1,47 @@
+package iso8583_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+func TestLLLLBINARY_MarshalISO8583(t *testing.T) {
+	o, err := iso8583.LLLLBINARY{0x01, 0xAB}.MarshalISO8583(4, "ebcdic")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, []byte{0xF0, 0xF0, 0xF0, 0xF2, 0x01, 0xAB}, o)
+}
+
+func TestLLLLBINARY_UnmarshalISO8583(t *testing.T) {
+	var v iso8583.LLLLBINARY
+
+	n, err := v.UnmarshalISO8583([]byte{'0', '0', '0', '2', 0x01, 0xAB, 0xFF}, 4, "ascii")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, 6, n)
+	assert.Equal(t, iso8583.LLLLBINARY{0x01, 0xAB}, v)
+
+	_, err = v.UnmarshalISO8583(nil, 4, "ascii")
+	assert.EqualError(t, err, "bytes input is nil")
+
+	_, err = v.UnmarshalISO8583([]byte("00"), 4, "ascii")
+	assert.EqualError(t, err, "message remain (2 bytes) is shorter than LLLL byte length (4)")
+}
+
+func TestLLLLBINARY_JSON(t *testing.T) {
+	b, err := json.Marshal(iso8583.LLLLBINARY{0x01, 0xAB})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, `"01AB"`, string(b))
+
+	var v iso8583.LLLLBINARY
+	assert.Nil(t, json.Unmarshal(b, &v))
+	assert.Equal(t, iso8583.LLLLBINARY{0x01, 0xAB}, v)
+}
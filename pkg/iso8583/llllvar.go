@@ -0,0 +1,34 @@
+package iso8583
+
+// LLLLVAR field type, used for fields of up to 9999 bytes like the ISO 8583:1993 private data elements.
+// For use of different encoding for 'LLLL' and 'VAR' separate both encodings with a slash,
+// where first element is the llll encoding and the second the var encoding.
+// For Unmarshal length indicate the amount of byte that contain the LLLL value
+// For example:
+// 	`iso8583:"127,length:4,encoding:ascii/ebcdic"`
+type LLLLVAR string
+
+// MarshalISO8583 allows to use this type in structs and be able tu iso8583.Marshal it.
+func (v LLLLVAR) MarshalISO8583(length int, enc string) ([]byte, error) {
+	return marshalLengthVar(4, string(v), enc)
+}
+
+// UnmarshalISO8583 allows to use this type in structs and be able tu iso8583.Unmarshal it.
+func (v *LLLLVAR) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	n, content, err := unmarshalLengthVar(4, b, length, enc)
+	if err != nil {
+		return 0, err
+	}
+
+	*v = LLLLVAR(content)
+	return n, nil
+}
+
+// ValidateISO8583 checks that the content fits in the LLLL indicator and satisfies the format.
+func (v LLLLVAR) ValidateISO8583(length int, format string) error {
+	if err := checkMaxLength(len(v), 4); err != nil {
+		return err
+	}
+
+	return ValidateFormat(format, string(v))
+}
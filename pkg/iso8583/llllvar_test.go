@@ -0,0 +1,116 @@
+package iso8583_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jattento/go-iso8583/pkg/encoding/ebcdic"
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLLLLVAR_MarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		V           iso8583.LLLLVAR
+		Encoding    string
+		OutputBytes []byte
+		OutputError string
+	}{
+		{
+			Name:        "ebcdic_ascii",
+			V:           "ascii_standard",
+			Encoding:    "ebcdic/ascii",
+			OutputBytes: append(ebcdic.V1047.FromGoString("0014"), []byte("ascii_standard")...),
+		},
+		{
+			Name:        "ascii_long",
+			V:           iso8583.LLLLVAR(strings.Repeat("A", 1000)),
+			Encoding:    "ascii",
+			OutputBytes: []byte("1000" + strings.Repeat("A", 1000)),
+		},
+		{
+			Name:        "exceeded",
+			V:           iso8583.LLLLVAR(strings.Repeat("A", 10000)),
+			Encoding:    "ascii",
+			OutputError: "content length exceeded the 9999 limit for LLLL elements",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(fmt.Sprintf("llllvar_to_bytes_%s", testCase.Name), func(t *testing.T) {
+			o, err := testCase.V.MarshalISO8583(4, testCase.Encoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputBytes, o)
+		})
+	}
+}
+
+func TestLLLLVAR_UnmarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name          string
+		InputBytes    []byte
+		InputEncoding string
+		InputLength   int
+		OutputContent string
+		OutputError   string
+		ExpectedRead  int
+	}{
+		{
+			Name:          "ascii_standard",
+			InputEncoding: "ascii",
+			InputLength:   4,
+			OutputContent: "ascii_standard",
+			InputBytes:    []byte("0014ascii_standardFF"),
+			ExpectedRead:  18,
+		},
+		{
+			Name:          "ebcdic_ascii",
+			InputEncoding: "ebcdic/ascii",
+			InputLength:   4,
+			OutputContent: "ebcdic",
+			InputBytes:    append(ebcdic.V1047.FromGoString("0006"), []byte("ebcdic")...),
+			ExpectedRead:  10,
+		},
+		{
+			Name:          "nil_bytes_error",
+			InputEncoding: "ascii",
+			InputLength:   4,
+			OutputError:   "bytes input is nil",
+		},
+		{
+			Name:          "short_content",
+			InputEncoding: "ascii",
+			InputLength:   4,
+			InputBytes:    []byte("0010text"),
+			OutputError:   "message remain (4 bytes) is shorter than LLLL indicated length (10)",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(fmt.Sprintf("bytes_to_llllvar_%s", testCase.Name), func(t *testing.T) {
+			var v iso8583.LLLLVAR
+
+			n, err := v.UnmarshalISO8583(testCase.InputBytes, testCase.InputLength, testCase.InputEncoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputContent, string(v))
+			assert.Equal(t, testCase.ExpectedRead, n)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package iso8583
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// LVAR field type is a variable length string which amount of length indicator digits is indicated
+// by the length tag, so any indicator width can be used without a new field type.
+// For example a field with a 6 digits length indicator:
+// 	`iso8583:"127,length:6,encoding:ascii"`
+// For use of different encoding for the indicator and 'VAR' separate both encodings with a slash,
+// where first element is the indicator encoding and the second the var encoding.
+type LVAR string
+
+// MarshalISO8583 allows to use this type in structs and be able tu iso8583.Marshal it.
+func (v LVAR) MarshalISO8583(length int, enc string) ([]byte, error) {
+	return marshalLengthVar(length, string(v), enc)
+}
+
+// UnmarshalISO8583 allows to use this type in structs and be able tu iso8583.Unmarshal it.
+func (v *LVAR) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	n, content, err := unmarshalLengthVar(length, b, length, enc)
+	if err != nil {
+		return 0, err
+	}
+
+	*v = LVAR(content)
+	return n, nil
+}
+
+// ValidateISO8583 checks that the content fits in the length indicator and satisfies the format.
+func (v LVAR) ValidateISO8583(length int, format string) error {
+	if err := checkLengthDigits(length); err != nil {
+		return err
+	}
+
+	if err := checkMaxLength(len(v), length); err != nil {
+		return err
+	}
+
+	return ValidateFormat(format, string(v))
+}
+
+// marshalLengthVar encodes v and prepends a length indicator of l digits with its amount of characters.
+func marshalLengthVar(l int, v string, enc string) ([]byte, error) {
+	if err := checkLengthDigits(l); err != nil {
+		return nil, err
+	}
+
+	lEncoding, varEncoding := ReadSplitEncodings(enc)
+
+	content, err := applyEncoding([]byte(v), varEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	indicator, err := marshalLengthIndicator(l, characters(v, content, varEncoding), lEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(indicator, content...), nil
+}
+
+// unmarshalLengthVar reads a l digits length indicator contained in length bytes and decodes the indicated
+// amount of characters.
+func unmarshalLengthVar(l int, b []byte, length int, enc string) (int, string, error) {
+	if b == nil {
+		return 0, "", errors.New("bytes input is nil")
+	}
+
+	if err := checkLengthDigits(l); err != nil {
+		return 0, "", err
+	}
+
+	lEncoding, varEncoding := ReadSplitEncodings(enc)
+
+	llValue, err := unmarshalLengthIndicator(l, b, length, lEncoding)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if remain := len(b) - length; llValue > decodedLen(varEncoding, remain) {
+		return 0, "", fmt.Errorf("message remain (%v bytes) is shorter than %s indicated length (%v)",
+			remain, strings.Repeat("L", l), encodedLen(varEncoding, llValue))
+	}
+
+	content, n, err := decodeCharacters(b[length:], llValue, varEncoding)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return length + n, string(content), nil
+}
+
+// characters returns the amount of characters of v, which is the decoded length of its encoded content
+// without the padding added by the codec.
+func characters(v string, content []byte, enc string) int {
+	n := decodedLen(enc, len(content))
+	if len(v) < n && encodedLen(enc, len(v)) == len(content) {
+		return len(v)
+	}
+
+	return n
+}
+
+// checkLengthDigits checks that a variable length field has a length indicator.
+func checkLengthDigits(l int) error {
+	if l < 1 {
+		return errors.New("length tag must indicate the amount of length indicator digits")
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package iso8583_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+func TestLVAR_MarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		V           iso8583.LVAR
+		Length      int
+		Encoding    string
+		OutputBytes []byte
+		OutputError string
+	}{
+		{
+			Name:        "one_digit",
+			V:           "text",
+			Length:      1,
+			Encoding:    "ascii",
+			OutputBytes: []byte("4text"),
+		},
+		{
+			Name:        "six_digits",
+			V:           "text",
+			Length:      6,
+			Encoding:    "ascii",
+			OutputBytes: []byte("000004text"),
+		},
+		{
+			Name:        "split_encoding",
+			V:           "text",
+			Length:      2,
+			Encoding:    "ebcdic/ascii",
+			OutputBytes: []byte{0xF0, 0xF4, 't', 'e', 'x', 't'},
+		},
+		{
+			Name:        "exceeded",
+			V:           "0123456789",
+			Length:      1,
+			Encoding:    "ascii",
+			OutputError: "content length exceeded the 9 limit for L elements",
+		},
+		{
+			Name:        "without_length",
+			V:           "text",
+			Encoding:    "ascii",
+			OutputError: "length tag must indicate the amount of length indicator digits",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(fmt.Sprintf("lvar_to_bytes_%s", testCase.Name), func(t *testing.T) {
+			o, err := testCase.V.MarshalISO8583(testCase.Length, testCase.Encoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputBytes, o)
+		})
+	}
+}
+
+func TestLVAR_UnmarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name          string
+		InputBytes    []byte
+		InputEncoding string
+		InputLength   int
+		OutputContent string
+		OutputError   string
+		ExpectedRead  int
+	}{
+		{
+			Name:          "six_digits",
+			InputEncoding: "ascii",
+			InputLength:   6,
+			OutputContent: "text",
+			InputBytes:    []byte("000004textFF"),
+			ExpectedRead:  10,
+		},
+		{
+			Name:          "split_encoding",
+			InputEncoding: "ebcdic/ascii",
+			InputLength:   2,
+			OutputContent: "text",
+			InputBytes:    []byte{0xF0, 0xF4, 't', 'e', 'x', 't'},
+			ExpectedRead:  6,
+		},
+		{
+			Name:          "nil_bytes_error",
+			InputEncoding: "ascii",
+			InputLength:   6,
+			OutputError:   "bytes input is nil",
+		},
+		{
+			Name:          "without_length",
+			InputEncoding: "ascii",
+			InputBytes:    []byte("4text"),
+			OutputError:   "length tag must indicate the amount of length indicator digits",
+		},
+		{
+			Name:          "invalid_indicator",
+			InputEncoding: "ascii",
+			InputLength:   3,
+			InputBytes:    []byte("0X4text"),
+			OutputError:   "obtained LLL after decoding is not a valid integer: 0X4",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(fmt.Sprintf("bytes_to_lvar_%s", testCase.Name), func(t *testing.T) {
+			var v iso8583.LVAR
+
+			n, err := v.UnmarshalISO8583(testCase.InputBytes, testCase.InputLength, testCase.InputEncoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputContent, string(v))
+			assert.Equal(t, testCase.ExpectedRead, n)
+		})
+	}
+}
+
+func TestLVAR_ValidateISO8583(t *testing.T) {
+	assert.Nil(t, iso8583.LVAR("123").ValidateISO8583(5, "n"))
+	assert.True(t, errors.Is(iso8583.LVAR("0123456789").ValidateISO8583(1, ""), iso8583.ErrInvalidLength))
+	assert.True(t, errors.Is(iso8583.LVAR("ABC").ValidateISO8583(5, "n"), iso8583.ErrInvalidFormat))
+	assert.EqualError(t, iso8583.LVAR("ABC").ValidateISO8583(0, ""),
+		"length tag must indicate the amount of length indicator digits")
+}
+
+func TestLVAR_message(t *testing.T) {
+	type message struct {
+		MTI       iso8583.MTI        `iso8583:"mti,length:4"`
+		Bitmap    iso8583.BITMAP     `iso8583:"bitmap,length:64"`
+		Record    iso8583.LLLLVAR    `iso8583:"2,length:4"`
+		File      iso8583.LBINARY    `iso8583:"3,length:5"`
+		Reference iso8583.LVAR       `iso8583:"4,length:6,encoding:ascii"`
+		Reserved  iso8583.LLLLBINARY `iso8583:"5,length:4,omitempty"`
+	}
+
+	input := message{
+		MTI:       iso8583.MTI{MTI: "0100"},
+		Record:    "record",
+		File:      []byte{0x01, 0x02},
+		Reference: "ref",
+	}
+
+	b, err := iso8583.Marshal(input)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, append(append([]byte("0100\x70\x00\x00\x00\x00\x00\x00\x000006record00002"), 0x01, 0x02),
+		[]byte("000003ref")...), b)
+
+	var output message
+	if _, err := iso8583.Unmarshal(b, &output); !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, input.Record, output.Record)
+	assert.Equal(t, input.File, output.File)
+	assert.Equal(t, input.Reference, output.Reference)
+}
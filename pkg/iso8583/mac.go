@@ -0,0 +1,186 @@
+package iso8583
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrMACMismatch is returned by VerifyMAC when the MAC field differs from the computed one,
+// exported error for asserting.
+var ErrMACMismatch = errors.New("message authentication code mismatch")
+
+// MACFunc computes the message authentication code of data, the message bytes that precede the MAC field.
+// The functions of the mac package can be used, for example:
+// 	f, err := mac.Algorithm3(key, mac.Padding1)
+// 	b, err := iso8583.MarshalMAC(&msg, f)
+type MACFunc func(data []byte) ([]byte, error)
+
+// MarshalMAC marshals v like Marshal, but the MAC field is filled with the code computed by mac over the
+// preceding bytes of the message. The MAC field is 128 if present, otherwise 64, and it must be the last field
+// of the message. As the bitmaps indicate it, it must be present in v with a placeholder value like
+// make(iso8583.BINARY, 8).
+//
+// MAC field type must be a byte slice, which receives the code as it is, or a string, which receives it
+// in upper case hexadecimal. If v is a pointer the MAC field is updated.
+func MarshalMAC(v interface{}, mac MACFunc) ([]byte, error) {
+	if mac == nil {
+		return nil, errors.New("iso8583.marshal: nil MAC function")
+	}
+
+	return marshal(v, mac)
+}
+
+// VerifyMAC checks the MAC field (128 if present, otherwise 64) of v, a message unmarshaled from data,
+// against the code computed by mac over the preceding bytes. Data must contain only the message,
+// for example data[:n] with n returned by Unmarshal. ErrMACMismatch is returned if codes differ.
+func VerifyMAC(data []byte, v interface{}, mac MACFunc) error {
+	fields, err := readMessageFields(v)
+	if err != nil {
+		return fmt.Errorf("iso8583.mac: %w", err)
+	}
+
+	present := make(map[string]messageField)
+	for _, f := range fields {
+		if !isNil(f.Value) {
+			present[f.Field] = f
+		}
+	}
+
+	name, err := macFieldName(func(name string) bool { _, ok := present[name]; return ok })
+	if err != nil {
+		return fmt.Errorf("iso8583.mac: %w", err)
+	}
+
+	f := present[name]
+
+	marshaler, isMarshaler := f.Value.Interface().(Marshaler)
+	if !isMarshaler {
+		return fmt.Errorf("iso8583.mac: field %s does not implement Marshaler interface", name)
+	}
+
+	received, err := marshaler.MarshalISO8583(f.Length, f.Encoding)
+	if err != nil {
+		return fmt.Errorf("iso8583.mac: field %s cant be marshaled: %w", name, err)
+	}
+
+	if !bytes.HasSuffix(data, received) {
+		return fmt.Errorf("iso8583.mac: message does not end with field %s", name)
+	}
+
+	code, err := mac(data[:len(data)-len(received)])
+	if err != nil {
+		return fmt.Errorf("iso8583.mac: field %s: %w", name, err)
+	}
+
+	_, expected, err := macFieldValue(f.Value, f.tags, code)
+	if err != nil {
+		return fmt.Errorf("iso8583.mac: field %s: %w", name, err)
+	}
+
+	if subtle.ConstantTimeCompare(expected, received) != 1 {
+		return fmt.Errorf("iso8583.mac: field %s: %w", name, ErrMACMismatch)
+	}
+
+	return nil
+}
+
+// fillMAC replaces the bytes of the MAC field, which must be the last one of the sorted fields, with the code
+// computed over the preceding fields. The MAC field value is updated if it can be set.
+func (m *marshalerMessage) fillMAC(fields []field) error {
+	name, err := macFieldName(func(name string) bool {
+		for _, f := range fields {
+			if f.name == name {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return fmt.Errorf("iso8583.marshal: %w", err)
+	}
+
+	last := len(fields) - 1
+	if fields[last].name != name {
+		return fmt.Errorf("iso8583.marshal: MAC field %s must be the last field of the message", name)
+	}
+
+	data := make([]byte, 0)
+	for _, f := range fields[:last] {
+		data = append(data, f.bytes...)
+	}
+
+	code, err := m.mac(data)
+	if err != nil {
+		return fmt.Errorf("iso8583.marshal: field %s: %w", name, err)
+	}
+
+	for _, f := range m.Fields {
+		if f.Field != name {
+			continue
+		}
+
+		value, b, err := macFieldValue(f.Marshaler, f.tags, code)
+		if err != nil {
+			return fmt.Errorf("iso8583.marshal: field %s: %w", name, err)
+		}
+
+		if f.Marshaler.CanSet() {
+			f.Marshaler.Set(value)
+		}
+
+		fields[last].bytes = b
+	}
+
+	return nil
+}
+
+// macFieldName returns the name of the MAC field: 128 if present, otherwise 64.
+func macFieldName(isPresent func(name string) bool) (string, error) {
+	for _, name := range []string{"128", "64"} {
+		if isPresent(name) {
+			return name, nil
+		}
+	}
+
+	return "", errors.New("MAC field 64 or 128 is not present")
+}
+
+// macFieldValue returns a value of the type of v loaded with code and its marshaled bytes.
+func macFieldValue(v reflect.Value, tag tags, code []byte) (reflect.Value, []byte, error) {
+	t := v.Type()
+	isPtr := t.Kind() == reflect.Ptr
+	if isPtr {
+		t = t.Elem()
+	}
+
+	value := reflect.New(t)
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		value.Elem().SetBytes(code)
+	case t.Kind() == reflect.String:
+		value.Elem().SetString(strings.ToUpper(hex.EncodeToString(code)))
+	default:
+		return reflect.Value{}, nil, errors.New("MAC field must be a byte slice or a string")
+	}
+
+	marshaler, isMarshaler := value.Interface().(Marshaler)
+	if !isMarshaler {
+		return reflect.Value{}, nil, errors.New("does not implement Marshaler interface")
+	}
+
+	b, err := marshaler.MarshalISO8583(tag.Length, tag.Encoding)
+	if err != nil {
+		return reflect.Value{}, nil, fmt.Errorf("cant be marshaled: %w", err)
+	}
+
+	if !isPtr {
+		value = value.Elem()
+	}
+
+	return value, b, nil
+}
@@ -0,0 +1,144 @@
+package iso8583_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+	"github.com/jattento/go-iso8583/pkg/mac"
+)
+
+type macTestMessage struct {
+	MTI             iso8583.VAR    `iso8583:"mti,length:4"`
+	Bitmap          iso8583.BITMAP `iso8583:"bitmap"`
+	SecondaryBitmap iso8583.BITMAP `iso8583:"1,omitempty"`
+	ProcessingCode  iso8583.VAR    `iso8583:"3,length:6"`
+	MAC             iso8583.BINARY `iso8583:"64,length:8,omitempty"`
+	NetworkCode     iso8583.VAR    `iso8583:"70,length:3,omitempty"`
+	MAC128          iso8583.BINARY `iso8583:"128,length:8,omitempty"`
+}
+
+// lengthMAC returns the length of the authenticated data as MAC, so tests can check which bytes were used.
+func lengthMAC(data []byte) ([]byte, error) {
+	return []byte(fmt.Sprintf("%08d", len(data))), nil
+}
+
+func TestMarshalMAC(t *testing.T) {
+	placeholder := make(iso8583.BINARY, 8)
+
+	testList := []struct {
+		Name        string
+		Input       macTestMessage
+		OutputBytes string
+		OutputMAC   iso8583.BINARY
+		OutputError string
+	}{
+		{
+			Name:        "primary_mac",
+			Input:       macTestMessage{MTI: "0800", ProcessingCode: "000000", MAC: placeholder},
+			OutputBytes: "0800" + "\x20\x00\x00\x00\x00\x00\x00\x01" + "000000" + "00000018",
+			OutputMAC:   iso8583.BINARY("00000018"),
+		},
+		{
+			Name:  "secondary_mac",
+			Input: macTestMessage{MTI: "0800", ProcessingCode: "000000", NetworkCode: "301", MAC128: placeholder},
+			OutputBytes: "0800" + "\xa0\x00\x00\x00\x00\x00\x00\x00" + "\x04\x00\x00\x00\x00\x00\x00\x01" +
+				"000000" + "301" + "00000029",
+		},
+		{
+			Name:        "primary_mac_not_last",
+			Input:       macTestMessage{MTI: "0800", ProcessingCode: "000000", NetworkCode: "301", MAC: placeholder},
+			OutputError: "iso8583.marshal: MAC field 64 must be the last field of the message",
+		},
+		{
+			Name:        "absent_mac",
+			Input:       macTestMessage{MTI: "0800", ProcessingCode: "000000"},
+			OutputError: "iso8583.marshal: MAC field 64 or 128 is not present",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			msg := testCase.Input
+
+			b, err := iso8583.MarshalMAC(&msg, lengthMAC)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputBytes, string(b))
+
+			if testCase.OutputMAC != nil {
+				assert.Equal(t, testCase.OutputMAC, msg.MAC)
+			}
+		})
+	}
+}
+
+func TestMarshalMAC_hexadecimal_field(t *testing.T) {
+	msg := struct {
+		MTI    iso8583.VAR    `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP `iso8583:"bitmap"`
+		MAC    iso8583.VAR    `iso8583:"64,length:16"`
+	}{MTI: "0800", MAC: "0000000000000000"}
+
+	b, err := iso8583.MarshalMAC(msg, func(data []byte) ([]byte, error) { return []byte{0xab, 0xcd, 1, 2, 3, 4, 5, 6}, nil })
+	assert.Nil(t, err)
+	assert.Equal(t, "0800\x00\x00\x00\x00\x00\x00\x00\x01ABCD010203040506", string(b))
+
+	// Values are only updated through pointers.
+	assert.Equal(t, iso8583.VAR("0000000000000000"), msg.MAC)
+}
+
+func TestMarshalMAC_function_error(t *testing.T) {
+	msg := macTestMessage{MTI: "0800", ProcessingCode: "000000", MAC: make(iso8583.BINARY, 8)}
+
+	_, err := iso8583.MarshalMAC(msg, func([]byte) ([]byte, error) { return nil, errors.New("hsm unavailable") })
+	assert.EqualError(t, err, "iso8583.marshal: field 64: hsm unavailable")
+
+	_, err = iso8583.MarshalMAC(msg, nil)
+	assert.EqualError(t, err, "iso8583.marshal: nil MAC function")
+}
+
+func TestVerifyMAC(t *testing.T) {
+	key, _ := hex.DecodeString("0123456789ABCDEFFEDCBA9876543210")
+	f, err := mac.Algorithm3(key, mac.Padding1)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	b, err := iso8583.MarshalMAC(&macTestMessage{
+		MTI: "0800", ProcessingCode: "000000", NetworkCode: "301", MAC128: make(iso8583.BINARY, 8),
+	}, f)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	var msg macTestMessage
+	n, err := iso8583.Unmarshal(b, &msg)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Nil(t, iso8583.VerifyMAC(b[:n], &msg, f))
+
+	tampered := append([]byte{}, b...)
+	tampered[len(tampered)-9] = '2'
+	msg = macTestMessage{}
+	n, err = iso8583.Unmarshal(tampered, &msg)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.True(t, errors.Is(iso8583.VerifyMAC(tampered[:n], &msg, f), iso8583.ErrMACMismatch))
+
+	assert.EqualError(t, iso8583.VerifyMAC(b[:n-1], &msg, f), "iso8583.mac: message does not end with field 128")
+	assert.EqualError(t, iso8583.VerifyMAC(b, &macTestMessage{MTI: "0800"}, f),
+		"iso8583.mac: MAC field 64 or 128 is not present")
+}
@@ -0,0 +1,132 @@
+package iso8583
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jattento/go-iso8583/pkg/emv"
+)
+
+// MaskCharacter is the character used to hide sensitive data.
+const MaskCharacter = '*'
+
+// MaskPolicies is the map of masking policies that can be referenced using the mask tag,
+// you can append more policies for extended functionality.
+// For example:
+// 	`iso8583:"2,length:2,encoding:ebcdic,mask:pan"`
+// - none: the value is displayed as it is.
+// - full: every character is masked.
+// - pan: only the first 6 and last 4 characters are displayed.
+// - track: the account number of a track is masked as in pan policy, the rest of the track is fully masked.
+// - emv: hexadecimal BER-TLV chip data, sensitive tags are masked using its emv.Dictionary policy (for example
+// 57, 5A and 9F6B) and the rest is displayed. Content which is not valid BER-TLV is fully masked.
+var MaskPolicies = map[string]func(string) string{
+	"none":  func(s string) string { return s },
+	"full":  maskFull,
+	"pan":   maskPAN,
+	"track": maskTrack,
+}
+
+func init() {
+	// Registered on init because chip data tags are masked using the other policies.
+	MaskPolicies["emv"] = maskChipData
+}
+
+// DefaultMasks indicates which policy is applied to a field when no mask tag is present.
+// It is keyed by field number and applies to the message fields of every struct regardless of its specification,
+// so structs where a listed number holds other data must set its mask tag, for example to `mask:none`.
+// The mask tag has always priority. Composite subfields are not affected.
+var DefaultMasks = map[string]string{
+	"2":  "pan",
+	"14": "full",
+	"35": "track",
+	"36": "full",
+	"45": "track",
+	"52": "full",
+	"55": "emv",
+}
+
+// MaskValue applies the given mask policy to s.
+// An empty policy returns s unmodified.
+func MaskValue(policy string, s string) (string, error) {
+	if policy == "" {
+		return s, nil
+	}
+
+	mask, exist := MaskPolicies[policy]
+	if !exist {
+		return "", fmt.Errorf("mask policy '%s' does not exist", policy)
+	}
+
+	return mask(s), nil
+}
+
+// maskPolicy returns the policy that applies to a field considering its tags and the default masks.
+func maskPolicy(tag tags) string {
+	if tag.Mask != "" {
+		return tag.Mask
+	}
+
+	return DefaultMasks[tag.Field]
+}
+
+func maskFull(s string) string {
+	return strings.Repeat(string(MaskCharacter), len([]rune(s)))
+}
+
+func maskPAN(s string) string {
+	const first, last = 6, 4
+
+	r := []rune(s)
+	if len(r) <= first+last {
+		return maskFull(s)
+	}
+
+	return string(r[:first]) + maskFull(string(r[first:len(r)-last])) + string(r[len(r)-last:])
+}
+
+// maskTrack masks the account number from track data with pan policy and the rest of the track entirely.
+// Leading format code and sentinels are kept, for example: "%B", ";".
+func maskTrack(s string) string {
+	r := []rune(s)
+
+	panStart := 0
+	for panStart < len(r) && !unicode.IsDigit(r[panStart]) {
+		panStart++
+	}
+
+	panEnd := panStart
+	for panEnd < len(r) && unicode.IsDigit(r[panEnd]) {
+		panEnd++
+	}
+
+	if panEnd == len(r) {
+		return string(r[:panStart]) + maskPAN(string(r[panStart:]))
+	}
+
+	// The separator is displayed to allow recognizing the track structure.
+	return string(r[:panStart]) + maskPAN(string(r[panStart:panEnd])) + string(r[panEnd]) +
+		maskFull(string(r[panEnd+1:]))
+}
+
+// maskChipData masks the sensitive tags of hexadecimal BER-TLV content.
+func maskChipData(s string) string {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return maskFull(s)
+	}
+
+	list, err := emv.Decode(b)
+	if err != nil {
+		return maskFull(s)
+	}
+
+	masked, err := emv.MaskedHex(list, MaskValue)
+	if err != nil {
+		return maskFull(s)
+	}
+
+	return masked
+}
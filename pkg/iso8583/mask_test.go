@@ -0,0 +1,60 @@
+package iso8583_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+func TestMaskValue(t *testing.T) {
+	testList := []struct {
+		Name        string
+		Policy      string
+		Input       string
+		Output      string
+		OutputError string
+	}{
+		{Name: "empty_policy", Policy: "", Input: "5400000000000011", Output: "5400000000000011"},
+		{Name: "none", Policy: "none", Input: "5400000000000011", Output: "5400000000000011"},
+		{Name: "full", Policy: "full", Input: "1225", Output: "****"},
+		{Name: "pan", Policy: "pan", Input: "5400000000000011", Output: "540000******0011"},
+		{Name: "pan_too_short", Policy: "pan", Input: "5400000011", Output: "**********"},
+		{Name: "track2", Policy: "track", Input: "5400000000000011=25121010000012300000",
+			Output: "540000******0011=********************"},
+		{Name: "track2_d_separator", Policy: "track", Input: "5400000000000011D2512101",
+			Output: "540000******0011D*******"},
+		{Name: "track1", Policy: "track", Input: "B5400000000000011^DOE/JOHN^2512101",
+			Output: "B540000******0011^****************"},
+		{Name: "track_without_separator", Policy: "track", Input: "5400000000000011",
+			Output: "540000******0011"},
+		{Name: "emv", Policy: "emv", Input: "9F2601FF5A085400000000000011" + "5713" +
+			"5400000000000011D25121010000012300000F",
+			Output: "9F2601FF5A08540000******0011" + "5713" + "540000******0011D*********************"},
+		{Name: "emv_expiration_date", Policy: "emv", Input: "5F2403251231" + "9F1F03313233",
+			Output: "5F2403******" + "9F1F03******"},
+		{Name: "emv_invalid_content", Policy: "emv", Input: "9F2608FF", Output: "********"},
+		{Name: "emv_not_hexadecimal", Policy: "emv", Input: "9F2601GG", Output: "********"},
+		{Name: "unknown_policy", Policy: "whale_song", Input: "1234",
+			OutputError: "mask policy 'whale_song' does not exist"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(fmt.Sprintf("mask_value_%s", testCase.Name), func(t *testing.T) {
+			o, err := iso8583.MaskValue(testCase.Policy, testCase.Input)
+			if testCase.OutputError != "" {
+				if assert.NotNil(t, err) {
+					assert.Equal(t, testCase.OutputError, err.Error())
+				}
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.Output, o)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package iso8583
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -43,3 +44,28 @@ func (mtiV *MTI) UnmarshalISO8583(b []byte, length int, enc string) (int, error)
 
 	return n, nil
 }
+
+// MarshalJSON represents the MTI as a JSON string.
+func (mtiV MTI) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mtiV.String())
+}
+
+// UnmarshalJSON reads the MTI from a JSON string and validates it as UnmarshalISO8583 does.
+func (mtiV *MTI) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	_, err := mtiV.UnmarshalISO8583([]byte(s), len(s), "")
+	return err
+}
+
+// ValidateISO8583 checks that the MTI is 4 numeric characters long, length and format are ignored.
+func (mtiV MTI) ValidateISO8583(length int, format string) error {
+	if err := checkLength(len(mtiV.MTI), 4); err != nil {
+		return err
+	}
+
+	return ValidateFormat("n", mtiV.String())
+}
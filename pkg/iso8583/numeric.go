@@ -0,0 +1,217 @@
+package iso8583
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NUMERIC is an int64 implementation of a fixed length numeric field, like amounts, STAN or counts.
+// It is marshaled with left zero padding up to the length tag, for example:
+// 	AmountTransaction iso8583.NUMERIC `iso8583:"4,length:12,encoding:ebcdic"`
+//
+// Amounts have implied decimals, use Decimal to represent them, for example
+// an AmountTransaction of 1050 with 2 decimals is "10.50".
+//
+// As any field, a zero value is considered absent by omitempty, the required tag and the Mandatory rules.
+// Use a *NUMERIC field when zero is a meaningful value: a nil pointer is absent and a pointer to zero is present,
+// Unmarshal allocates the pointer of present fields. For example:
+// 	AmountCardholderBilling *iso8583.NUMERIC `iso8583:"6,length:12,encoding:ebcdic,omitempty"`
+type NUMERIC int64
+
+// MarshalISO8583 returns the value padded with zeros up to length digits, negative values are not allowed.
+func (n NUMERIC) MarshalISO8583(length int, enc string) ([]byte, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("negative value %v can not be represented by a NUMERIC field", int64(n))
+	}
+
+	content, err := padNumeric(uint64(n), length)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyEncoding([]byte(content), enc)
+}
+
+// UnmarshalISO8583 reads length digits from b, any other character is rejected.
+func (n *NUMERIC) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	content, read, err := readNumeric(b, length, enc)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := parseNumeric(content)
+	if err != nil {
+		return 0, err
+	}
+
+	*n = NUMERIC(v)
+	return read, nil
+}
+
+// String returns the value without padding.
+func (n NUMERIC) String() string { return strconv.FormatInt(int64(n), 10) }
+
+// Decimal represents the value with the indicated amount of implied decimals, for example
+// NUMERIC(1050).Decimal(2) returns "10.50".
+func (n NUMERIC) Decimal(decimals int) string { return formatDecimal(int64(n), decimals) }
+
+// ValidateISO8583 checks that the value is not negative and fits in length digits.
+func (n NUMERIC) ValidateISO8583(length int, format string) error {
+	if n < 0 {
+		return fmt.Errorf("%w: negative value %v", ErrInvalidFormat, int64(n))
+	}
+
+	if _, err := padNumeric(uint64(n), length); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLength, err)
+	}
+
+	return nil
+}
+
+// SIGNEDNUMERIC is an int64 implementation of a fixed length 'x+n' field, used by signed amounts
+// of reconciliation messages and fees: a 'C' (credit) or 'D' (debit) character followed by digits.
+// Length tag includes the sign character, for example:
+// 	AmountTransactionFee iso8583.SIGNEDNUMERIC `iso8583:"28,length:9,encoding:ebcdic"`
+//
+// Positive values and zero are credits and negative values are debits.
+type SIGNEDNUMERIC int64
+
+// MarshalISO8583 returns the sign character followed by the absolute value padded with zeros up to length.
+func (n SIGNEDNUMERIC) MarshalISO8583(length int, enc string) ([]byte, error) {
+	content, err := formatSigned(int64(n), length)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyEncoding([]byte(content), enc)
+}
+
+// UnmarshalISO8583 reads length characters from b, which must be 'C' or 'D' followed by digits.
+func (n *SIGNEDNUMERIC) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	content, read, err := readNumeric(b, length, enc)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(content) < 2 || (content[0] != 'C' && content[0] != 'D') {
+		return 0, fmt.Errorf("signed numeric '%s' must start with C or D followed by digits", content)
+	}
+
+	v, err := parseNumeric(content[1:])
+	if err != nil {
+		return 0, err
+	}
+
+	if content[0] == 'D' {
+		v = -v
+	}
+
+	*n = SIGNEDNUMERIC(v)
+	return read, nil
+}
+
+// String returns the value as it would be marshaled without padding, for example "D100".
+func (n SIGNEDNUMERIC) String() string {
+	if n < 0 {
+		return "D" + strconv.FormatUint(uint64(-n), 10)
+	}
+
+	return "C" + strconv.FormatInt(int64(n), 10)
+}
+
+// Decimal represents the value with the indicated amount of implied decimals, for example
+// SIGNEDNUMERIC(-1050).Decimal(2) returns "-10.50".
+func (n SIGNEDNUMERIC) Decimal(decimals int) string { return formatDecimal(int64(n), decimals) }
+
+// ValidateISO8583 checks that the value fits in length characters, sign included.
+func (n SIGNEDNUMERIC) ValidateISO8583(length int, format string) error {
+	if _, err := formatSigned(int64(n), length); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLength, err)
+	}
+
+	return nil
+}
+
+func formatSigned(v int64, length int) (string, error) {
+	if length < 2 {
+		return "", errors.New("signed numeric length must include the sign and at least one digit")
+	}
+
+	sign, abs := "C", uint64(v)
+	if v < 0 {
+		// Negation is done on unsigned to support the minimum int64.
+		sign, abs = "D", -uint64(v)
+	}
+
+	digits, err := padNumeric(abs, length-1)
+	if err != nil {
+		return "", err
+	}
+
+	return sign + digits, nil
+}
+
+// padNumeric returns v padded with zeros up to length digits.
+func padNumeric(v uint64, length int) (string, error) {
+	content := strconv.FormatUint(v, 10)
+	if len(content) > length {
+		return "", fmt.Errorf("value %v exceeds %v digits", v, length)
+	}
+
+	return strings.Repeat("0", length-len(content)) + content, nil
+}
+
+// readNumeric reads and decodes length digits from b, it returns also the amount of read bytes.
+func readNumeric(b []byte, length int, enc string) (string, int, error) {
+	if b == nil {
+		return "", 0, errors.New("bytes input is nil")
+	}
+
+	content, n, err := decodeCharacters(b, length, enc)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return string(content), n, nil
+}
+
+// parseNumeric parses digits only content, an overflow of int64 is rejected.
+func parseNumeric(content string) (int64, error) {
+	if content == "" {
+		return 0, errors.New("numeric content is empty")
+	}
+
+	for position, r := range content {
+		if !isDigit(r) {
+			return 0, fmt.Errorf("character %q at position %v of '%s' is not a digit", r, position, content)
+		}
+	}
+
+	v, err := strconv.ParseInt(content, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("numeric '%s' overflows int64", content)
+	}
+
+	return v, nil
+}
+
+// formatDecimal represents v with the indicated amount of implied decimals.
+func formatDecimal(v int64, decimals int) string {
+	sign, abs := "", uint64(v)
+	if v < 0 {
+		sign, abs = "-", -uint64(v)
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if decimals <= 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-decimals] + "." + digits[len(digits)-decimals:]
+}
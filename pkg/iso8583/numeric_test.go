@@ -0,0 +1,299 @@
+package iso8583_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+func TestNUMERIC_MarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		V           iso8583.NUMERIC
+		Length      int
+		Encoding    string
+		OutputBytes []byte
+		OutputError string
+	}{
+		{
+			Name:        "padded",
+			V:           1050,
+			Length:      12,
+			Encoding:    "ascii",
+			OutputBytes: []byte("000000001050"),
+		},
+		{
+			Name:        "ebcdic",
+			V:           1,
+			Length:      6,
+			Encoding:    "ebcdic",
+			OutputBytes: []byte{0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF1},
+		},
+		{
+			Name:        "max_int64",
+			V:           math.MaxInt64,
+			Length:      19,
+			Encoding:    "ascii",
+			OutputBytes: []byte("9223372036854775807"),
+		},
+		{
+			Name:        "overflow",
+			V:           1000000,
+			Length:      6,
+			Encoding:    "ascii",
+			OutputError: "value 1000000 exceeds 6 digits",
+		},
+		{
+			Name:        "negative",
+			V:           -1,
+			Length:      6,
+			Encoding:    "ascii",
+			OutputError: "negative value -1 can not be represented by a NUMERIC field",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(fmt.Sprintf("numeric_to_bytes_%s", testCase.Name), func(t *testing.T) {
+			o, err := testCase.V.MarshalISO8583(testCase.Length, testCase.Encoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputBytes, o)
+		})
+	}
+}
+
+func TestNUMERIC_UnmarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		InputBytes  []byte
+		Length      int
+		Encoding    string
+		OutputValue iso8583.NUMERIC
+		OutputError string
+	}{
+		{
+			Name:        "padded",
+			InputBytes:  []byte("000000001050FF"),
+			Length:      12,
+			Encoding:    "ascii",
+			OutputValue: 1050,
+		},
+		{
+			Name:        "ebcdic",
+			InputBytes:  []byte{0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF1},
+			Length:      6,
+			Encoding:    "ebcdic",
+			OutputValue: 1,
+		},
+		{
+			Name:        "non_digit",
+			InputBytes:  []byte("00 1A0"),
+			Length:      6,
+			Encoding:    "ascii",
+			OutputError: "character ' ' at position 2 of '00 1A0' is not a digit",
+		},
+		{
+			Name:        "overflow",
+			InputBytes:  []byte("9223372036854775808"),
+			Length:      19,
+			Encoding:    "ascii",
+			OutputError: "numeric '9223372036854775808' overflows int64",
+		},
+		{
+			Name:        "short",
+			InputBytes:  []byte("0001"),
+			Length:      6,
+			Encoding:    "ascii",
+			OutputError: "message remain (4 bytes) is shorter than indicated length: 6",
+		},
+		{
+			Name:        "nil_input",
+			Length:      6,
+			Encoding:    "ascii",
+			OutputError: "bytes input is nil",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(fmt.Sprintf("bytes_to_numeric_%s", testCase.Name), func(t *testing.T) {
+			var v iso8583.NUMERIC
+
+			n, err := v.UnmarshalISO8583(testCase.InputBytes, testCase.Length, testCase.Encoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.Length, n)
+			assert.Equal(t, testCase.OutputValue, v)
+		})
+	}
+}
+
+func TestSIGNEDNUMERIC_MarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		V           iso8583.SIGNEDNUMERIC
+		Length      int
+		OutputBytes []byte
+		OutputError string
+	}{
+		{Name: "credit", V: 150, Length: 9, OutputBytes: []byte("C00000150")},
+		{Name: "zero", V: 0, Length: 9, OutputBytes: []byte("C00000000")},
+		{Name: "debit", V: -150, Length: 9, OutputBytes: []byte("D00000150")},
+		{Name: "min_int64", V: math.MinInt64, Length: 20, OutputBytes: []byte("D9223372036854775808")},
+		{Name: "overflow", V: -100000000, Length: 9, OutputError: "value 100000000 exceeds 8 digits"},
+		{Name: "no_digits", V: 1, Length: 1,
+			OutputError: "signed numeric length must include the sign and at least one digit"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(fmt.Sprintf("signed_numeric_to_bytes_%s", testCase.Name), func(t *testing.T) {
+			o, err := testCase.V.MarshalISO8583(testCase.Length, "ascii")
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputBytes, o)
+		})
+	}
+}
+
+func TestSIGNEDNUMERIC_UnmarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		InputBytes  []byte
+		OutputValue iso8583.SIGNEDNUMERIC
+		OutputError string
+	}{
+		{Name: "credit", InputBytes: []byte("C00000150"), OutputValue: 150},
+		{Name: "debit", InputBytes: []byte("D00000150"), OutputValue: -150},
+		{Name: "no_sign", InputBytes: []byte("000000150"),
+			OutputError: "signed numeric '000000150' must start with C or D followed by digits"},
+		{Name: "non_digit", InputBytes: []byte("D0000015X"),
+			OutputError: "character 'X' at position 7 of '0000015X' is not a digit"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(fmt.Sprintf("bytes_to_signed_numeric_%s", testCase.Name), func(t *testing.T) {
+			var v iso8583.SIGNEDNUMERIC
+
+			n, err := v.UnmarshalISO8583(testCase.InputBytes, 9, "ascii")
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, 9, n)
+			assert.Equal(t, testCase.OutputValue, v)
+		})
+	}
+}
+
+func TestNUMERIC_Decimal(t *testing.T) {
+	assert.Equal(t, "10.50", iso8583.NUMERIC(1050).Decimal(2))
+	assert.Equal(t, "0.05", iso8583.NUMERIC(5).Decimal(2))
+	assert.Equal(t, "0.000", iso8583.NUMERIC(0).Decimal(3))
+	assert.Equal(t, "1050", iso8583.NUMERIC(1050).Decimal(0))
+	assert.Equal(t, "-10.50", iso8583.SIGNEDNUMERIC(-1050).Decimal(2))
+	assert.Equal(t, "1050", iso8583.NUMERIC(1050).String())
+	assert.Equal(t, "D1050", iso8583.SIGNEDNUMERIC(-1050).String())
+	assert.Equal(t, "C1050", iso8583.SIGNEDNUMERIC(1050).String())
+}
+
+func TestNUMERIC_ValidateISO8583(t *testing.T) {
+	assert.Nil(t, iso8583.NUMERIC(999999).ValidateISO8583(6, "n"))
+	assert.True(t, errors.Is(iso8583.NUMERIC(1000000).ValidateISO8583(6, "n"), iso8583.ErrInvalidLength))
+	assert.True(t, errors.Is(iso8583.NUMERIC(-1).ValidateISO8583(6, "n"), iso8583.ErrInvalidFormat))
+	assert.Nil(t, iso8583.SIGNEDNUMERIC(-99999999).ValidateISO8583(9, "x+n"))
+	assert.True(t, errors.Is(iso8583.SIGNEDNUMERIC(-100000000).ValidateISO8583(9, "x+n"), iso8583.ErrInvalidLength))
+}
+
+func TestNUMERIC_message(t *testing.T) {
+	type message struct {
+		MTI    iso8583.MTI           `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP        `iso8583:"bitmap,length:64"`
+		Amount iso8583.NUMERIC       `iso8583:"4,length:12"`
+		STAN   iso8583.NUMERIC       `iso8583:"11,length:6"`
+		Fee    iso8583.SIGNEDNUMERIC `iso8583:"28,length:9"`
+	}
+
+	input := message{MTI: iso8583.MTI{MTI: "0200"}, Amount: 1050, STAN: 42, Fee: -75}
+
+	b, err := iso8583.Marshal(input)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "000000001050000042D00000075", string(b[12:]))
+
+	var output message
+	if _, err := iso8583.Unmarshal(b, &output); !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, input.Amount, output.Amount)
+	assert.Equal(t, input.STAN, output.STAN)
+	assert.Equal(t, input.Fee, output.Fee)
+}
+
+func TestNUMERIC_pointer_presence(t *testing.T) {
+	type message struct {
+		MTI    iso8583.MTI      `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP   `iso8583:"bitmap,length:64"`
+		Amount *iso8583.NUMERIC `iso8583:"4,length:12,omitempty,required"`
+		STAN   iso8583.NUMERIC  `iso8583:"11,length:6,omitempty"`
+	}
+
+	zero := iso8583.NUMERIC(0)
+	input := message{MTI: iso8583.MTI{MTI: "0200"}, Amount: &zero}
+
+	assert.Nil(t, iso8583.Validate(input))
+
+	b, err := iso8583.Marshal(input)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "000000000000", string(b[12:]))
+
+	var output message
+	if _, err := iso8583.Unmarshal(b, &output); !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	if assert.NotNil(t, output.Amount) {
+		assert.Equal(t, zero, *output.Amount)
+	}
+
+	j, err := iso8583.MarshalJSONMessage(output)
+	if assert.Nil(t, err) {
+		assert.Equal(t, `{"mti":"0200","bitmap":"1000000000000000","4":0}`, string(j))
+	}
+
+	// STAN is omitted as zero values of non pointer fields are absent.
+	b, err = iso8583.Marshal(message{MTI: iso8583.MTI{MTI: "0200"}, Amount: &zero, STAN: 0})
+	if assert.Nil(t, err) {
+		assert.Equal(t, "000000000000", string(b[12:]))
+	}
+
+	assert.Equal(t, []string{"field 4: field is required"},
+		errorMessages(iso8583.Validate(message{MTI: iso8583.MTI{MTI: "0200"}})))
+}
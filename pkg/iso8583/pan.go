@@ -0,0 +1,135 @@
+package iso8583
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PANValidation indicates which checks are done by PAN on marshal and unmarshal.
+type PANValidation int
+
+const (
+	// PANValidationNone does not check the account number.
+	PANValidationNone PANValidation = iota
+	// PANValidationLength checks that the account number has between 12 and 19 digits.
+	PANValidationLength
+	// PANValidationLuhn checks the length and the Luhn check digit.
+	PANValidationLuhn
+)
+
+// PANStrictness is the validation applied by PAN on marshal and unmarshal, ValidateISO8583 always uses
+// PANValidationLuhn. It can be relaxed to handle test cards which do not satisfy the Luhn algorithm.
+var PANStrictness = PANValidationLuhn
+
+// PAN is a LL indicated primary account number (DE2) which checks its length and Luhn check digit,
+// for example:
+// 	PrimaryAccountNumber iso8583.PAN `iso8583:"2,length:2,encoding:ebcdic"`
+//
+// PAN never prints itself unmasked, String and GoString display only the BIN and last 4 digits
+// so it can not leak through fmt verbs. The account number is obtained with a conversion:
+// 	pan := string(msg.PrimaryAccountNumber)
+type PAN string
+
+// MarshalISO8583 checks the account number as indicated by PANStrictness and prepends the LL indicator.
+func (pan PAN) MarshalISO8583(length int, enc string) ([]byte, error) {
+	if err := pan.check(PANStrictness); err != nil {
+		return nil, err
+	}
+
+	return LLVAR(pan).MarshalISO8583(length, enc)
+}
+
+// UnmarshalISO8583 reads the LL indicated account number and checks it as indicated by PANStrictness.
+func (pan *PAN) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	var v LLVAR
+	n, err := v.UnmarshalISO8583(b, length, enc)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := PAN(v).check(PANStrictness); err != nil {
+		return 0, err
+	}
+
+	*pan = PAN(v)
+	return n, nil
+}
+
+// ValidateISO8583 checks the length, digits and Luhn check digit of the account number.
+func (pan PAN) ValidateISO8583(length int, format string) error {
+	return pan.check(PANValidationLuhn)
+}
+
+// BIN returns the bank identification number, the first 6 digits.
+func (pan PAN) BIN() string { return pan.IIN(6) }
+
+// IIN returns the first n digits of the account number, like IIN(8) for the 8 digits issuer identification number.
+func (pan PAN) IIN(n int) string {
+	if n > len(pan) {
+		n = len(pan)
+	}
+
+	return string(pan[:n])
+}
+
+// Last4 returns the last 4 digits of the account number.
+func (pan PAN) Last4() string {
+	if len(pan) < 4 {
+		return string(pan)
+	}
+
+	return string(pan[len(pan)-4:])
+}
+
+// Masked returns the account number masked with the pan policy, for example "540000******0011".
+func (pan PAN) Masked() string { return maskPAN(string(pan)) }
+
+// Raw returns the account number unmasked.
+func (pan PAN) Raw() string { return string(pan) }
+
+// String implements fmt.Stringer returning the masked account number.
+func (pan PAN) String() string { return pan.Masked() }
+
+// GoString implements fmt.GoStringer returning the masked account number.
+func (pan PAN) GoString() string { return fmt.Sprintf("%q", pan.Masked()) }
+
+// MarshalJSON represents the account number unmasked, as JSON messages are used as fixtures.
+func (pan PAN) MarshalJSON() ([]byte, error) { return json.Marshal(string(pan)) }
+
+func (pan PAN) check(validation PANValidation) error {
+	if validation == PANValidationNone {
+		return nil
+	}
+
+	if len(pan) < 12 || len(pan) > 19 {
+		return fmt.Errorf("%w: account number is %v digits long but should be between 12 and 19",
+			ErrInvalidLength, len(pan))
+	}
+
+	if err := checkCharacters("n", string(pan), isDigit); err != nil {
+		return fmt.Errorf("account number: %w", err)
+	}
+
+	if validation == PANValidationLuhn && !luhn(string(pan)) {
+		return fmt.Errorf("%w: account number %s does not satisfy the Luhn check digit", ErrInvalidFormat, pan.Masked())
+	}
+
+	return nil
+}
+
+// luhn checks the check digit of a digits only string.
+func luhn(s string) bool {
+	var sum int
+	for n := 0; n < len(s); n++ {
+		digit := int(s[len(s)-1-n] - '0')
+		if n%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
@@ -0,0 +1,112 @@
+package iso8583_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+func TestPAN_MarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		V           iso8583.PAN
+		Strictness  iso8583.PANValidation
+		OutputBytes []byte
+		OutputError error
+	}{
+		{Name: "valid", V: "4111111111111111", Strictness: iso8583.PANValidationLuhn,
+			OutputBytes: []byte("164111111111111111")},
+		{Name: "invalid_luhn", V: "4111111111111112", Strictness: iso8583.PANValidationLuhn,
+			OutputError: iso8583.ErrInvalidFormat},
+		{Name: "relaxed_luhn", V: "4111111111111112", Strictness: iso8583.PANValidationLength,
+			OutputBytes: []byte("164111111111111112")},
+		{Name: "short", V: "41111111111", Strictness: iso8583.PANValidationLength,
+			OutputError: iso8583.ErrInvalidLength},
+		{Name: "long", V: "41111111111111111111", Strictness: iso8583.PANValidationLength,
+			OutputError: iso8583.ErrInvalidLength},
+		{Name: "non_digits", V: "4111 1111 1111 1111", Strictness: iso8583.PANValidationLength,
+			OutputError: iso8583.ErrInvalidFormat},
+		{Name: "unchecked", V: "4111", Strictness: iso8583.PANValidationNone, OutputBytes: []byte("044111")},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			original := iso8583.PANStrictness
+			iso8583.PANStrictness = testCase.Strictness
+			defer func() { iso8583.PANStrictness = original }()
+
+			o, err := testCase.V.MarshalISO8583(2, "ascii")
+			if testCase.OutputError != nil {
+				assert.True(t, errors.Is(err, testCase.OutputError), err)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputBytes, o)
+		})
+	}
+}
+
+func TestPAN_UnmarshalISO8583(t *testing.T) {
+	var pan iso8583.PAN
+
+	n, err := pan.UnmarshalISO8583([]byte{0xF1, 0xF6, 0xF4, 0xF1, 0xF1, 0xF1, 0xF1, 0xF1, 0xF1, 0xF1, 0xF1, 0xF1,
+		0xF1, 0xF1, 0xF1, 0xF1, 0xF1, 0xF1}, 2, "ebcdic")
+	if assert.Nil(t, err) {
+		assert.Equal(t, 18, n)
+		assert.Equal(t, iso8583.PAN("4111111111111111"), pan)
+	}
+
+	_, err = pan.UnmarshalISO8583([]byte("164111111111111112"), 2, "ascii")
+	assert.EqualError(t, err, "invalid format: account number 411111******1112 does not satisfy the Luhn check digit")
+
+	_, err = pan.UnmarshalISO8583(nil, 2, "ascii")
+	assert.EqualError(t, err, "bytes input is nil")
+}
+
+func TestPAN_components(t *testing.T) {
+	pan := iso8583.PAN("5413330089010079")
+
+	assert.Equal(t, "541333", pan.BIN())
+	assert.Equal(t, "54133300", pan.IIN(8))
+	assert.Equal(t, "5413330089010079", pan.IIN(30))
+	assert.Equal(t, "0079", pan.Last4())
+	assert.Equal(t, "541333******0079", pan.Masked())
+	assert.Equal(t, "123", iso8583.PAN("123").Last4())
+
+	assert.Nil(t, pan.ValidateISO8583(2, ""))
+	assert.True(t, errors.Is(iso8583.PAN("5413330089010078").ValidateISO8583(2, ""), iso8583.ErrInvalidFormat))
+}
+
+func TestPAN_formatting(t *testing.T) {
+	msg := struct {
+		MTI iso8583.MTI `iso8583:"mti,length:4"`
+		PAN iso8583.PAN `iso8583:"2,length:2"`
+	}{MTI: iso8583.MTI{MTI: "0100"}, PAN: "5413330089010079"}
+
+	for _, format := range []string{"%v", "%+v", "%s", "%#v", "%q", "%x"} {
+		o := fmt.Sprintf(format, msg)
+		assert.NotContains(t, o, "5413330089010079", format)
+		assert.NotContains(t, o, fmt.Sprintf("%x", "5413330089010079"), format)
+	}
+
+	assert.Equal(t, "541333******0079", fmt.Sprint(msg.PAN))
+	assert.Equal(t, `"541333******0079"`, fmt.Sprintf("%#v", msg.PAN))
+
+	dump, err := iso8583.Dump(msg)
+	if assert.Nil(t, err) {
+		assert.NotContains(t, dump, "5413330089010079")
+	}
+
+	b, err := json.Marshal(msg.PAN)
+	if assert.Nil(t, err) {
+		assert.Equal(t, `"5413330089010079"`, string(b))
+	}
+}
@@ -0,0 +1,162 @@
+package iso8583
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/jattento/go-iso8583/pkg/mti"
+)
+
+// Presence indicates if a field must be present in a message.
+type Presence int
+
+const (
+	// Optional fields can be present or not.
+	Optional Presence = iota
+	// Mandatory fields must be present.
+	Mandatory
+	// Conditional fields must be present only if the rule condition is satisfied, otherwise they are optional.
+	Conditional
+	// Forbidden fields must not be present.
+	Forbidden
+)
+
+// String returns the presence initial used in scheme specifications: M, C, O or F.
+func (p Presence) String() string {
+	switch p {
+	case Mandatory:
+		return "M"
+	case Conditional:
+		return "C"
+	case Forbidden:
+		return "F"
+	default:
+		return "O"
+	}
+}
+
+// ErrFieldForbidden exported error for asserting.
+var ErrFieldForbidden = errors.New("field is forbidden")
+
+// Condition decides if a conditional field must be present, it receives the checked message.
+type Condition func(msg interface{}) bool
+
+// Rule declares the presence of a field in the messages which MTI matches the pattern.
+// Pattern accepts 'x' as wildcard, for example "x1x0" or "0100", see mti.MTI.Match.
+type Rule struct {
+	MTI       string
+	Field     string
+	Presence  Presence
+	Condition Condition
+}
+
+// Rules is a set of rules, for example:
+// 	iso8583.Rules{
+// 		{MTI: "0100", Field: "4", Presence: iso8583.Mandatory},
+// 		{MTI: "0800", Field: "4", Presence: iso8583.Conditional, Condition: isFinancial},
+// 		{MTI: "x1x0", Field: "39", Presence: iso8583.Mandatory},
+// 	}
+type Rules []Rule
+
+var (
+	rulesMutex    sync.RWMutex
+	rulesRegistry = make(map[reflect.Type]Rules)
+)
+
+// RegisterRules attaches rules to the struct type of v, replacing previously registered rules.
+// Rules of a type are checked by Validate and always before Marshal and after Unmarshal,
+// a nil rules value detaches them.
+func RegisterRules(v interface{}, rules Rules) {
+	t := structType(reflect.TypeOf(v))
+
+	rulesMutex.Lock()
+	defer rulesMutex.Unlock()
+
+	if rules == nil {
+		delete(rulesRegistry, t)
+		return
+	}
+
+	rulesRegistry[t] = rules
+}
+
+// CheckRules checks v against the rules registered for its type and returns every violation,
+// nil is returned if v satisfies all of them. Zero value fields are considered not present.
+// Returned errors are *ValidationError wrapping ErrFieldRequired or ErrFieldForbidden.
+func CheckRules(v interface{}) []error {
+	rules := registeredRules(v)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	fields, err := readMessageFields(v)
+	if err != nil {
+		return []error{fmt.Errorf("iso8583.rules: %w", err)}
+	}
+
+	var messageMTI mti.MTI
+	present := make(map[string]bool)
+	for _, f := range fields {
+		if f.Field == _tagMTI {
+			messageMTI = mti.MTI(displayValue(f.Value))
+		}
+
+		present[f.Field] = !f.Value.IsZero()
+	}
+
+	errs := make([]error, 0)
+	reported := make(map[string]bool)
+	for _, rule := range rules {
+		if reported[rule.Field] || !messageMTI.Match(rule.MTI) {
+			continue
+		}
+
+		var violation error
+		switch {
+		case rule.Presence == Mandatory && !present[rule.Field]:
+			violation = ErrFieldRequired
+		case rule.Presence == Conditional && !present[rule.Field] && rule.Condition != nil && rule.Condition(v):
+			violation = ErrFieldRequired
+		case rule.Presence == Forbidden && present[rule.Field]:
+			violation = ErrFieldForbidden
+		default:
+			continue
+		}
+
+		reported[rule.Field] = true
+		errs = append(errs, &ValidationError{
+			Field: rule.Field,
+			Err:   fmt.Errorf("%w in mti %s (%s rule)", violation, messageMTI, rule.Presence),
+		})
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// registeredRules returns the rules attached to the type of v.
+func registeredRules(v interface{}) Rules {
+	t := structType(reflect.TypeOf(v))
+	if t == nil {
+		return nil
+	}
+
+	rulesMutex.RLock()
+	defer rulesMutex.RUnlock()
+
+	return rulesRegistry[t]
+}
+
+// structType returns the underlying type of pointers.
+func structType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
@@ -0,0 +1,136 @@
+package iso8583_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+type rulesTestMessage struct {
+	MTI      iso8583.MTI    `iso8583:"mti,length:4"`
+	Bitmap   iso8583.BITMAP `iso8583:"bitmap"`
+	Code     iso8583.VAR    `iso8583:"3,length:6,omitempty"`
+	Amount   iso8583.VAR    `iso8583:"4,length:12,omitempty"`
+	Response iso8583.VAR    `iso8583:"39,length:2,omitempty"`
+	Network  iso8583.VAR    `iso8583:"70,length:3,omitempty"`
+}
+
+var rulesTestRules = iso8583.Rules{
+	{MTI: "0100", Field: "4", Presence: iso8583.Mandatory},
+	{MTI: "0800", Field: "4", Presence: iso8583.Conditional, Condition: func(msg interface{}) bool {
+		return msg.(*rulesTestMessage).Code == "200000"
+	}},
+	{MTI: "x110", Field: "39", Presence: iso8583.Mandatory},
+	{MTI: "x100", Field: "39", Presence: iso8583.Forbidden},
+	{MTI: "x110", Field: "70", Presence: iso8583.Forbidden},
+	{MTI: "08xx", Field: "70", Presence: iso8583.Mandatory},
+	{MTI: "xxxx", Field: "3", Presence: iso8583.Optional},
+}
+
+func TestCheckRules(t *testing.T) {
+	iso8583.RegisterRules(rulesTestMessage{}, rulesTestRules)
+	defer iso8583.RegisterRules(rulesTestMessage{}, nil)
+
+	testList := []struct {
+		Name     string
+		Input    *rulesTestMessage
+		Expected []string
+	}{
+		{
+			Name:  "valid_0100",
+			Input: &rulesTestMessage{MTI: iso8583.MTI{MTI: "0100"}, Amount: "000000000100"},
+		},
+		{
+			Name:  "every_violation_reported",
+			Input: &rulesTestMessage{MTI: iso8583.MTI{MTI: "0110"}, Network: "301"},
+			Expected: []string{
+				"field 39: field is required in mti 0110 (M rule)",
+				"field 70: field is forbidden in mti 0110 (F rule)",
+			},
+		},
+		{
+			Name:     "conditional_satisfied",
+			Input:    &rulesTestMessage{MTI: iso8583.MTI{MTI: "0800"}, Code: "200000", Network: "301"},
+			Expected: []string{"field 4: field is required in mti 0800 (C rule)"},
+		},
+		{
+			Name:  "conditional_not_satisfied",
+			Input: &rulesTestMessage{MTI: iso8583.MTI{MTI: "0800"}, Code: "990000", Network: "301"},
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			errs := iso8583.CheckRules(testCase.Input)
+			if len(testCase.Expected) == 0 {
+				assert.Nil(t, errs)
+				return
+			}
+
+			assert.Equal(t, testCase.Expected, errorMessages(errs))
+		})
+	}
+}
+
+func TestCheckRules_errors_are_assertable(t *testing.T) {
+	iso8583.RegisterRules(&rulesTestMessage{}, rulesTestRules)
+	defer iso8583.RegisterRules(rulesTestMessage{}, nil)
+
+	errs := iso8583.CheckRules(&rulesTestMessage{MTI: iso8583.MTI{MTI: "0110"}, Network: "301"})
+	if assert.Len(t, errs, 2) {
+		assert.True(t, errors.Is(errs[0], iso8583.ErrFieldRequired))
+		assert.True(t, errors.Is(errs[1], iso8583.ErrFieldForbidden))
+
+		var validationErr *iso8583.ValidationError
+		assert.True(t, errors.As(errs[1], &validationErr))
+		assert.Equal(t, "70", validationErr.Field)
+	}
+}
+
+func TestCheckRules_without_rules(t *testing.T) {
+	assert.Nil(t, iso8583.CheckRules(&rulesTestMessage{MTI: iso8583.MTI{MTI: "0110"}}))
+	assert.Nil(t, iso8583.CheckRules(nil))
+}
+
+func TestCheckRules_on_marshal_unmarshal_and_validate(t *testing.T) {
+	valid := rulesTestMessage{MTI: iso8583.MTI{MTI: "0110"}, Response: "00"}
+
+	b, err := iso8583.Marshal(valid)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	iso8583.RegisterRules(rulesTestMessage{}, rulesTestRules)
+	defer iso8583.RegisterRules(rulesTestMessage{}, nil)
+
+	_, err = iso8583.Marshal(rulesTestMessage{MTI: iso8583.MTI{MTI: "0100"}})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "iso8583.marshal: validation failed: field 4: field is required in mti 0100 (M rule)",
+			err.Error())
+	}
+
+	// The 0110 message is valid, but once decoded as 0100 the rules are not satisfied.
+	b[2] = '0'
+
+	var output rulesTestMessage
+	_, err = iso8583.Unmarshal(b, &output)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "iso8583.unmarshal: validation failed: field 4: field is required in mti 0100 (M rule); "+
+			"field 39: field is forbidden in mti 0100 (F rule)", err.Error())
+	}
+
+	assert.Equal(t, []string{
+		"field 4: field is required in mti 0100 (M rule)",
+		"field 39: field is forbidden in mti 0100 (F rule)",
+	}, errorMessages(iso8583.Validate(&output)))
+}
+
+func TestPresence_String(t *testing.T) {
+	assert.Equal(t, "O", iso8583.Optional.String())
+	assert.Equal(t, "M", iso8583.Mandatory.String())
+	assert.Equal(t, "C", iso8583.Conditional.String())
+	assert.Equal(t, "F", iso8583.Forbidden.String())
+}
@@ -14,6 +14,24 @@ type tags struct {
 	Disesteem bool
 	Encoding  string
 	Length    int
+	Prefix    int
+	Mask      string
+	Format    string
+	Required  bool
+
+	// TagDigits and LengthDigits are the TLVASCII layout indicated by the layout tag.
+	TagDigits    int
+	LengthDigits int
+
+	// Sub indicates that the field is a subfield of a composite named with the sub tag.
+	Sub bool
+
+	// Min and Max limit the content length of the field, zero means no limit.
+	Min int
+	Max int
+
+	// RequiredMTI contains the MTI patterns for which the field is required, empty means always.
+	RequiredMTI []string
 }
 
 const _tagBITMAP = "bitmap"
@@ -74,11 +92,87 @@ func readTags(tag reflect.StructTag) (tags, error) {
 			continue
 		}
 
+		if strings.HasPrefix(tagBlock, "prefix") && len(strings.Split(tagBlock, ":")) == 2 {
+			p, err := strconv.Atoi(strings.TrimPrefix(tagBlock, "prefix:"))
+			if err != nil {
+				returnErr = fmt.Errorf("invalid prefix: %w", err)
+			}
+
+			output.Prefix = p
+
+			continue
+		}
+
+		if strings.HasPrefix(tagBlock, "min") && len(strings.Split(tagBlock, ":")) == 2 {
+			m, err := strconv.Atoi(strings.TrimPrefix(tagBlock, "min:"))
+			if err != nil {
+				returnErr = fmt.Errorf("invalid min: %w", err)
+			}
+
+			output.Min = m
+
+			continue
+		}
+
+		if strings.HasPrefix(tagBlock, "max") && len(strings.Split(tagBlock, ":")) == 2 {
+			m, err := strconv.Atoi(strings.TrimPrefix(tagBlock, "max:"))
+			if err != nil {
+				returnErr = fmt.Errorf("invalid max: %w", err)
+			}
+
+			output.Max = m
+
+			continue
+		}
+
+		if strings.HasPrefix(tagBlock, "layout") && len(strings.Split(tagBlock, ":")) == 2 {
+			tagDigits, lengthDigits := ReadSplitEncodings(strings.TrimPrefix(tagBlock, "layout:"))
+
+			var err error
+			if output.TagDigits, err = strconv.Atoi(tagDigits); err != nil {
+				returnErr = fmt.Errorf("invalid layout: %w", err)
+			}
+
+			if output.LengthDigits, err = strconv.Atoi(lengthDigits); err != nil {
+				returnErr = fmt.Errorf("invalid layout: %w", err)
+			}
+
+			continue
+		}
+
+		// Subfields of composites are named by position, for example: `iso8583:"sub:1,length:23"`
+		if strings.HasPrefix(tagBlock, "sub") && len(strings.Split(tagBlock, ":")) == 2 {
+			output.Field = strings.TrimPrefix(tagBlock, "sub:")
+			output.Sub = true
+			continue
+		}
+
 		if strings.HasPrefix(tagBlock, "encoding") && len(strings.Split(tagBlock, ":")) == 2 {
 			output.Encoding = strings.TrimPrefix(tagBlock, "encoding:")
 			continue
 		}
 
+		if strings.HasPrefix(tagBlock, "mask") && len(strings.Split(tagBlock, ":")) == 2 {
+			output.Mask = strings.TrimPrefix(tagBlock, "mask:")
+			continue
+		}
+
+		if strings.HasPrefix(tagBlock, "format") && len(strings.Split(tagBlock, ":")) == 2 {
+			output.Format = strings.TrimPrefix(tagBlock, "format:")
+			continue
+		}
+
+		if tagBlock == "required" {
+			output.Required = true
+			continue
+		}
+
+		if strings.HasPrefix(tagBlock, "required") && len(strings.Split(tagBlock, ":")) == 2 {
+			output.Required = true
+			output.RequiredMTI = strings.Split(strings.TrimPrefix(tagBlock, "required:"), "/")
+			continue
+		}
+
 		output.Field = tagBlock
 	}
 
@@ -86,6 +180,10 @@ func readTags(tag reflect.StructTag) (tags, error) {
 		return output, errors.New("exported struct field contains ISO8583 tag but no field name")
 	}
 
+	if returnErr == nil && output.Max != 0 && output.Min > output.Max {
+		returnErr = fmt.Errorf("min %v is greater than max %v", output.Min, output.Max)
+	}
+
 	if returnErr != nil {
 		return output, fmt.Errorf("field %s: %w", output.Field, returnErr)
 	}
@@ -0,0 +1,137 @@
+package iso8583
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jattento/go-iso8583/pkg/emv"
+)
+
+// _formatEMV is the format of BER-TLV chip data validated against emv.Dictionary.
+const _formatEMV = "emv"
+
+// TLV wrapps the emv.List type to be used as a LLL indicated field with BER-TLV content,
+// like the EMV chip data (DE55). Encoding tag only applies to the LLL indicator, with split encodings
+// the first one is used, for example:
+// 	ICC iso8583.TLV `iso8583:"55,length:3,encoding:ascii"`
+//
+// Tags are accessed with Get and Set, for example:
+// 	cryptogram, found := msg.ICC.Get("9F26")
+// 	err := msg.ICC.Set("9F02", []byte{0x00, 0x00, 0x00, 0x00, 0x10, 0x00})
+type TLV struct {
+	emv.List
+}
+
+// MarshalISO8583 builds the BER-TLV content preserving the objects order and prepends the LLL indicator.
+func (tlv TLV) MarshalISO8583(length int, enc string) ([]byte, error) {
+	content, err := tlv.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("tlv: %w", err)
+	}
+
+	lllEncoding, _ := ReadSplitEncodings(enc)
+	return LengthMarshal(3, content, lllEncoding)
+}
+
+// UnmarshalISO8583 reads the LLL indicated amount of bytes from b and parses them as BER-TLV.
+func (tlv *TLV) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	if b == nil {
+		return 0, errors.New("bytes input is nil")
+	}
+
+	lllEncoding, _ := ReadSplitEncodings(enc)
+
+	n, content, err := LengthUnmarshal(3, b, length, lllEncoding)
+	if err != nil {
+		return 0, err
+	}
+
+	list, err := emv.Decode(content)
+	if err != nil {
+		return 0, fmt.Errorf("tlv: %w", err)
+	}
+
+	tlv.List = list
+	return n, nil
+}
+
+// String returns the BER-TLV content in upper case hexadecimal, as done for binary fields.
+func (tlv TLV) String() string {
+	content, err := tlv.Encode()
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%X", content)
+}
+
+// MarshalJSON represents the BER-TLV content in upper case hexadecimal.
+func (tlv TLV) MarshalJSON() ([]byte, error) {
+	content, err := tlv.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalHexJSON(content)
+}
+
+// UnmarshalJSON reads the BER-TLV content from hexadecimal.
+func (tlv *TLV) UnmarshalJSON(data []byte) error {
+	content, err := unmarshalHexJSON(data)
+	if err != nil {
+		return err
+	}
+
+	list, err := emv.Decode(content)
+	if err != nil {
+		return err
+	}
+
+	tlv.List = list
+	return nil
+}
+
+// ValidateISO8583 checks that the content can be encoded and fits in the LLL indicator,
+// only 'b' and 'emv' formats are allowed. With 'emv' format objects must satisfy its emv.Dictionary definitions.
+func (tlv TLV) ValidateISO8583(length int, format string) error {
+	if format == _formatEMV {
+		if err := validateChipList(tlv.List); err != nil {
+			return err
+		}
+	} else if err := checkBinaryFormat(format); err != nil {
+		return err
+	}
+
+	content, err := tlv.Encode()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+
+	return checkMaxLength(len(content), 3)
+}
+
+// validateChipData checks that b is BER-TLV content which satisfies the emv.Dictionary definitions.
+func validateChipData(b []byte) error {
+	list, err := emv.Decode(b)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+
+	return validateChipList(list)
+}
+
+// validateChipList checks the objects of l against its emv.Dictionary definitions.
+func validateChipList(l emv.List) error {
+	errs := emv.Validate(l)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return fmt.Errorf("%w: %s", ErrInvalidFormat, strings.Join(messages, ", "))
+}
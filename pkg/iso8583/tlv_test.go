@@ -0,0 +1,172 @@
+package iso8583_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/emv"
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+func TestTLV_MarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		V           iso8583.TLV
+		Encoding    string
+		OutputBytes []byte
+		OutputError string
+	}{
+		{
+			Name: "ascii",
+			V: iso8583.TLV{List: emv.List{
+				{Tag: "9F26", Value: []byte{0x11, 0x22}},
+				{Tag: "5F2A", Value: []byte{0x09, 0x78}},
+			}},
+			Encoding:    "ascii",
+			OutputBytes: []byte{'0', '1', '0', 0x9F, 0x26, 0x02, 0x11, 0x22, 0x5F, 0x2A, 0x02, 0x09, 0x78},
+		},
+		{
+			Name:        "ebcdic",
+			V:           iso8583.TLV{List: emv.List{{Tag: "82", Value: []byte{0x01}}}},
+			Encoding:    "ebcdic",
+			OutputBytes: []byte{0xF0, 0xF0, 0xF3, 0x82, 0x01, 0x01},
+		},
+		{
+			Name:        "split_encoding",
+			V:           iso8583.TLV{List: emv.List{{Tag: "82", Value: []byte{0x01}}}},
+			Encoding:    "ebcdic/ascii",
+			OutputBytes: []byte{0xF0, 0xF0, 0xF3, 0x82, 0x01, 0x01},
+		},
+		{
+			Name:        "invalid_tag",
+			V:           iso8583.TLV{List: emv.List{{Tag: "9F", Value: []byte{0x01}}}},
+			Encoding:    "ascii",
+			OutputError: "tlv: invalid tag: '9F' is not a valid BER-TLV tag",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			o, err := testCase.V.MarshalISO8583(3, testCase.Encoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputBytes, o)
+		})
+	}
+}
+
+func TestTLV_UnmarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		InputBytes  []byte
+		Encoding    string
+		OutputList  emv.List
+		OutputN     int
+		OutputError string
+	}{
+		{
+			Name:       "ascii",
+			InputBytes: []byte{'0', '0', '5', 0x9F, 0x36, 0x02, 0x00, 0x05, 0xFF},
+			Encoding:   "ascii",
+			OutputList: emv.List{{Tag: "9F36", Value: []byte{0x00, 0x05}}},
+			OutputN:    8,
+		},
+		{
+			Name:        "invalid_content",
+			InputBytes:  []byte{'0', '0', '3', 0x9F, 0x36, 0x02},
+			Encoding:    "ascii",
+			OutputError: "tlv: offset 0: tag 9F36: truncated data object: value should be 2 bytes long but only 0 bytes are available",
+		},
+		{
+			Name:        "nil",
+			InputBytes:  nil,
+			OutputError: "bytes input is nil",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var tlv iso8583.TLV
+			n, err := tlv.UnmarshalISO8583(testCase.InputBytes, 3, testCase.Encoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputN, n)
+			assert.Equal(t, testCase.OutputList, tlv.List)
+		})
+	}
+}
+
+func TestTLV_split_encoding_round_trip(t *testing.T) {
+	v := iso8583.TLV{List: emv.List{
+		{Tag: "9F26", Value: []byte{0x11, 0x22}},
+		{Tag: "5F2A", Value: []byte{0x09, 0x78}},
+	}}
+
+	for _, enc := range []string{"ebcdic/ascii", "ascii/ebcdic"} {
+		t.Run(enc, func(t *testing.T) {
+			b, err := v.MarshalISO8583(3, enc)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			var tlv iso8583.TLV
+			n, err := tlv.UnmarshalISO8583(b, 3, enc)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, len(b), n)
+			assert.Equal(t, v.List, tlv.List)
+		})
+	}
+}
+
+func TestTLV_Message(t *testing.T) {
+	type message struct {
+		MTI    iso8583.MTI    `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP `iso8583:"bitmap"`
+		ICC    iso8583.TLV    `iso8583:"55,length:3"`
+	}
+
+	input := message{MTI: iso8583.MTI{MTI: "0100"}}
+	assert.Nil(t, input.ICC.Set("9F26", []byte{0x01, 0x02}))
+	assert.Nil(t, input.ICC.Set("9F02", []byte{0x00, 0x00, 0x00, 0x00, 0x10, 0x00}))
+
+	b, err := iso8583.Marshal(input)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	var output message
+	_, err = iso8583.Unmarshal(b, &output)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	amount, found := output.ICC.Get("9F02")
+	assert.True(t, found)
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x00, 0x10, 0x00}, amount)
+	assert.Equal(t, input.ICC, output.ICC)
+
+	j, err := json.Marshal(output.ICC)
+	assert.Nil(t, err)
+	assert.Equal(t, `"9F260201029F0206000000001000"`, string(j))
+
+	var fromJSON iso8583.TLV
+	assert.Nil(t, json.Unmarshal(j, &fromJSON))
+	assert.Equal(t, output.ICC, fromJSON)
+	assert.Equal(t, "9F260201029F0206000000001000", fromJSON.String())
+}
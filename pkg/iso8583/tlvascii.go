@@ -0,0 +1,365 @@
+package iso8583
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// _defaultTLVASCIIDigits is the amount of tag and length digits assumed if they are not indicated.
+const _defaultTLVASCIIDigits = 2
+
+// JSON keys of the TLVASCII layout.
+const (
+	_jsonTagDigits    = "tagDigits"
+	_jsonLengthDigits = "lengthDigits"
+)
+
+// TLVASCII is a field which content is a sequence of elements represented by tag, length and value,
+// where tag and length are decimal digits with a fixed width. For example with the MasterCard DE48 subelements
+// layout (2 digits tag and 2 digits length) "2203ABC" contains the subelement 22 with value "ABC".
+//
+// TagDigits and LengthDigits indicate the width of tags and lengths, if zero 2 is assumed.
+// Other layouts are indicated by the layout tag, tag digits and length digits separated by a slash,
+// which is applied by Marshal, Unmarshal, Validate and UnmarshalJSONMessage, for example for the IPM PDS:
+// 	PDS iso8583.TLVASCII `iso8583:"48,length:3,layout:4/3"`
+//
+// Values built without those functions must set TagDigits and LengthDigits before calling Set:
+// 	msg.PDS = iso8583.TLVASCII{TagDigits: 4, LengthDigits: 3}
+// 	err := msg.PDS.Set("0023", "POI")
+//
+// Lengths count characters, not bytes, as the length indicators of other fields.
+//
+// Length tag indicates the amount of bytes of a LL or LLL indicator that wraps the content,
+// if zero all available bytes are read, which allows using it as the last subfield of a composite:
+// 	type AdditionalData struct {
+// 		TransactionCategoryCode iso8583.VAR      `iso8583:"sub:1,length:1"`
+// 		Subelements             iso8583.TLVASCII `iso8583:"sub:2"`
+// 	}
+//
+// 	AdditionalData AdditionalData `iso8583:"48,prefix:3,encoding:ebcdic"`
+//
+// Elements keep its order, they are accessed with Get, Set, Delete and Tags.
+// For different encoding of length indicator and content separate both with a slash.
+type TLVASCII struct {
+	TagDigits    int
+	LengthDigits int
+
+	elements []tlvASCIIElement
+}
+
+type tlvASCIIElement struct {
+	tag   string
+	value string
+}
+
+// withTagLayout sets the layout of the layout tag to i if it is a TLVASCII or a pointer to one,
+// pointed values are modified so unmarshaled values keep the layout. Other values are returned as received.
+func withTagLayout(i interface{}, tag tags) interface{} {
+	if tag.TagDigits == 0 && tag.LengthDigits == 0 {
+		return i
+	}
+
+	switch t := i.(type) {
+	case TLVASCII:
+		t.TagDigits, t.LengthDigits = tag.TagDigits, tag.LengthDigits
+		return t
+	case *TLVASCII:
+		if t != nil {
+			t.TagDigits, t.LengthDigits = tag.TagDigits, tag.LengthDigits
+		}
+	}
+
+	return i
+}
+
+// Get returns the value of the indicated tag, the returned bool indicates if the tag is present.
+func (t TLVASCII) Get(tag string) (string, bool) {
+	for _, element := range t.elements {
+		if element.tag == tag {
+			return element.value, true
+		}
+	}
+
+	return "", false
+}
+
+// Set replaces the value of the indicated tag keeping its position, if the tag is not present
+// the element is appended. The tag must contain TagDigits digits.
+func (t *TLVASCII) Set(tag string, value string) error {
+	if err := t.checkTag(tag); err != nil {
+		return err
+	}
+
+	for n := range t.elements {
+		if t.elements[n].tag == tag {
+			t.elements[n].value = value
+			return nil
+		}
+	}
+
+	t.elements = append(t.elements, tlvASCIIElement{tag: tag, value: value})
+	return nil
+}
+
+// Delete removes the indicated tag.
+func (t *TLVASCII) Delete(tag string) {
+	for n := range t.elements {
+		if t.elements[n].tag == tag {
+			t.elements = append(t.elements[:n], t.elements[n+1:]...)
+			return
+		}
+	}
+}
+
+// Tags returns the present tags in order.
+func (t TLVASCII) Tags() []string {
+	tags := make([]string, 0, len(t.elements))
+	for _, element := range t.elements {
+		tags = append(tags, element.tag)
+	}
+
+	return tags
+}
+
+// Len returns the amount of elements.
+func (t TLVASCII) Len() int { return len(t.elements) }
+
+// MarshalISO8583 builds the elements in order and if length is not zero prepends the LL or LLL indicator.
+func (t TLVASCII) MarshalISO8583(length int, enc string) ([]byte, error) {
+	content, err := t.build()
+	if err != nil {
+		return nil, err
+	}
+
+	if length == 0 {
+		_, contentEncoding := ReadSplitEncodings(enc)
+		return applyEncoding([]byte(content), contentEncoding)
+	}
+
+	return marshalLengthVar(length, content, enc)
+}
+
+// UnmarshalISO8583 parses the elements of the LL or LLL indicated content, if length is zero all bytes are read.
+// TagDigits and LengthDigits of t are kept.
+func (t *TLVASCII) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	if b == nil {
+		return 0, errors.New("bytes input is nil")
+	}
+
+	var n int
+	var content string
+	if length != 0 {
+		var err error
+		if n, content, err = unmarshalLengthVar(length, b, length, enc); err != nil {
+			return 0, err
+		}
+	} else {
+		_, contentEncoding := ReadSplitEncodings(enc)
+
+		decoded, err := applyDecoding(b, contentEncoding)
+		if err != nil {
+			return 0, err
+		}
+		n, content = len(b), string(decoded)
+	}
+
+	elements, err := t.parse(content)
+	if err != nil {
+		return 0, err
+	}
+
+	t.elements = elements
+	return n, nil
+}
+
+// String returns the elements as they are represented in the message.
+func (t TLVASCII) String() string {
+	content, err := t.build()
+	if err != nil {
+		return ""
+	}
+
+	return content
+}
+
+// MarshalJSON represents the elements as a JSON object keyed by tag, in order.
+// Layouts other than the default one are indicated by the "tagDigits" and "lengthDigits" keys, which can not
+// collide with numeric tags, for example:
+// 	{"tagDigits":4,"lengthDigits":3,"0023":"ABC"}
+func (t TLVASCII) MarshalJSON() ([]byte, error) {
+	keys := make([]string, 0, len(t.elements)+2)
+	values := make([]json.RawMessage, 0, len(t.elements)+2)
+	if t.tagDigits() != _defaultTLVASCIIDigits || t.lengthDigits() != _defaultTLVASCIIDigits {
+		keys = append(keys, _jsonTagDigits, _jsonLengthDigits)
+		values = append(values, json.RawMessage(strconv.Itoa(t.tagDigits())),
+			json.RawMessage(strconv.Itoa(t.lengthDigits())))
+	}
+
+	for _, element := range t.elements {
+		// Strings can not fail to be marshaled.
+		value, _ := json.Marshal(element.value)
+
+		keys = append(keys, element.tag)
+		values = append(values, value)
+	}
+
+	return encodeJSONObject(keys, values), nil
+}
+
+// UnmarshalJSON reads the elements from a JSON object keyed by tag keeping its order.
+// The layout is read from the "tagDigits" and "lengthDigits" keys, if they are not present
+// TagDigits and LengthDigits of t are kept.
+func (t *TLVASCII) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return errors.New("tlv ascii elements must be a JSON object")
+	}
+
+	layout := TLVASCII{TagDigits: t.TagDigits, LengthDigits: t.LengthDigits}
+	var elements []tlvASCIIElement
+	for decoder.More() {
+		// Keys are always strings.
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case _jsonTagDigits:
+			err = decoder.Decode(&layout.TagDigits)
+		case _jsonLengthDigits:
+			err = decoder.Decode(&layout.LengthDigits)
+		default:
+			element := tlvASCIIElement{tag: token.(string)}
+			err = decoder.Decode(&element.value)
+			elements = append(elements, element)
+		}
+
+		if err != nil {
+			return fmt.Errorf("tag %v: %w", token, err)
+		}
+	}
+
+	// Elements are set once the layout is known, as layout keys could be found after them.
+	for _, element := range elements {
+		if err := layout.Set(element.tag, element.value); err != nil {
+			return err
+		}
+	}
+
+	*t = layout
+	return nil
+}
+
+// ValidateISO8583 checks that every value fits in the length digits and satisfies the format.
+func (t TLVASCII) ValidateISO8583(length int, format string) error {
+	for _, element := range t.elements {
+		if err := t.checkTag(element.tag); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+
+		if err := checkMaxLength(utf8.RuneCountInString(element.value), t.lengthDigits()); err != nil {
+			return fmt.Errorf("tag %s: %w", element.tag, err)
+		}
+
+		if err := ValidateFormat(format, element.value); err != nil {
+			return fmt.Errorf("tag %s: %w", element.tag, err)
+		}
+	}
+
+	if length == 0 {
+		return nil
+	}
+
+	// Element lengths were already checked so content can be built.
+	content, _ := t.build()
+
+	return checkMaxLength(utf8.RuneCountInString(content), length)
+}
+
+func (t TLVASCII) build() (string, error) {
+	var content strings.Builder
+	for _, element := range t.elements {
+		l := strconv.Itoa(utf8.RuneCountInString(element.value))
+		if len(l) > t.lengthDigits() {
+			return "", fmt.Errorf("tag %s: value length exceeded the %s limit",
+				element.tag, strings.Repeat("9", t.lengthDigits()))
+		}
+
+		content.WriteString(element.tag)
+		content.WriteString(strings.Repeat("0", t.lengthDigits()-len(l)) + l)
+		content.WriteString(element.value)
+	}
+
+	return content.String(), nil
+}
+
+func (t TLVASCII) parse(content string) ([]tlvASCIIElement, error) {
+	elements := make([]tlvASCIIElement, 0)
+
+	// Positions and lengths are expressed in characters.
+	r := []rune(content)
+	for offset := 0; offset < len(r); {
+		header := t.tagDigits() + t.lengthDigits()
+		if len(r)-offset < header {
+			return nil, fmt.Errorf("element at position %v is truncated: '%s'", offset, string(r[offset:]))
+		}
+
+		tag := string(r[offset : offset+t.tagDigits()])
+		if err := t.checkTag(tag); err != nil {
+			return nil, fmt.Errorf("element at position %v: %w", offset, err)
+		}
+
+		lengthDigits := string(r[offset+t.tagDigits() : offset+header])
+		l, err := strconv.Atoi(lengthDigits)
+		if err != nil || l < 0 {
+			return nil, fmt.Errorf("tag %s: length is not a valid integer: '%s'", tag, lengthDigits)
+		}
+
+		if len(r)-offset-header < l {
+			return nil, fmt.Errorf("tag %s: value should be %v long but only %v characters are available",
+				tag, l, len(r)-offset-header)
+		}
+
+		elements = append(elements, tlvASCIIElement{tag: tag, value: string(r[offset+header : offset+header+l])})
+		offset += header + l
+	}
+
+	return elements, nil
+}
+
+func (t TLVASCII) checkTag(tag string) error {
+	if len(tag) != t.tagDigits() {
+		return fmt.Errorf("tag '%s' should be %v digits long", tag, t.tagDigits())
+	}
+
+	for _, r := range tag {
+		if !isDigit(r) {
+			return fmt.Errorf("tag '%s' is not numeric", tag)
+		}
+	}
+
+	return nil
+}
+
+func (t TLVASCII) tagDigits() int {
+	if t.TagDigits == 0 {
+		return _defaultTLVASCIIDigits
+	}
+
+	return t.TagDigits
+}
+
+func (t TLVASCII) lengthDigits() int {
+	if t.LengthDigits == 0 {
+		return _defaultTLVASCIIDigits
+	}
+
+	return t.LengthDigits
+}
@@ -0,0 +1,389 @@
+package iso8583_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+	"github.com/jattento/go-iso8583/pkg/template"
+)
+
+func newTLVASCII(t *testing.T, layout iso8583.TLVASCII, pairs ...string) iso8583.TLVASCII {
+	for n := 0; n+1 < len(pairs); n += 2 {
+		if !assert.Nil(t, layout.Set(pairs[n], pairs[n+1])) {
+			t.FailNow()
+		}
+	}
+
+	return layout
+}
+
+func TestTLVASCII_MarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		V           iso8583.TLVASCII
+		Length      int
+		Encoding    string
+		OutputBytes []byte
+		OutputError string
+	}{
+		{
+			Name:        "subelements",
+			V:           newTLVASCII(t, iso8583.TLVASCII{}, "61", "00000840", "22", "ABC"),
+			Length:      3,
+			Encoding:    "ascii",
+			OutputBytes: []byte("0196108000008402203ABC"),
+		},
+		{
+			Name:        "pds",
+			V:           newTLVASCII(t, iso8583.TLVASCII{TagDigits: 4, LengthDigits: 3}, "0023", "POI"),
+			Length:      3,
+			Encoding:    "ascii",
+			OutputBytes: []byte("0100023003POI"),
+		},
+		{
+			Name:        "without_indicator",
+			V:           newTLVASCII(t, iso8583.TLVASCII{}, "10", "1"),
+			Encoding:    "ascii",
+			OutputBytes: []byte("10011"),
+		},
+		{
+			Name:        "ebcdic",
+			V:           newTLVASCII(t, iso8583.TLVASCII{}, "10", "1"),
+			Length:      2,
+			Encoding:    "ebcdic",
+			OutputBytes: []byte{0xF0, 0xF5, 0xF1, 0xF0, 0xF0, 0xF1, 0xF1},
+		},
+		{
+			Name:        "split_encoding",
+			V:           newTLVASCII(t, iso8583.TLVASCII{}, "10", "1"),
+			Length:      2,
+			Encoding:    "ascii/ebcdic",
+			OutputBytes: []byte{'0', '5', 0xF1, 0xF0, 0xF0, 0xF1, 0xF1},
+		},
+		{
+			Name:        "empty",
+			Length:      3,
+			Encoding:    "ascii",
+			OutputBytes: []byte("000"),
+		},
+		{
+			Name:        "value_too_long",
+			V:           newTLVASCII(t, iso8583.TLVASCII{LengthDigits: 1}, "10", "1234567890"),
+			Encoding:    "ascii",
+			OutputError: "tag 10: value length exceeded the 9 limit",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			o, err := testCase.V.MarshalISO8583(testCase.Length, testCase.Encoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputBytes, o)
+		})
+	}
+}
+
+func TestTLVASCII_UnmarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		Layout      iso8583.TLVASCII
+		InputBytes  []byte
+		Length      int
+		Encoding    string
+		OutputTags  []string
+		OutputN     int
+		OutputError string
+	}{
+		{
+			Name:       "subelements",
+			InputBytes: []byte("0196108000008402203ABCFF"),
+			Length:     3,
+			Encoding:   "ascii",
+			OutputTags: []string{"61", "22"},
+			OutputN:    22,
+		},
+		{
+			Name:       "pds",
+			Layout:     iso8583.TLVASCII{TagDigits: 4, LengthDigits: 3},
+			InputBytes: []byte("0100023003POI"),
+			Length:     3,
+			Encoding:   "ascii",
+			OutputTags: []string{"0023"},
+			OutputN:    13,
+		},
+		{
+			Name:       "without_indicator",
+			InputBytes: []byte("100112002AB"),
+			Encoding:   "ascii",
+			OutputTags: []string{"10", "20"},
+			OutputN:    11,
+		},
+		{
+			Name:       "ebcdic",
+			InputBytes: []byte{0xF0, 0xF5, 0xF1, 0xF0, 0xF0, 0xF1, 0xF1},
+			Length:     2,
+			Encoding:   "ebcdic",
+			OutputTags: []string{"10"},
+			OutputN:    7,
+		},
+		{
+			Name:        "truncated_header",
+			InputBytes:  []byte("100111"),
+			Encoding:    "ascii",
+			OutputError: "element at position 5 is truncated: '1'",
+		},
+		{
+			Name:        "truncated_value",
+			InputBytes:  []byte("1005AB"),
+			Encoding:    "ascii",
+			OutputError: "tag 10: value should be 5 long but only 2 characters are available",
+		},
+		{
+			Name:        "invalid_tag",
+			InputBytes:  []byte("A1011"),
+			Encoding:    "ascii",
+			OutputError: "element at position 0: tag 'A1' is not numeric",
+		},
+		{
+			Name:        "invalid_length",
+			InputBytes:  []byte("10X11"),
+			Encoding:    "ascii",
+			OutputError: "tag 10: length is not a valid integer: 'X1'",
+		},
+		{
+			Name:        "nil_input",
+			Encoding:    "ascii",
+			OutputError: "bytes input is nil",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			v := testCase.Layout
+			n, err := v.UnmarshalISO8583(testCase.InputBytes, testCase.Length, testCase.Encoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputN, n)
+			assert.Equal(t, testCase.OutputTags, v.Tags())
+			assert.Equal(t, testCase.Layout.TagDigits, v.TagDigits)
+			assert.Equal(t, testCase.Layout.LengthDigits, v.LengthDigits)
+		})
+	}
+}
+
+func TestTLVASCII_elements(t *testing.T) {
+	v := newTLVASCII(t, iso8583.TLVASCII{}, "61", "00000840", "22", "ABC", "10", "1")
+
+	value, found := v.Get("22")
+	assert.True(t, found)
+	assert.Equal(t, "ABC", value)
+
+	_, found = v.Get("99")
+	assert.False(t, found)
+
+	assert.Nil(t, v.Set("22", "XY"))
+	assert.Equal(t, []string{"61", "22", "10"}, v.Tags())
+	assert.Equal(t, "6108000008402202XY10011", v.String())
+
+	v.Delete("61")
+	v.Delete("99")
+	assert.Equal(t, []string{"22", "10"}, v.Tags())
+	assert.Equal(t, 2, v.Len())
+
+	assert.EqualError(t, v.Set("1", "X"), "tag '1' should be 2 digits long")
+	assert.EqualError(t, v.Set("AB", "X"), "tag 'AB' is not numeric")
+}
+
+func TestTLVASCII_JSON(t *testing.T) {
+	v := newTLVASCII(t, iso8583.TLVASCII{}, "61", "00000840", "22", "ABC")
+
+	b, err := json.Marshal(v)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, `{"61":"00000840","22":"ABC"}`, string(b))
+
+	replayed := iso8583.TLVASCII{}
+	if !assert.Nil(t, json.Unmarshal(b, &replayed)) {
+		t.FailNow()
+	}
+	assert.Equal(t, v.Tags(), replayed.Tags())
+	assert.Equal(t, v.String(), replayed.String())
+
+	assert.EqualError(t, json.Unmarshal([]byte(`["61"]`), &replayed), "tlv ascii elements must be a JSON object")
+	assert.EqualError(t, json.Unmarshal([]byte(`{"6":"X"}`), &replayed), "tag '6' should be 2 digits long")
+
+	pds := newTLVASCII(t, iso8583.TLVASCII{TagDigits: 4, LengthDigits: 3}, "0023", "ABC", "0158", "X")
+
+	b, err = json.Marshal(pds)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, `{"tagDigits":4,"lengthDigits":3,"0023":"ABC","0158":"X"}`, string(b))
+
+	replayed = iso8583.TLVASCII{}
+	if !assert.Nil(t, json.Unmarshal(b, &replayed)) {
+		t.FailNow()
+	}
+	assert.Equal(t, 4, replayed.TagDigits)
+	assert.Equal(t, 3, replayed.LengthDigits)
+	assert.Equal(t, pds.String(), replayed.String())
+
+	// Layout keys can be found after the elements.
+	replayed = iso8583.TLVASCII{}
+	if assert.Nil(t, json.Unmarshal([]byte(`{"0023":"ABC","tagDigits":4,"lengthDigits":3}`), &replayed)) {
+		assert.Equal(t, "0023003ABC", replayed.String())
+	}
+
+	assert.EqualError(t, json.Unmarshal([]byte(`{"tagDigits":"4"}`), &replayed),
+		"tag tagDigits: json: cannot unmarshal string into Go value of type int")
+}
+
+func TestTLVASCII_ValidateISO8583(t *testing.T) {
+	assert.Nil(t, newTLVASCII(t, iso8583.TLVASCII{}, "61", "00000840").ValidateISO8583(3, "n"))
+
+	err := newTLVASCII(t, iso8583.TLVASCII{}, "22", "ABC").ValidateISO8583(3, "n")
+	assert.True(t, errors.Is(err, iso8583.ErrInvalidFormat))
+
+	err = newTLVASCII(t, iso8583.TLVASCII{LengthDigits: 1}, "22", "1234567890").ValidateISO8583(3, "")
+	assert.True(t, errors.Is(err, iso8583.ErrInvalidLength))
+
+	err = newTLVASCII(t, iso8583.TLVASCII{}, "22", "1234567890").ValidateISO8583(1, "")
+	assert.True(t, errors.Is(err, iso8583.ErrInvalidLength))
+}
+
+func TestTLVASCII_mastercard_additional_data(t *testing.T) {
+	msg := template.MasterCardISO87{
+		MessageTypeIdentifier: iso8583.MTI{MTI: "0100"},
+		ProcessingCode:        "000000",
+	}
+	msg.AdditionalDataPrivateUse.TransactionCategoryCode = "R"
+	if !assert.Nil(t, msg.AdditionalDataPrivateUse.Subelements.Set("61", "00000840")) {
+		t.FailNow()
+	}
+
+	b, err := iso8583.Marshal(msg)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	var output template.MasterCardISO87
+	if _, err := iso8583.Unmarshal(b, &output); !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, iso8583.VAR("R"), output.AdditionalDataPrivateUse.TransactionCategoryCode)
+	value, found := output.AdditionalDataPrivateUse.Subelements.Get("61")
+	assert.True(t, found)
+	assert.Equal(t, "00000840", value)
+
+	fixture, err := iso8583.MarshalJSONMessage(msg)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	var replayed template.MasterCardISO87
+	if !assert.Nil(t, iso8583.UnmarshalJSONMessage(fixture, &replayed)) {
+		t.FailNow()
+	}
+
+	o, err := iso8583.Marshal(replayed)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, b, o)
+}
+
+type tlvASCIILayoutMessage struct {
+	MTI    iso8583.MTI      `iso8583:"mti,length:4"`
+	Bitmap iso8583.BITMAP   `iso8583:"bitmap"`
+	PDS    iso8583.TLVASCII `iso8583:"48,length:3,layout:4/3,encoding:ascii/ebcdic"`
+}
+
+func TestTLVASCII_layout_tag(t *testing.T) {
+	msg := tlvASCIILayoutMessage{
+		MTI: iso8583.MTI{MTI: "1240"},
+		PDS: iso8583.TLVASCII{TagDigits: 4, LengthDigits: 3},
+	}
+	if !assert.Nil(t, msg.PDS.Set("0023", "POI")) {
+		t.FailNow()
+	}
+
+	b, err := iso8583.Marshal(msg)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, []byte{'0', '1', '0', 0xF0, 0xF0, 0xF2, 0xF3, 0xF0, 0xF0, 0xF3, 0xD7, 0xD6, 0xC9}, b[12:])
+
+	// Fresh values receive the layout from the tag.
+	var output tlvASCIILayoutMessage
+	if _, err := iso8583.Unmarshal(b, &output); !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	value, found := output.PDS.Get("0023")
+	assert.True(t, found)
+	assert.Equal(t, "POI", value)
+
+	differences, err := iso8583.Diff(b, b, tlvASCIILayoutMessage{})
+	assert.Nil(t, err)
+	assert.Empty(t, differences)
+
+	var replayed tlvASCIILayoutMessage
+	if !assert.Nil(t, iso8583.UnmarshalJSONMessage([]byte(`{"mti":"1240","48":{"0023":"POI"}}`), &replayed)) {
+		t.FailNow()
+	}
+	o, err := iso8583.Marshal(replayed)
+	assert.Nil(t, err)
+	assert.Equal(t, b, o)
+}
+
+func TestTLVASCII_invalid_layout_tag(t *testing.T) {
+	msg := struct {
+		MTI    iso8583.MTI      `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP   `iso8583:"bitmap"`
+		PDS    iso8583.TLVASCII `iso8583:"48,length:3,layout:T/3"`
+	}{MTI: iso8583.MTI{MTI: "1240"}}
+
+	_, err := iso8583.Marshal(msg)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, `iso8583.marshal: field 48: invalid layout: strconv.Atoi: parsing "T": invalid syntax`, err.Error())
+	}
+}
+
+func TestTLVASCII_multibyte_characters(t *testing.T) {
+	v := newTLVASCII(t, iso8583.TLVASCII{}, "01", "CAFÉ")
+
+	b, err := v.MarshalISO8583(2, "ascii/ebcdic")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, []byte{'0', '8', 0xF0, 0xF1, 0xF0, 0xF4, 0xC3, 0xC1, 0xC6, 0x71}, b)
+
+	var output iso8583.TLVASCII
+	n, err := output.UnmarshalISO8583(b, 2, "ascii/ebcdic")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, len(b), n)
+	value, _ := output.Get("01")
+	assert.Equal(t, "CAFÉ", value)
+
+	assert.Nil(t, v.ValidateISO8583(2, ""))
+}
@@ -0,0 +1,286 @@
+package iso8583
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Track2 is a LL indicated field with the track 2 data (DE35) split in its components,
+// start and end sentinels and LRC are not included. For example:
+// 	Track2Data iso8583.Track2 `iso8583:"35,length:2,encoding:ebcdic"`
+//
+// With the 'bcd' encoding the LL indicator is the amount of digits, the separator is the 'D' nibble,
+// so unmarshaled tracks have the 'D' separator, and odd tracks are padded with a 'F' nibble, for example:
+// 	Track2Data iso8583.Track2 `iso8583:"35,length:1,encoding:bcd"`
+// 	Track2Data iso8583.Track2 `iso8583:"35,length:2,encoding:ascii/bcd"`
+//
+// Unmarshal only requires the separator, use ValidateISO8583 to check the complete structure.
+// As PAN, String and GoString are masked so the track can not leak through fmt verbs.
+type Track2 struct {
+	PAN           string
+	Expiration    string
+	ServiceCode   string
+	Discretionary string
+
+	// Separator is '=' or 'D', if empty '=' is used.
+	Separator string
+}
+
+// ParseTrack2 splits track 2 data in its components, sentinels are ignored if present.
+func ParseTrack2(s string) (Track2, error) {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, ";"), "?")
+
+	end := strings.IndexAny(s, "=D")
+	if end < 0 {
+		return Track2{}, fmt.Errorf("track 2 of %v characters does not contain a '=' or 'D' separator", len(s))
+	}
+
+	track := Track2{PAN: s[:end], Separator: s[end : end+1]}
+	track.Expiration, track.ServiceCode, track.Discretionary = splitTrackData(s[end+1:])
+
+	return track, nil
+}
+
+// Raw rebuilds the track from its components.
+func (t Track2) Raw() string {
+	separator := t.Separator
+	if separator == "" {
+		separator = "="
+	}
+
+	return t.PAN + separator + t.Expiration + t.ServiceCode + t.Discretionary
+}
+
+// String implements fmt.Stringer returning the track masked with the track policy, use Raw to obtain the track.
+func (t Track2) String() string { return maskTrack(t.Raw()) }
+
+// GoString implements fmt.GoStringer returning the masked track.
+func (t Track2) GoString() string { return fmt.Sprintf("%q", t.String()) }
+
+// MarshalISO8583 rebuilds the track and prepends the LL indicator.
+func (t Track2) MarshalISO8583(length int, enc string) ([]byte, error) {
+	return marshalLengthVar(2, t.Raw(), enc)
+}
+
+// UnmarshalISO8583 reads the LL indicated track and splits it in its components.
+func (t *Track2) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	n, s, err := unmarshalLengthVar(2, b, length, enc)
+	if err != nil {
+		return 0, err
+	}
+
+	track, err := ParseTrack2(s)
+	if err != nil {
+		return 0, err
+	}
+
+	*t = track
+	return n, nil
+}
+
+// MarshalJSON represents the track as it is represented in the message.
+func (t Track2) MarshalJSON() ([]byte, error) { return json.Marshal(t.Raw()) }
+
+// UnmarshalJSON reads the track as it is represented in the message.
+func (t *Track2) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	track, err := ParseTrack2(s)
+	if err != nil {
+		return err
+	}
+
+	*t = track
+	return nil
+}
+
+// ValidateISO8583 checks the track structure: an account number of up to 19 digits, a '=' or 'D' separator,
+// the expiration date as YYMM, a 3 digits service code and numeric discretionary data, up to 37 characters.
+func (t Track2) ValidateISO8583(length int, format string) error {
+	if t.Separator != "" && t.Separator != "=" && t.Separator != "D" {
+		return fmt.Errorf("%w: track 2 separator '%s' must be '=' or 'D'", ErrInvalidFormat, t.Separator)
+	}
+
+	if err := validateTrackData(t.PAN, t.Expiration, t.ServiceCode); err != nil {
+		return err
+	}
+
+	if err := checkCharacters("n", t.Discretionary, isDigit); err != nil {
+		return fmt.Errorf("discretionary data: %w", err)
+	}
+
+	if l := len(t.Raw()); l > 37 {
+		return fmt.Errorf("%w: track 2 is %v characters long but up to 37 are allowed", ErrInvalidLength, l)
+	}
+
+	return nil
+}
+
+// Track1 is a LL indicated field with the track 1 data (DE45) split in its components,
+// start and end sentinels and LRC are not included. For example:
+// 	Track1Data iso8583.Track1 `iso8583:"45,length:2,encoding:ebcdic"`
+//
+// Unmarshal only requires the format code and both '^' separators, use ValidateISO8583 to check the complete structure.
+// As PAN, String and GoString are masked so the track can not leak through fmt verbs.
+type Track1 struct {
+	// FormatCode is 'B' for payment cards, if empty 'B' is used.
+	FormatCode    string
+	PAN           string
+	Name          string
+	Expiration    string
+	ServiceCode   string
+	Discretionary string
+}
+
+// ParseTrack1 splits track 1 data in its components, sentinels are ignored if present.
+func ParseTrack1(s string) (Track1, error) {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "%"), "?")
+
+	parts := strings.SplitN(s, "^", 3)
+	if len(parts) != 3 || len(parts[0]) < 1 {
+		return Track1{}, fmt.Errorf("track 1 of %v characters must contain a format code and two '^' separators",
+			len(s))
+	}
+
+	track := Track1{FormatCode: parts[0][:1], PAN: parts[0][1:], Name: parts[1]}
+	track.Expiration, track.ServiceCode, track.Discretionary = splitTrackData(parts[2])
+
+	return track, nil
+}
+
+// Raw rebuilds the track from its components.
+func (t Track1) Raw() string {
+	formatCode := t.FormatCode
+	if formatCode == "" {
+		formatCode = "B"
+	}
+
+	return formatCode + t.PAN + "^" + t.Name + "^" + t.Expiration + t.ServiceCode + t.Discretionary
+}
+
+// String implements fmt.Stringer returning the track masked with the track policy, use Raw to obtain the track.
+func (t Track1) String() string { return maskTrack(t.Raw()) }
+
+// GoString implements fmt.GoStringer returning the masked track.
+func (t Track1) GoString() string { return fmt.Sprintf("%q", t.String()) }
+
+// MarshalISO8583 rebuilds the track and prepends the LL indicator.
+func (t Track1) MarshalISO8583(length int, enc string) ([]byte, error) {
+	return marshalLengthVar(2, t.Raw(), enc)
+}
+
+// UnmarshalISO8583 reads the LL indicated track and splits it in its components.
+func (t *Track1) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
+	var s LLVAR
+	n, err := s.UnmarshalISO8583(b, length, enc)
+	if err != nil {
+		return 0, err
+	}
+
+	track, err := ParseTrack1(string(s))
+	if err != nil {
+		return 0, err
+	}
+
+	*t = track
+	return n, nil
+}
+
+// MarshalJSON represents the track as it is represented in the message.
+func (t Track1) MarshalJSON() ([]byte, error) { return json.Marshal(t.Raw()) }
+
+// UnmarshalJSON reads the track as it is represented in the message.
+func (t *Track1) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	track, err := ParseTrack1(s)
+	if err != nil {
+		return err
+	}
+
+	*t = track
+	return nil
+}
+
+// ValidateISO8583 checks the track structure: 'B' format code, an account number of up to 19 digits,
+// a name of 2 to 26 characters, the expiration date as YYMM, a 3 digits service code and discretionary data,
+// up to 76 characters.
+func (t Track1) ValidateISO8583(length int, format string) error {
+	if t.FormatCode != "" && t.FormatCode != "B" {
+		return fmt.Errorf("%w: track 1 format code '%s' must be 'B'", ErrInvalidFormat, t.FormatCode)
+	}
+
+	if err := validateTrackData(t.PAN, t.Expiration, t.ServiceCode); err != nil {
+		return err
+	}
+
+	if len(t.Name) < 2 || len(t.Name) > 26 {
+		return fmt.Errorf("%w: name is %v characters long but should be between 2 and 26",
+			ErrInvalidLength, len(t.Name))
+	}
+
+	if strings.Contains(t.Name+t.Discretionary, "^") {
+		return fmt.Errorf("%w: name and discretionary data can not contain '^'", ErrInvalidFormat)
+	}
+
+	if l := len(t.Raw()); l > 76 {
+		return fmt.Errorf("%w: track 1 is %v characters long but up to 76 are allowed", ErrInvalidLength, l)
+	}
+
+	return nil
+}
+
+// splitTrackData splits the data after the account number separator in expiration date, service code
+// and discretionary data.
+func splitTrackData(s string) (string, string, string) {
+	expiration := s
+	if len(expiration) > 4 {
+		expiration = s[:4]
+	}
+	s = s[len(expiration):]
+
+	serviceCode := s
+	if len(serviceCode) > 3 {
+		serviceCode = s[:3]
+	}
+
+	return expiration, serviceCode, s[len(serviceCode):]
+}
+
+func validateTrackData(pan string, expiration string, serviceCode string) error {
+	if len(pan) < 1 || len(pan) > 19 {
+		return fmt.Errorf("%w: account number is %v digits long but should be between 1 and 19",
+			ErrInvalidLength, len(pan))
+	}
+
+	if err := checkCharacters("n", pan, isDigit); err != nil {
+		return fmt.Errorf("account number: %w", err)
+	}
+
+	if len(expiration) != 4 {
+		return fmt.Errorf("%w: expiration date is %v digits long but should be 4", ErrInvalidLength, len(expiration))
+	}
+
+	if month, err := strconv.Atoi(expiration[2:]); err != nil || month < 1 || month > 12 ||
+		checkCharacters("n", expiration, isDigit) != nil {
+		return fmt.Errorf("%w: expiration date is not a valid YYMM date", ErrInvalidFormat)
+	}
+
+	if len(serviceCode) != 3 {
+		return fmt.Errorf("%w: service code is %v digits long but should be 3", ErrInvalidLength, len(serviceCode))
+	}
+
+	if err := checkCharacters("n", serviceCode, isDigit); err != nil {
+		return fmt.Errorf("service code: %w", err)
+	}
+
+	return nil
+}
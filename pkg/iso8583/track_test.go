@@ -0,0 +1,317 @@
+package iso8583_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/bitmap"
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+var testTrack2 = iso8583.Track2{PAN: "5400000000000011", Expiration: "2512", ServiceCode: "101",
+	Discretionary: "0000012300000"}
+
+func TestTrack2_MarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		V           iso8583.Track2
+		Length      int
+		Encoding    string
+		OutputBytes []byte
+		OutputError string
+	}{
+		{
+			Name:        "ascii",
+			V:           testTrack2,
+			Length:      2,
+			Encoding:    "ascii",
+			OutputBytes: []byte("375400000000000011=25121010000012300000"),
+		},
+		{
+			Name:        "d_separator",
+			V:           iso8583.Track2{PAN: "4000", Separator: "D", Expiration: "2512", ServiceCode: "201"},
+			Length:      2,
+			Encoding:    "ebcdic",
+			OutputBytes: []byte{0xF1, 0xF2, 0xF4, 0xF0, 0xF0, 0xF0, 0xC4, 0xF2, 0xF5, 0xF1, 0xF2, 0xF2, 0xF0, 0xF1},
+		},
+		{
+			Name:        "bcd",
+			V:           iso8583.Track2{PAN: "4000", Expiration: "2512", ServiceCode: "201"},
+			Length:      1,
+			Encoding:    "bcd",
+			OutputBytes: []byte{0x12, 0x40, 0x00, 0xD2, 0x51, 0x22, 0x01},
+		},
+		{
+			Name:        "bcd_odd_ascii_indicator",
+			V:           iso8583.Track2{PAN: "4000", Expiration: "2512", ServiceCode: "201", Discretionary: "1"},
+			Length:      2,
+			Encoding:    "ascii/bcd",
+			OutputBytes: []byte{'1', '3', 0x40, 0x00, 0xD2, 0x51, 0x22, 0x01, 0x1F},
+		},
+		{
+			Name:        "bcd_invalid_character",
+			V:           iso8583.Track2{PAN: "40A0", Expiration: "2512", ServiceCode: "201"},
+			Length:      1,
+			Encoding:    "bcd",
+			OutputError: "encoder 'bcd' returned error: character 'A' at position 2 can not be packed in a nibble",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			o, err := testCase.V.MarshalISO8583(testCase.Length, testCase.Encoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputBytes, o)
+		})
+	}
+}
+
+func TestTrack2_UnmarshalISO8583(t *testing.T) {
+	testList := []struct {
+		Name        string
+		InputBytes  []byte
+		Length      int
+		Encoding    string
+		OutputTrack iso8583.Track2
+		OutputN     int
+		OutputError string
+	}{
+		{
+			Name:       "ascii",
+			InputBytes: []byte("375400000000000011=25121010000012300000FF"),
+			Length:     2,
+			Encoding:   "ascii",
+			OutputTrack: iso8583.Track2{PAN: "5400000000000011", Separator: "=", Expiration: "2512", ServiceCode: "101",
+				Discretionary: "0000012300000"},
+			OutputN: 39,
+		},
+		{
+			Name:        "sentinels",
+			InputBytes:  []byte("14;4000D2512201?"),
+			Length:      2,
+			Encoding:    "ascii",
+			OutputTrack: iso8583.Track2{PAN: "4000", Separator: "D", Expiration: "2512", ServiceCode: "201"},
+			OutputN:     16,
+		},
+		{
+			Name:       "bcd",
+			InputBytes: []byte{0x13, 0x40, 0x00, 0xD2, 0x51, 0x22, 0x01, 0x1F, 0xFF},
+			Length:     1,
+			Encoding:   "bcd",
+			OutputTrack: iso8583.Track2{PAN: "4000", Separator: "D", Expiration: "2512", ServiceCode: "201",
+				Discretionary: "1"},
+			OutputN: 8,
+		},
+		{
+			Name:        "short_expiration",
+			InputBytes:  []byte("074000=25"),
+			Length:      2,
+			Encoding:    "ascii",
+			OutputTrack: iso8583.Track2{PAN: "4000", Separator: "=", Expiration: "25"},
+			OutputN:     9,
+		},
+		{
+			Name:        "without_separator",
+			InputBytes:  []byte("044000"),
+			Length:      2,
+			Encoding:    "ascii",
+			OutputError: "track 2 of 4 characters does not contain a '=' or 'D' separator",
+		},
+		{
+			Name:        "bcd_short",
+			InputBytes:  []byte{0x13, 0x40, 0x00},
+			Length:      1,
+			Encoding:    "bcd",
+			OutputError: "message remain (2 bytes) is shorter than LL indicated length (7)",
+		},
+		{
+			Name:        "bcd_negative_indicator",
+			InputBytes:  []byte{'-', '1', 0x40, 0x00},
+			Length:      2,
+			Encoding:    "ascii/bcd",
+			OutputError: "obtained LL after decoding is a negative length: -1",
+		},
+		{
+			Name:        "bcd_indicator_exceeds_remain",
+			InputBytes:  []byte{'0', '5', 0x40, 0xD0},
+			Length:      2,
+			Encoding:    "ascii/bcd",
+			OutputError: "message remain (2 bytes) is shorter than LL indicated length (3)",
+		},
+		{
+			Name:        "nil_input",
+			Length:      2,
+			Encoding:    "ascii",
+			OutputError: "bytes input is nil",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var v iso8583.Track2
+			n, err := v.UnmarshalISO8583(testCase.InputBytes, testCase.Length, testCase.Encoding)
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.OutputN, n)
+			assert.Equal(t, testCase.OutputTrack, v)
+		})
+	}
+}
+
+func TestTrack2_ValidateISO8583(t *testing.T) {
+	assert.Nil(t, testTrack2.ValidateISO8583(2, ""))
+
+	testList := []struct {
+		Name        string
+		V           iso8583.Track2
+		OutputError error
+	}{
+		{Name: "long_pan", V: iso8583.Track2{PAN: "12345678901234567890", Expiration: "2512", ServiceCode: "101"},
+			OutputError: iso8583.ErrInvalidLength},
+		{Name: "non_numeric_pan", V: iso8583.Track2{PAN: "40A0", Expiration: "2512", ServiceCode: "101"},
+			OutputError: iso8583.ErrInvalidFormat},
+		{Name: "invalid_month", V: iso8583.Track2{PAN: "4000", Expiration: "2513", ServiceCode: "101"},
+			OutputError: iso8583.ErrInvalidFormat},
+		{Name: "missing_service_code", V: iso8583.Track2{PAN: "4000", Expiration: "2512"},
+			OutputError: iso8583.ErrInvalidLength},
+		{Name: "invalid_separator", V: iso8583.Track2{PAN: "4000", Separator: "^", Expiration: "2512", ServiceCode: "101"},
+			OutputError: iso8583.ErrInvalidFormat},
+		{Name: "non_numeric_discretionary", V: iso8583.Track2{PAN: "4000", Expiration: "2512", ServiceCode: "101",
+			Discretionary: "12X"}, OutputError: iso8583.ErrInvalidFormat},
+		{Name: "too_long", V: iso8583.Track2{PAN: "5400000000000011", Expiration: "2512", ServiceCode: "101",
+			Discretionary: "00000123000000000"}, OutputError: iso8583.ErrInvalidLength},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := testCase.V.ValidateISO8583(2, "")
+			assert.True(t, errors.Is(err, testCase.OutputError), err)
+		})
+	}
+}
+
+func TestTrack1(t *testing.T) {
+	track, err := iso8583.ParseTrack1("%B5400000000000011^DOE/JOHN^2512101000000123?")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, iso8583.Track1{FormatCode: "B", PAN: "5400000000000011", Name: "DOE/JOHN", Expiration: "2512",
+		ServiceCode: "101", Discretionary: "000000123"}, track)
+	assert.Nil(t, track.ValidateISO8583(2, ""))
+
+	o, err := track.MarshalISO8583(2, "ascii")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, []byte("43B5400000000000011^DOE/JOHN^2512101000000123"), o)
+
+	var v iso8583.Track1
+	n, err := v.UnmarshalISO8583(o, 2, "ascii")
+	if assert.Nil(t, err) {
+		assert.Equal(t, 45, n)
+		assert.Equal(t, track, v)
+	}
+
+	_, err = iso8583.ParseTrack1("B5400000000000011^DOE/JOHN")
+	assert.EqualError(t, err, "track 1 of 26 characters must contain a format code and two '^' separators")
+
+	assert.True(t, errors.Is(iso8583.Track1{FormatCode: "A", PAN: "4000", Name: "DOE", Expiration: "2512",
+		ServiceCode: "101"}.ValidateISO8583(2, ""), iso8583.ErrInvalidFormat))
+	assert.True(t, errors.Is(iso8583.Track1{PAN: "4000", Name: "D", Expiration: "2512",
+		ServiceCode: "101"}.ValidateISO8583(2, ""), iso8583.ErrInvalidLength))
+}
+
+func TestTrack_JSON(t *testing.T) {
+	b, err := json.Marshal(testTrack2)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, `"5400000000000011=25121010000012300000"`, string(b))
+
+	var v iso8583.Track2
+	if assert.Nil(t, json.Unmarshal(b, &v)) {
+		assert.Equal(t, testTrack2.Raw(), v.Raw())
+	}
+
+	var track1 iso8583.Track1
+	assert.Nil(t, json.Unmarshal([]byte(`"B4000^DOE/JOHN^2512101"`), &track1))
+	assert.Equal(t, "DOE/JOHN", track1.Name)
+
+	assert.EqualError(t, json.Unmarshal([]byte(`"4000"`), &v), "track 2 of 4 characters does not contain a '=' or 'D' separator")
+}
+
+func TestTrack2_mask(t *testing.T) {
+	masked, err := iso8583.MaskValue("track", testTrack2.Raw())
+	if assert.Nil(t, err) {
+		assert.Equal(t, "540000******0011=********************", masked)
+	}
+
+	assert.Equal(t, "5400000000000011=25121010000012300000", testTrack2.Raw())
+	assert.Equal(t, masked, testTrack2.String())
+	assert.Equal(t, masked, fmt.Sprintf("%v", testTrack2))
+	assert.Equal(t, `"`+masked+`"`, fmt.Sprintf("%#v", testTrack2))
+}
+
+func TestTrack1_mask(t *testing.T) {
+	track := iso8583.Track1{FormatCode: "B", PAN: "4000000000000002", Name: "DOE/JOHN", Expiration: "2512",
+		ServiceCode: "101"}
+
+	assert.Equal(t, "B4000000000000002^DOE/JOHN^2512101", track.Raw())
+	for _, display := range []string{track.String(), fmt.Sprintf("%v", track), fmt.Sprintf("%#v", track)} {
+		assert.NotContains(t, display, "4000000000000002")
+		assert.NotContains(t, display, "DOE/JOHN")
+	}
+}
+
+func TestTrack_errors_do_not_leak(t *testing.T) {
+	type message struct {
+		MTI    iso8583.MTI    `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP `iso8583:"bitmap"`
+		Track2 iso8583.Track2 `iso8583:"35,length:2"`
+		Track1 iso8583.Track1 `iso8583:"45,length:2"`
+	}
+
+	const pan = "4111111111111111"
+
+	header := append([]byte("0100"), bitmap.ToBytes(map[int]bool{35: true, 64: false})...)
+	_, err := iso8583.Unmarshal(append(header, []byte("16"+pan)...), &message{})
+	if assert.NotNil(t, err) {
+		assert.EqualError(t, err, "iso8583.unmarshal: cant unmarshal field 35: "+
+			"track 2 of 16 characters does not contain a '=' or 'D' separator")
+	}
+
+	header = append([]byte("0100"), bitmap.ToBytes(map[int]bool{45: true, 64: false})...)
+	_, err = iso8583.Unmarshal(append(header, []byte("18B"+pan+"^")...), &message{})
+	if assert.NotNil(t, err) {
+		assert.EqualError(t, err, "iso8583.unmarshal: cant unmarshal field 45: "+
+			"track 1 of 18 characters must contain a format code and two '^' separators")
+	}
+
+	for _, err := range []error{
+		iso8583.Track2{PAN: pan, Expiration: "2513", ServiceCode: "101"}.ValidateISO8583(2, ""),
+		iso8583.Track2{PAN: pan, Expiration: "251", ServiceCode: "101"}.ValidateISO8583(2, ""),
+		iso8583.Track2{PAN: pan, Expiration: "2512", ServiceCode: "1010"}.ValidateISO8583(2, ""),
+	} {
+		if assert.NotNil(t, err) {
+			assert.NotContains(t, err.Error(), pan)
+			assert.NotContains(t, err.Error(), "25")
+		}
+	}
+}
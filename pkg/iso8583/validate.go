@@ -0,0 +1,294 @@
+package iso8583
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jattento/go-iso8583/pkg/mti"
+)
+
+// Validator interface for iso8583 fields.
+// ValidateISO8583 receives the length and format tags, the format must be checked using ValidateFormat.
+// Fields that do not implement Validator only get its format checked against its string representation.
+type Validator interface {
+	ValidateISO8583(length int, format string) error
+}
+
+// ValidateOnMarshal indicates if Marshal must run Validate before marshaling.
+var ValidateOnMarshal = false
+
+// ValidateOnUnmarshal indicates if Unmarshal must run Validate after unmarshaling.
+var ValidateOnUnmarshal = false
+
+var (
+	// ErrFieldRequired exported error for asserting.
+	ErrFieldRequired = errors.New("field is required")
+	// ErrInvalidFormat exported error for asserting.
+	ErrInvalidFormat = errors.New("invalid format")
+	// ErrInvalidLength exported error for asserting.
+	ErrInvalidLength = errors.New("invalid length")
+)
+
+// ValidationError represents a field that does not satisfy its tags.
+type ValidationError struct {
+	Field string
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("field %s: %s", e.Field, e.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// ValidationErrors is returned by Marshal and Unmarshal when validation is enabled and fails.
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Validate checks all fields of v against its tags and returns every found error,
+// nil is returned if v is valid.
+// - format: checked with ValidateFormat or by the Validator implementation of the field.
+// - length: checked by the Validator implementation of the field, for example VAR must be exactly
+// length characters long.
+// - min and max: the content length of present fields must be between both limits.
+// - required: zero value fields are reported if the MTI matches some of the indicated patterns.
+// - rules: the rules registered for the type of v are checked, see RegisterRules.
+//
+// Subfields of composites are checked with its own tags and reported by its position, for example "43.1".
+//
+// Returned errors are *ValidationError, except if v is not a struct.
+func Validate(v interface{}) []error {
+	fields, err := readMessageFields(v)
+	if err != nil {
+		return []error{fmt.Errorf("iso8583.validate: %w", err)}
+	}
+
+	var messageMTI mti.MTI
+	for _, f := range fields {
+		if f.Field == _tagMTI {
+			messageMTI = mti.MTI(displayValue(f.Value))
+		}
+	}
+
+	errs := validateFields(fields, messageMTI, "")
+
+	// Rules violations are reported only for fields without tag errors.
+	for _, ruleErr := range CheckRules(v) {
+		var validationErr *ValidationError
+		if errors.As(ruleErr, &validationErr) && hasFieldError(errs, validationErr.Field) {
+			continue
+		}
+
+		errs = append(errs, ruleErr)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// validateMessage runs Validate if full is true, otherwise only the registered rules are checked.
+func validateMessage(v interface{}, full bool) []error {
+	if full {
+		return Validate(v)
+	}
+
+	return CheckRules(v)
+}
+
+func hasFieldError(errs []error, field string) bool {
+	for _, err := range errs {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) && validationErr.Field == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateFields checks the tags of fields, subfields of composites are checked recursively and
+// reported with its parent name as prefix, for example "43.1".
+func validateFields(fields []messageField, messageMTI mti.MTI, prefix string) []error {
+	errs := make([]error, 0)
+	for _, f := range fields {
+		name := prefix + f.Field
+
+		if f.Value.IsZero() {
+			if isRequired(f.tags, messageMTI) {
+				errs = append(errs, &ValidationError{Field: name, Err: ErrFieldRequired})
+			}
+			continue
+		}
+
+		if isComposite(f.Value) {
+			subfields, err := readSubfields(f.Value)
+			if err != nil {
+				errs = append(errs, &ValidationError{Field: name, Err: err})
+				continue
+			}
+
+			errs = append(errs, validateFields(subfields, messageMTI, name+".")...)
+			continue
+		}
+
+		if err := validateField(f); err != nil {
+			errs = append(errs, &ValidationError{Field: name, Err: err})
+		}
+	}
+
+	return errs
+}
+
+func validateField(f messageField) error {
+	var err error
+	if validator, isValidator := withTagLayout(f.Value.Interface(), f.tags).(Validator); isValidator {
+		err = validator.ValidateISO8583(f.Length, f.Format)
+	} else {
+		err = ValidateFormat(f.Format, rawValue(f.Value))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return checkMinMaxLength(contentLength(f.Value), f.Min, f.Max)
+}
+
+func isRequired(tag tags, messageMTI mti.MTI) bool {
+	if !tag.Required {
+		return false
+	}
+
+	if len(tag.RequiredMTI) == 0 {
+		return true
+	}
+
+	for _, pattern := range tag.RequiredMTI {
+		if messageMTI.Match(pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateFormat checks that s satisfies the ISO-8583 data element attribute.
+// An empty format accepts any content.
+// - a: alphabetic characters and spaces.
+// - n: numeric digits.
+// - s: special characters, any printable character that is not alphanumeric, space included.
+// - an, as, ns, ans: combinations of the above.
+// - b: binary data represented in hexadecimal.
+// - z: track 2 and 3 code set, digits, ':', ';', '<', '=', '>', '?' and 'D' separator.
+// - x+n: 'C' (credit) or 'D' (debit) followed by numeric digits.
+func ValidateFormat(format string, s string) error {
+	switch format {
+	case "":
+		return nil
+	case "b":
+		return checkCharacters(format, s, isHexDigit)
+	case "z":
+		return checkCharacters(format, s, func(r rune) bool {
+			return isDigit(r) || strings.ContainsRune(":;<=>?D", r)
+		})
+	case "x+n":
+		if len(s) < 2 || (s[0] != 'C' && s[0] != 'D') {
+			return fmt.Errorf("%w: '%s' must start with C or D followed by digits", ErrInvalidFormat, s)
+		}
+		return checkCharacters(format, s[1:], isDigit)
+	}
+
+	var classes []func(rune) bool
+	for _, class := range format {
+		switch class {
+		case 'a':
+			classes = append(classes, isAlphabetic)
+		case 'n':
+			classes = append(classes, isDigit)
+		case 's':
+			classes = append(classes, isSpecial)
+		default:
+			return fmt.Errorf("format '%s' does not exist", format)
+		}
+	}
+
+	return checkCharacters(format, s, func(r rune) bool {
+		for _, class := range classes {
+			if class(r) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func checkCharacters(format string, s string, valid func(rune) bool) error {
+	for n, r := range s {
+		if !valid(r) {
+			return fmt.Errorf("%w: character %q at position %v is not allowed by format '%s'",
+				ErrInvalidFormat, r, n, format)
+		}
+	}
+
+	return nil
+}
+
+// checkLength validates fixed length fields, a zero length is not checked.
+func checkLength(l int, length int) error {
+	if length != 0 && l != length {
+		return fmt.Errorf("%w: content is %v long but should be %v", ErrInvalidLength, l, length)
+	}
+
+	return nil
+}
+
+// checkMinMaxLength validates the content length against the min and max tags, zero means no limit.
+func checkMinMaxLength(l int, min int, max int) error {
+	if l < min {
+		return fmt.Errorf("%w: content is %v long but at least %v are required", ErrInvalidLength, l, min)
+	}
+
+	if max != 0 && l > max {
+		return fmt.Errorf("%w: content is %v long but up to %v are allowed", ErrInvalidLength, l, max)
+	}
+
+	return nil
+}
+
+// checkMaxLength validates variable length fields against the limit of the length indicator.
+func checkMaxLength(l int, digits int) error {
+	max := 1
+	for n := 0; n < digits; n++ {
+		max *= 10
+	}
+
+	if l > max-1 {
+		return fmt.Errorf("%w: content is %v long but %s allows up to %v",
+			ErrInvalidLength, l, strings.Repeat("L", digits), max-1)
+	}
+
+	return nil
+}
+
+func isDigit(r rune) bool { return r >= '0' && r <= '9' }
+
+func isAlphabetic(r rune) bool { return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == ' ' }
+
+func isSpecial(r rune) bool {
+	return r == ' ' || (r > ' ' && r <= '~' && !isDigit(r) && !isAlphabetic(r))
+}
+
+func isHexDigit(r rune) bool { return isDigit(r) || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F') }
@@ -0,0 +1,252 @@
+package iso8583_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/emv"
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+)
+
+func TestValidateFormat(t *testing.T) {
+	testList := []struct {
+		Format      string
+		Input       string
+		OutputError string
+	}{
+		{Format: "", Input: "anything^"},
+		{Format: "n", Input: "0123456789"},
+		{Format: "n", Input: "12a4", OutputError: "invalid format: character 'a' at position 2 is not allowed by format 'n'"},
+		{Format: "a", Input: "Acme Store"},
+		{Format: "a", Input: "Acme 1", OutputError: "invalid format: character '1' at position 5 is not allowed by format 'a'"},
+		{Format: "an", Input: "Store 01"},
+		{Format: "an", Input: "Store-01", OutputError: "invalid format: character '-' at position 5 is not allowed by format 'an'"},
+		{Format: "ans", Input: "Store-01 #2"},
+		{Format: "ans", Input: "Tienda ñ", OutputError: "invalid format: character 'ñ' at position 7 is not allowed by format 'ans'"},
+		{Format: "ns", Input: "01/02"},
+		{Format: "as", Input: "A/B"},
+		{Format: "b", Input: "0123ABCDef"},
+		{Format: "b", Input: "0G", OutputError: "invalid format: character 'G' at position 1 is not allowed by format 'b'"},
+		{Format: "z", Input: ";5400000000000011=2512101?"},
+		{Format: "z", Input: "5400000000000011D2512101"},
+		{Format: "z", Input: "54^00", OutputError: "invalid format: character '^' at position 2 is not allowed by format 'z'"},
+		{Format: "x+n", Input: "C00000100"},
+		{Format: "x+n", Input: "D00000100"},
+		{Format: "x+n", Input: "00000100", OutputError: "invalid format: '00000100' must start with C or D followed by digits"},
+		{Format: "x+n", Input: "C0000A100", OutputError: "invalid format: character 'A' at position 4 is not allowed by format 'x+n'"},
+		{Format: "q", Input: "1", OutputError: "format 'q' does not exist"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(fmt.Sprintf("format_%s_%s", testCase.Format, testCase.Input), func(t *testing.T) {
+			err := iso8583.ValidateFormat(testCase.Format, testCase.Input)
+			if testCase.OutputError != "" {
+				if assert.NotNil(t, err) {
+					assert.Equal(t, testCase.OutputError, err.Error())
+				}
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+type validateTestMessage struct {
+	MTI      iso8583.MTI       `iso8583:"mti,length:4"`
+	Bitmap   iso8583.BITMAP    `iso8583:"bitmap"`
+	PAN      iso8583.LLVAR     `iso8583:"2,length:2,format:n,required:x1xx/x2xx"`
+	Code     iso8583.VAR       `iso8583:"3,length:6,format:n,required"`
+	Amount   iso8583.VAR       `iso8583:"4,length:12,format:n,required:0100"`
+	Name     iso8583.VAR       `iso8583:"43,length:10,format:ans"`
+	Data     iso8583.LLLVAR    `iso8583:"48,length:3,format:ans"`
+	PIN      iso8583.BINARY    `iso8583:"52,length:8"`
+	ICC      iso8583.LLLBINARY `iso8583:"55,length:3,format:b"`
+	Record   iso8583.LLBINARY  `iso8583:"120,length:2,format:an"`
+	Response iso8583.VAR       `iso8583:"39,length:2,format:an,required:xx10"`
+}
+
+func TestValidate(t *testing.T) {
+	valid := validateTestMessage{
+		MTI:      iso8583.MTI{MTI: "0110"},
+		PAN:      "5400000000000011",
+		Code:     "000000",
+		Name:     "ACME #1   ",
+		PIN:      []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Response: "00",
+	}
+
+	assert.Nil(t, iso8583.Validate(valid))
+	assert.Nil(t, iso8583.Validate(&valid))
+
+	invalid := validateTestMessage{
+		MTI:    iso8583.MTI{MTI: "0100"},
+		PAN:    "54000000000000A1",
+		Name:   "ACME",
+		Data:   iso8583.LLLVAR(strings.Repeat("A", 1000)),
+		PIN:    []byte{1, 2, 3},
+		Record: []byte{1},
+	}
+
+	errs := iso8583.Validate(invalid)
+	assert.Equal(t, []string{
+		"field 2: invalid format: character 'A' at position 14 is not allowed by format 'n'",
+		"field 3: field is required",
+		"field 4: field is required",
+		"field 43: invalid length: content is 4 long but should be 10",
+		"field 48: invalid length: content is 1000 long but LLL allows up to 999",
+		"field 52: invalid length: content is 3 long but should be 8",
+		"field 120: invalid format: binary fields only allow format 'b' but its 'an'",
+	}, errorMessages(errs))
+
+	var validationErr *iso8583.ValidationError
+	if assert.True(t, errors.As(errs[1], &validationErr)) {
+		assert.Equal(t, "3", validationErr.Field)
+	}
+	assert.True(t, errors.Is(errs[0], iso8583.ErrInvalidFormat))
+	assert.True(t, errors.Is(errs[1], iso8583.ErrFieldRequired))
+	assert.True(t, errors.Is(errs[3], iso8583.ErrInvalidLength))
+}
+
+func TestValidate_min_max(t *testing.T) {
+	type message struct {
+		MTI    iso8583.MTI       `iso8583:"mti,length:4"`
+		PAN    iso8583.LLVAR     `iso8583:"2,length:2,format:n,min:12,max:19"`
+		Name   iso8583.LLVAR     `iso8583:"43,length:2,max:5"`
+		Data   iso8583.LLLVAR    `iso8583:"48,length:3,min:3"`
+		ICC    iso8583.LLLBINARY `iso8583:"55,length:3,min:2,max:4"`
+		Track2 iso8583.Track2    `iso8583:"35,length:2,max:30"`
+	}
+
+	assert.Nil(t, iso8583.Validate(message{
+		MTI:  iso8583.MTI{MTI: "0100"},
+		PAN:  "540000000000",
+		Name: "ACME",
+		ICC:  []byte{1, 2, 3, 4},
+	}))
+
+	errs := iso8583.Validate(message{
+		MTI:    iso8583.MTI{MTI: "0100"},
+		PAN:    "54000000000",
+		Name:   "ACME #1",
+		Data:   "AB",
+		ICC:    []byte{1, 2, 3, 4, 5},
+		Track2: testTrack2,
+	})
+	assert.Equal(t, []string{
+		"field 2: invalid length: content is 11 long but at least 12 are required",
+		"field 35: invalid length: content is 37 long but up to 30 are allowed",
+		"field 43: invalid length: content is 7 long but up to 5 are allowed",
+		"field 48: invalid length: content is 2 long but at least 3 are required",
+		"field 55: invalid length: content is 5 long but up to 4 are allowed",
+	}, errorMessages(errs))
+	assert.True(t, errors.Is(errs[0], iso8583.ErrInvalidLength))
+
+	// Format errors are reported before the length limits.
+	assert.Equal(t, []string{"field 2: invalid format: character 'A' at position 0 is not allowed by format 'n'"},
+		errorMessages(iso8583.Validate(message{MTI: iso8583.MTI{MTI: "0100"}, PAN: "A"})))
+
+	assert.Equal(t, []string{"iso8583.validate: field 2: min 5 is greater than max 4"},
+		errorMessages(iso8583.Validate(struct {
+			PAN iso8583.LLVAR `iso8583:"2,length:2,min:5,max:4"`
+		}{PAN: "5400"})))
+
+	assert.Equal(t, []string{"iso8583.validate: field 2: invalid max: strconv.Atoi: parsing \"a\": invalid syntax"},
+		errorMessages(iso8583.Validate(struct {
+			PAN iso8583.LLVAR `iso8583:"2,length:2,max:a"`
+		}{PAN: "5400"})))
+}
+
+func TestValidate_invalid_input(t *testing.T) {
+	assert.Equal(t, []string{"iso8583.validate: input is not a struct or is pointing to one"},
+		errorMessages(iso8583.Validate("0100")))
+	assert.Equal(t, []string{"field mti: invalid format: character 'A' at position 1 is not allowed by format 'n'"},
+		errorMessages(iso8583.Validate(struct {
+			MTI iso8583.MTI `iso8583:"mti"`
+		}{MTI: iso8583.MTI{MTI: "0A00"}})))
+}
+
+func TestValidate_chip_data(t *testing.T) {
+	type message struct {
+		MTI    iso8583.MTI       `iso8583:"mti,length:4"`
+		Bitmap iso8583.BITMAP    `iso8583:"bitmap"`
+		ICC    iso8583.LLLBINARY `iso8583:"55,length:3,format:emv"`
+		Chip   iso8583.TLV       `iso8583:"56,length:3,format:emv"`
+	}
+
+	valid := message{
+		MTI:  iso8583.MTI{MTI: "0100"},
+		ICC:  []byte{0x9F, 0x36, 0x02, 0x00, 0x05, 0x9F, 0x1A, 0x02, 0x00, 0x32},
+		Chip: iso8583.TLV{List: emv.List{{Tag: "9F27", Value: []byte{0x80}}}},
+	}
+	assert.Nil(t, iso8583.Validate(valid))
+
+	invalid := message{
+		MTI:  iso8583.MTI{MTI: "0100"},
+		ICC:  []byte{0x9F, 0x36, 0x01, 0x05, 0x9F, 0x1A, 0x02, 0x00, 0x3A},
+		Chip: iso8583.TLV{List: emv.List{{Tag: "9F27", Value: []byte{0x80, 0x00}}}},
+	}
+	assert.Equal(t, []string{
+		"field 55: invalid format: tag 9F36 (Application Transaction Counter (ATC)): invalid length: " +
+			"value is 1 bytes long but should be between 2 and 2, tag 9F1A (Terminal Country Code): " +
+			"invalid format: byte 3A at position 1 is not allowed by format 'n'",
+		"field 56: invalid format: tag 9F27 (Cryptogram Information Data): invalid length: " +
+			"value is 2 bytes long but should be between 1 and 1",
+	}, errorMessages(iso8583.Validate(invalid)))
+
+	assert.Equal(t, []string{
+		"field 55: invalid format: offset 0: tag 9F36: truncated data object: " +
+			"value should be 2 bytes long but only 0 bytes are available",
+	}, errorMessages(iso8583.Validate(message{MTI: iso8583.MTI{MTI: "0100"}, ICC: []byte{0x9F, 0x36, 0x02}})))
+}
+
+func TestValidate_on_marshal_and_unmarshal(t *testing.T) {
+	msg := validateTestMessage{MTI: iso8583.MTI{MTI: "0800"}, Code: "99000A"}
+
+	_, err := iso8583.Marshal(msg)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	iso8583.ValidateOnMarshal = true
+	defer func() { iso8583.ValidateOnMarshal = false }()
+
+	_, err = iso8583.Marshal(msg)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "iso8583.marshal: validation failed: field 3: invalid format: character 'A' "+
+			"at position 5 is not allowed by format 'n'", err.Error())
+
+		var validationErrs iso8583.ValidationErrors
+		assert.True(t, errors.As(err, &validationErrs))
+		assert.Len(t, validationErrs, 1)
+	}
+
+	iso8583.ValidateOnMarshal = false
+	b, err := iso8583.Marshal(msg)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	iso8583.ValidateOnUnmarshal = true
+	defer func() { iso8583.ValidateOnUnmarshal = false }()
+
+	var output validateTestMessage
+	n, err := iso8583.Unmarshal(b, &output)
+	assert.Equal(t, len(b), n)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "iso8583.unmarshal: validation failed: field 3: invalid format: character 'A' "+
+			"at position 5 is not allowed by format 'n'", err.Error())
+	}
+}
+
+func errorMessages(errs []error) []string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
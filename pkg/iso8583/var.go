@@ -2,7 +2,6 @@ package iso8583
 
 import (
 	"errors"
-	"fmt"
 	"strings"
 	"unicode"
 )
@@ -14,7 +13,7 @@ type VAR string
 func (v VAR) MarshalISO8583(length int, enc string) ([]byte, error) {
 	content := []byte(v)
 
-	content, err := applyEncoding(content, enc, MarshalEncodings)
+	content, err := applyEncoding(content, enc)
 	if err != nil {
 		return nil, err
 	}
@@ -28,15 +27,8 @@ func (v *VAR) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
 		return 0, errors.New("bytes input is nil")
 	}
 
-	if len(b) < length {
-		return 0, fmt.Errorf("message remain (%v bytes) is shorter than indicated length: %v",
-			len(b), length)
-	}
-
-	byt := make([]byte, length)
-	copy(byt, b[:length])
-
-	byt, err := applyEncoding(byt, enc, UnmarshalDecodings)
+	// Length is the amount of characters, which may be represented by a different amount of bytes.
+	byt, n, err := decodeCharacters(b, length, enc)
 	if err != nil {
 		return 0, err
 	}
@@ -45,25 +37,14 @@ func (v *VAR) UnmarshalISO8583(b []byte, length int, enc string) (int, error) {
 		return !unicode.IsGraphic(r)
 	}))
 
-	return length, nil
+	return n, nil
 }
 
-func applyEncoding(bytes []byte, enc string, encodings map[string]func([]byte) ([]byte, error)) ([]byte, error) {
-	b := make([]byte, len(bytes))
-	copy(b, bytes)
-
-	if enc != "" {
-		encoder, exist := encodings[enc]
-		if !exist {
-			return nil, fmt.Errorf("encoder '%s' does not exist", enc)
-		}
-
-		var err error
-		b, err = encoder(b)
-		if err != nil {
-			return nil, fmt.Errorf("encoder '%s' returned error: %w", enc, err)
-		}
+// ValidateISO8583 checks that the content is exactly length characters long and satisfies the format.
+func (v VAR) ValidateISO8583(length int, format string) error {
+	if err := checkLength(len(v), length); err != nil {
+		return err
 	}
 
-	return b, nil
+	return ValidateFormat(format, string(v))
 }
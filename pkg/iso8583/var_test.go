@@ -34,6 +34,20 @@ func TestVAR_MarshalISO8583(t *testing.T) {
 			OutputError: "",
 			OutputBytes: ebcdic.V1047.FromGoString("ebcdic"),
 		},
+		{
+			Name:        "ebcdic_code_page",
+			V:           "[!]",
+			Encoding:    "ebcdic273",
+			OutputError: "",
+			OutputBytes: []byte{0x63, 0x4F, 0xFC},
+		},
+		{
+			Name:        "ebcdic_unmappable",
+			V:           "ebcdic鲸",
+			Encoding:    "ebcdic",
+			OutputError: "encoder 'ebcdic' returned error: unmappable character: rune '鲸' (U+9CB8) at position 6",
+			OutputBytes: nil,
+		},
 		{
 			Name:        "encoding_error",
 			V:           "ebcdic",
@@ -137,3 +151,28 @@ func TestVAR_UnmarshalISO8583(t *testing.T) {
 		})
 	}
 }
+
+func TestVAR_MarshalISO8583_ebcdic_lenient(t *testing.T) {
+	o, err := iso8583.VAR("CAFÉ 鲸").MarshalISO8583(0, "ebcdic-lenient")
+	assert.Nil(t, err)
+	assert.Equal(t, ebcdic.V1047.FromGoString("CAFÉ ?"), o)
+
+	_, err = iso8583.VAR("CAFÉ 鲸").MarshalISO8583(0, "ebcdic")
+	assert.True(t, errors.Is(err, ebcdic.ErrUnmappable))
+
+	c, err := iso8583.LenientEBCDICCodec("ebcdic037", '*')
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	iso8583.RegisterCodec("test_ebcdic037_lenient", c)
+
+	o, err = iso8583.VAR("CAFÉ 鲸").MarshalISO8583(0, "test_ebcdic037_lenient")
+	assert.Nil(t, err)
+	assert.Equal(t, ebcdic.V037.FromGoString("CAFÉ *"), o)
+
+	_, err = iso8583.LenientEBCDICCodec("ebcdic", '鲸')
+	assert.EqualError(t, err, "unmappable character: substitute rune '鲸' (U+9CB8)")
+
+	_, err = iso8583.LenientEBCDICCodec("ascii", '?')
+	assert.EqualError(t, err, "'ascii' is not an inbuilt EBCDIC codec")
+}
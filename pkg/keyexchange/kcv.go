@@ -0,0 +1,100 @@
+package keyexchange
+
+import (
+	"crypto/cipher"
+	"crypto/des"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidKey is returned when a key length is not allowed, exported error for asserting.
+var ErrInvalidKey = errors.New("invalid key")
+
+// _kcvLength is the amount of enciphered bytes used as key check value.
+const _kcvLength = 3
+
+// KCV returns the key check value of a DES or TDES key (8, 16 or 24 bytes):
+// the first 3 bytes of a zero block enciphered under it.
+func KCV(key []byte) ([]byte, error) {
+	block, err := tdes(key)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, des.BlockSize)
+	block.Encrypt(output, output)
+
+	return output[:_kcvLength], nil
+}
+
+// ECB wraps keys enciphering each 8 bytes block under a TDES zone master key, wrapped keys are
+// represented in upper case hexadecimal. TR-31 key blocks are wrapped by tr31.KeyBlock.
+type ECB struct {
+	ZMK []byte
+}
+
+// Format returns "01".
+func (e ECB) Format() string { return "01" }
+
+// Wrap enciphers key, which length must be a multiple of 8.
+func (e ECB) Wrap(key []byte) (string, error) {
+	b, err := e.apply(key, true)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToUpper(hex.EncodeToString(b)), nil
+}
+
+// Unwrap deciphers a wrapped key.
+func (e ECB) Unwrap(wrapped string) ([]byte, error) {
+	b, err := hex.DecodeString(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("wrapped key is not hexadecimal: %w", err)
+	}
+
+	return e.apply(b, false)
+}
+
+func (e ECB) apply(key []byte, encrypt bool) ([]byte, error) {
+	block, err := tdes(e.ZMK)
+	if err != nil {
+		return nil, fmt.Errorf("zone master key: %w", err)
+	}
+
+	if len(key) == 0 || len(key)%des.BlockSize != 0 {
+		return nil, fmt.Errorf("%w: key is %v bytes long but should be a multiple of 8", ErrInvalidKey, len(key))
+	}
+
+	output := make([]byte, len(key))
+	for offset := 0; offset < len(key); offset += des.BlockSize {
+		if encrypt {
+			block.Encrypt(output[offset:], key[offset:])
+		} else {
+			block.Decrypt(output[offset:], key[offset:])
+		}
+	}
+
+	return output, nil
+}
+
+func tdes(key []byte) (cipher.Block, error) {
+	switch len(key) {
+	case 8:
+		// Key length was already checked.
+		block, _ := des.NewCipher(key)
+		return block, nil
+	case 16:
+		// Double length keys are used as K1 K2 K1.
+		key = append(append(make([]byte, 0, 24), key...), key[:8]...)
+	case 24:
+	default:
+		return nil, fmt.Errorf("%w: TDES key is %v bytes long but should be 8, 16 or 24", ErrInvalidKey, len(key))
+	}
+
+	// Key length was already checked.
+	block, _ := des.NewTripleDESCipher(key)
+	return block, nil
+}
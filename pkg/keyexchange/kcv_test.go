@@ -0,0 +1,69 @@
+package keyexchange_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/keyexchange"
+)
+
+func decode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func TestKCV(t *testing.T) {
+	testList := []struct {
+		Name        string
+		Key         string
+		Output      string
+		OutputError string
+	}{
+		{Name: "single_length", Key: "0123456789ABCDEF", Output: "D5D44F"},
+		{Name: "double_length", Key: "0123456789ABCDEFFEDCBA9876543210", Output: "08D7B4"},
+		{Name: "triple_length", Key: "0123456789ABCDEFFEDCBA98765432100123456789ABCDEF", Output: "08D7B4"},
+		{Name: "invalid_length", Key: "0123456789", OutputError: "invalid key: TDES key is 5 bytes long but should be 8, 16 or 24"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			kcv, err := keyexchange.KCV(decode(testCase.Key))
+			if testCase.OutputError != "" {
+				assert.EqualError(t, err, testCase.OutputError)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.Output, strings.ToUpper(hex.EncodeToString(kcv)))
+		})
+	}
+}
+
+func TestECB(t *testing.T) {
+	zmk := keyexchange.ECB{ZMK: decode("0123456789ABCDEFFEDCBA9876543210")}
+
+	wrapped, err := zmk.Wrap(make([]byte, 16))
+	assert.Nil(t, err)
+	assert.Equal(t, "08D7B4FB629D088508D7B4FB629D0885", wrapped)
+
+	key, err := zmk.Unwrap(wrapped)
+	assert.Nil(t, err)
+	assert.Equal(t, make([]byte, 16), key)
+
+	_, err = zmk.Wrap(make([]byte, 12))
+	assert.True(t, errors.Is(err, keyexchange.ErrInvalidKey), err)
+
+	_, err = zmk.Unwrap("08D7B4FB629D088Z")
+	assert.EqualError(t, err, "wrapped key is not hexadecimal: encoding/hex: invalid byte: U+005A 'Z'")
+
+	_, err = keyexchange.ECB{ZMK: make([]byte, 10)}.Wrap(make([]byte, 16))
+	assert.EqualError(t, err, "zone master key: invalid key: TDES key is 10 bytes long but should be 8, 16 or 24")
+}
@@ -0,0 +1,226 @@
+package keyexchange
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+	"github.com/jattento/go-iso8583/pkg/template"
+)
+
+var (
+	// ErrKCVMismatch is returned when the check value of a received key differs from the indicated one,
+	// exported error for asserting.
+	ErrKCVMismatch = errors.New("key check value mismatch")
+	// ErrRejected is returned when a key exchange response does not approve the key, exported error for asserting.
+	ErrRejected = errors.New("key exchange rejected")
+)
+
+const (
+	// NetworkCodeKeyChange is the network management information code (DE70) of key exchange messages.
+	NetworkCodeKeyChange = "161"
+
+	// ResponseApproved is the response code (DE39) of accepted keys.
+	ResponseApproved = "00"
+	// ResponseFormatError is the response code (DE39) of requests which key can not be read.
+	ResponseFormatError = "30"
+	// ResponseCryptographicFailure is the response code (DE39) of requests which key check value differs.
+	ResponseCryptographicFailure = "88"
+
+	// _transactionCategoryCode is the DE48 transaction category code of network management messages.
+	_transactionCategoryCode = " "
+)
+
+// KeyClass identifies the usage of an exchanged working key.
+type KeyClass string
+
+const (
+	// KeyClassPIN is a PIN encryption key.
+	KeyClassPIN KeyClass = "PK"
+	// KeyClassMAC is a message authentication key.
+	KeyClassMAC KeyClass = "MK"
+	// KeyClassData is a data encryption key.
+	KeyClassData KeyClass = "DK"
+)
+
+// Key is a clear working key and its identification.
+type Key struct {
+	Class KeyClass
+	// Index and Cycle identify the key among the keys of its class, from 0 to 99.
+	Index int
+	Cycle int
+	Value []byte
+}
+
+// Wrapper protects working keys under a zone master key (ZMK) for its transport.
+// Wrapped keys are text, as they are carried in DE120.
+type Wrapper interface {
+	// Format is a two digits code that identifies the wrapping method in DE53.
+	Format() string
+	Wrap(key []byte) (string, error)
+	Unwrap(wrapped string) ([]byte, error)
+}
+
+// NewRequest returns a key exchange request (0800) that delivers key wrapped by w:
+// - DE120: key class followed by the wrapped key, up to 999 characters so TR-31 key blocks fit.
+// - DE53: wrapping format, key index and key cycle, zero filled.
+// - DE96: key check value in hexadecimal, zero filled.
+func NewRequest(key Key, w Wrapper, stan string, now time.Time) (template.MasterCardISO87, error) {
+	if err := checkKey(key); err != nil {
+		return template.MasterCardISO87{}, err
+	}
+
+	kcv, err := KCV(key.Value)
+	if err != nil {
+		return template.MasterCardISO87{}, err
+	}
+
+	wrapped, err := w.Wrap(key.Value)
+	if err != nil {
+		return template.MasterCardISO87{}, fmt.Errorf("key can not be wrapped: %w", err)
+	}
+
+	msg := template.MasterCardISO87{
+		MessageTypeIdentifier:             iso8583.MTI{MTI: "0800"},
+		TransmissionDateAndTime:           iso8583.MMDDHHMMSS{Time: now},
+		SystemTraceAuditNumber:            iso8583.VAR(stan),
+		SecurityRelatedControlInformation: iso8583.VAR(securityControl(w.Format(), key)),
+		NetworkManagementInformationCode:  NetworkCodeKeyChange,
+		MessageSecurityCode:               iso8583.VAR(checkValueField(kcv)),
+		RecordData:                        iso8583.LLLVAR(string(key.Class) + wrapped),
+	}
+
+	if err := msg.RecordData.ValidateISO8583(3, ""); err != nil {
+		return template.MasterCardISO87{}, fmt.Errorf("wrapped key does not fit in record data: %w", err)
+	}
+
+	msg.AdditionalDataPrivateUse.TransactionCategoryCode = _transactionCategoryCode
+
+	return msg, nil
+}
+
+// ParseRequest returns the key delivered by a key exchange request, which is unwrapped by w
+// and checked against the check value of DE96.
+func ParseRequest(msg template.MasterCardISO87, w Wrapper) (Key, error) {
+	if msg.MessageTypeIdentifier.MTI != "0800" || msg.NetworkManagementInformationCode != NetworkCodeKeyChange {
+		return Key{}, fmt.Errorf("message %s with network code '%s' is not a key exchange request",
+			msg.MessageTypeIdentifier, msg.NetworkManagementInformationCode)
+	}
+
+	control := string(msg.SecurityRelatedControlInformation)
+	if len(control) != 16 {
+		return Key{}, fmt.Errorf("security related control information '%s' should be 16 digits long", control)
+	}
+
+	if control[:2] != w.Format() {
+		return Key{}, fmt.Errorf("key wrapping format %s is not supported, expected %s", control[:2], w.Format())
+	}
+
+	index, indexErr := strconv.Atoi(control[2:4])
+	cycle, cycleErr := strconv.Atoi(control[4:6])
+	if indexErr != nil || cycleErr != nil {
+		return Key{}, fmt.Errorf("security related control information '%s' is not numeric", control)
+	}
+
+	data := string(msg.RecordData)
+	if len(data) < 2 {
+		return Key{}, errors.New("record data does not contain a key")
+	}
+
+	value, err := w.Unwrap(data[2:])
+	if err != nil {
+		return Key{}, fmt.Errorf("key can not be unwrapped: %w", err)
+	}
+
+	key := Key{Class: KeyClass(data[:2]), Index: index, Cycle: cycle, Value: value}
+	if err := checkKey(key); err != nil {
+		return Key{}, err
+	}
+
+	kcv, err := KCV(value)
+	if err != nil {
+		return Key{}, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(checkValueField(kcv)), []byte(msg.MessageSecurityCode)) != 1 {
+		return Key{}, fmt.Errorf("%w: key %X but message %s", ErrKCVMismatch, kcv, msg.MessageSecurityCode)
+	}
+
+	return key, nil
+}
+
+// NewResponse returns the response (0810) of a key exchange request and its parsed key. The response code
+// indicates if the key was accepted, in which case DE96 carries the check value of the received key.
+// The returned error indicates why the key was rejected.
+func NewResponse(request template.MasterCardISO87, w Wrapper, now time.Time) (template.MasterCardISO87, Key, error) {
+	response := template.MasterCardISO87{
+		MessageTypeIdentifier:            iso8583.MTI{MTI: "0810"},
+		TransmissionDateAndTime:          iso8583.MMDDHHMMSS{Time: now},
+		SystemTraceAuditNumber:           request.SystemTraceAuditNumber,
+		ResponseCode:                     ResponseApproved,
+		NetworkManagementInformationCode: request.NetworkManagementInformationCode,
+	}
+
+	key, err := ParseRequest(request, w)
+	switch {
+	case errors.Is(err, ErrKCVMismatch):
+		response.ResponseCode = ResponseCryptographicFailure
+	case err != nil:
+		response.ResponseCode = ResponseFormatError
+	default:
+		response.MessageSecurityCode = request.MessageSecurityCode
+	}
+
+	return response, key, err
+}
+
+// ParseResponse checks that response approves the key delivered by request.
+func ParseResponse(request template.MasterCardISO87, response template.MasterCardISO87) error {
+	if response.MessageTypeIdentifier.MTI != "0810" {
+		return fmt.Errorf("message %s is not a key exchange response", response.MessageTypeIdentifier)
+	}
+
+	if response.SystemTraceAuditNumber != request.SystemTraceAuditNumber {
+		return fmt.Errorf("response trace number %s does not match request %s",
+			response.SystemTraceAuditNumber, request.SystemTraceAuditNumber)
+	}
+
+	if response.ResponseCode != ResponseApproved {
+		return fmt.Errorf("%w: response code %s", ErrRejected, response.ResponseCode)
+	}
+
+	if response.MessageSecurityCode != request.MessageSecurityCode {
+		return fmt.Errorf("%w: sent %s but received %s", ErrKCVMismatch,
+			request.MessageSecurityCode, response.MessageSecurityCode)
+	}
+
+	return nil
+}
+
+// securityControl returns DE53: wrapping format, key index and key cycle, zero filled.
+func securityControl(format string, key Key) string {
+	return fmt.Sprintf("%2s%02d%02d", format, key.Index, key.Cycle) + strings.Repeat("0", 10)
+}
+
+// checkValueField returns DE96: the key check value in upper case hexadecimal, zero filled.
+func checkValueField(kcv []byte) string {
+	s := strings.ToUpper(hex.EncodeToString(kcv))
+	return s + strings.Repeat("0", 8-len(s))
+}
+
+func checkKey(key Key) error {
+	if len(key.Class) != 2 {
+		return fmt.Errorf("key class '%s' should be 2 characters long", key.Class)
+	}
+
+	if key.Index < 0 || key.Index > 99 || key.Cycle < 0 || key.Cycle > 99 {
+		return fmt.Errorf("key index %v and cycle %v should be between 0 and 99", key.Index, key.Cycle)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package keyexchange_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+	"github.com/jattento/go-iso8583/pkg/keyexchange"
+	"github.com/jattento/go-iso8583/pkg/template"
+	"github.com/jattento/go-iso8583/pkg/tr31"
+)
+
+const (
+	_testZMK     = "0123456789ABCDEFFEDCBA9876543210"
+	_testAESKBPK = "88E1AB2A2E3DD38C1FA039A536500CC8A87AB9D62DC92C01058FA79F44657DE6"
+)
+
+// writeMessage marshals msg and writes it with a 2 bytes length header.
+func writeMessage(w io.Writer, msg template.MasterCardISO87) error {
+	b, err := iso8583.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, 2)
+	binary.BigEndian.PutUint16(header, uint16(len(b)))
+
+	_, err = w.Write(append(header, b...))
+	return err
+}
+
+// readMessage reads a message with a 2 bytes length header.
+func readMessage(r io.Reader) (template.MasterCardISO87, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return template.MasterCardISO87{}, err
+	}
+
+	b := make([]byte, binary.BigEndian.Uint16(header))
+	if _, err := io.ReadFull(r, b); err != nil {
+		return template.MasterCardISO87{}, err
+	}
+
+	var msg template.MasterCardISO87
+	_, err := iso8583.Unmarshal(b, &msg)
+	return msg, err
+}
+
+// fakeHost answers one key exchange request received through conn and sends the accepted key to keys.
+func fakeHost(conn net.Conn, w keyexchange.Wrapper, keys chan<- keyexchange.Key) {
+	defer conn.Close()
+	defer close(keys)
+
+	request, err := readMessage(conn)
+	if err != nil {
+		return
+	}
+
+	response, key, err := keyexchange.NewResponse(request, w, time.Now())
+	if err == nil {
+		keys <- key
+	}
+
+	_ = writeMessage(conn, response)
+}
+
+func TestKeyExchange_fake_host(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC)
+	key := keyexchange.Key{
+		Class: keyexchange.KeyClassPIN, Index: 3, Cycle: 12, Value: decode("1A2B3C4D5E6F70811A2B3C4D5E6F7081"),
+	}
+
+	header := func(version byte) tr31.Header {
+		return tr31.Header{Version: version, KeyUsage: "P0", Algorithm: 'T', ModeOfUse: 'E', KeyVersion: "00",
+			Exportability: 'N'}
+	}
+
+	testList := []struct {
+		Name               string
+		Acquirer           keyexchange.Wrapper
+		Host               keyexchange.Wrapper
+		OutputError        error
+		OutputResponseCode string
+	}{
+		{
+			Name:     "ecb",
+			Acquirer: keyexchange.ECB{ZMK: decode(_testZMK)},
+			Host:     keyexchange.ECB{ZMK: decode(_testZMK)},
+		},
+		{
+			Name:               "ecb_different_zmk",
+			Acquirer:           keyexchange.ECB{ZMK: decode(_testZMK)},
+			Host:               keyexchange.ECB{ZMK: decode("FEDCBA98765432100123456789ABCDEF")},
+			OutputError:        keyexchange.ErrRejected,
+			OutputResponseCode: keyexchange.ResponseCryptographicFailure,
+		},
+		{
+			Name:     "tr31_version_b",
+			Acquirer: tr31.KeyBlock{KBPK: decode(_testZMK), Header: header(tr31.VersionB)},
+			Host:     tr31.KeyBlock{KBPK: decode(_testZMK), Header: header(tr31.VersionB)},
+		},
+		{
+			Name:     "tr31_version_d",
+			Acquirer: tr31.KeyBlock{KBPK: decode(_testAESKBPK), Header: header(tr31.VersionD)},
+			Host:     tr31.KeyBlock{KBPK: decode(_testAESKBPK), Header: header(tr31.VersionD)},
+		},
+		{
+			Name:               "tr31_different_kbpk",
+			Acquirer:           tr31.KeyBlock{KBPK: decode(_testAESKBPK), Header: header(tr31.VersionD)},
+			Host:               tr31.KeyBlock{KBPK: decode(_testZMK + _testZMK), Header: header(tr31.VersionD)},
+			OutputError:        keyexchange.ErrRejected,
+			OutputResponseCode: keyexchange.ResponseFormatError,
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer client.Close()
+
+			keys := make(chan keyexchange.Key, 1)
+			go fakeHost(server, testCase.Host, keys)
+
+			request, err := keyexchange.NewRequest(key, testCase.Acquirer, "000123", now)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			if !assert.Nil(t, writeMessage(client, request)) {
+				t.FailNow()
+			}
+
+			response, err := readMessage(client)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			err = keyexchange.ParseResponse(request, response)
+			if testCase.OutputError != nil {
+				assert.True(t, errors.Is(err, testCase.OutputError), err)
+				assert.Equal(t, iso8583.VAR(testCase.OutputResponseCode), response.ResponseCode)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, key, <-keys)
+		})
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	key := keyexchange.Key{Class: keyexchange.KeyClassMAC, Index: 1, Cycle: 2, Value: make([]byte, 16)}
+
+	msg, err := keyexchange.NewRequest(key, keyexchange.ECB{ZMK: decode(_testZMK)}, "000001", time.Now())
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, iso8583.VAR("0101020000000000"), msg.SecurityRelatedControlInformation)
+	assert.Equal(t, iso8583.VAR("8CA64D00"), msg.MessageSecurityCode)
+	assert.Equal(t, iso8583.VAR(keyexchange.NetworkCodeKeyChange), msg.NetworkManagementInformationCode)
+
+	assert.Equal(t, iso8583.LLLVAR("MK08D7B4FB629D088508D7B4FB629D0885"), msg.RecordData)
+
+	_, err = keyexchange.NewRequest(keyexchange.Key{Class: "PK", Index: 100, Value: make([]byte, 16)},
+		keyexchange.ECB{ZMK: decode(_testZMK)}, "000001", time.Now())
+	assert.EqualError(t, err, "key index 100 and cycle 0 should be between 0 and 99")
+}
+
+func TestParseRequest_errors(t *testing.T) {
+	zmk := keyexchange.ECB{ZMK: decode(_testZMK)}
+	valid, _ := keyexchange.NewRequest(keyexchange.Key{Class: "PK", Value: make([]byte, 16)}, zmk, "000001", time.Now())
+
+	testList := []struct {
+		Name        string
+		Modify      func(msg *template.MasterCardISO87)
+		OutputError string
+	}{
+		{
+			Name:        "not_key_exchange",
+			Modify:      func(msg *template.MasterCardISO87) { msg.NetworkManagementInformationCode = "301" },
+			OutputError: "message 0800 with network code '301' is not a key exchange request",
+		},
+		{
+			Name:        "unknown_format",
+			Modify:      func(msg *template.MasterCardISO87) { msg.SecurityRelatedControlInformation = "0200000000000000" },
+			OutputError: "key wrapping format 02 is not supported, expected 01",
+		},
+		{
+			Name:        "kcv_mismatch",
+			Modify:      func(msg *template.MasterCardISO87) { msg.MessageSecurityCode = "00000000" },
+			OutputError: "key check value mismatch: key 8CA64D but message 00000000",
+		},
+		{
+			Name:        "missing_key",
+			Modify:      func(msg *template.MasterCardISO87) { msg.RecordData = "" },
+			OutputError: "record data does not contain a key",
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			msg := valid
+			testCase.Modify(&msg)
+
+			_, err := keyexchange.ParseRequest(msg, zmk)
+			assert.EqualError(t, err, testCase.OutputError)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package mac
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidKey is returned when a key length is not allowed by an algorithm, exported error for asserting.
+var ErrInvalidKey = errors.New("invalid key")
+
+// Padding is an ISO 9797-1 padding method applied to the data before the DES based algorithms.
+type Padding int
+
+const (
+	// Padding1 appends zeros up to a multiple of the block size, empty data is padded to one block.
+	Padding1 Padding = 1
+	// Padding2 appends a 0x80 byte and then zeros up to a multiple of the block size.
+	Padding2 Padding = 2
+)
+
+// Algorithm1 returns the ISO 9797-1 MAC algorithm 1 (CBC-MAC) function, the key is single DES (8 bytes)
+// as in ANSI X9.9, or TDES (16 or 24 bytes). Returned MAC are 8 bytes long.
+func Algorithm1(key []byte, padding Padding) (func(data []byte) ([]byte, error), error) {
+	var block cipher.Block
+	switch len(key) {
+	case 8:
+		// Key length was already checked.
+		block, _ = des.NewCipher(key)
+	case 16, 24:
+		block = tdes(key)
+	default:
+		return nil, fmt.Errorf("%w: DES key is %v bytes long but should be 8, 16 or 24", ErrInvalidKey, len(key))
+	}
+
+	if err := checkPadding(padding); err != nil {
+		return nil, err
+	}
+
+	return func(data []byte) ([]byte, error) {
+		return cbcMAC(block, block, pad(data, padding)), nil
+	}, nil
+}
+
+// Algorithm3 returns the ISO 9797-1 MAC algorithm 3 function, known as ANSI X9.19 retail MAC: data is chained
+// with single DES under the first 8 bytes of key and the last block is enciphered with TDES.
+// Key is TDES (16 or 24 bytes). Returned MAC are 8 bytes long.
+func Algorithm3(key []byte, padding Padding) (func(data []byte) ([]byte, error), error) {
+	if len(key) != 16 && len(key) != 24 {
+		return nil, fmt.Errorf("%w: TDES key is %v bytes long but should be 16 or 24", ErrInvalidKey, len(key))
+	}
+
+	if err := checkPadding(padding); err != nil {
+		return nil, err
+	}
+
+	// Key length was already checked.
+	single, _ := des.NewCipher(key[:8])
+	triple := tdes(key)
+
+	return func(data []byte) ([]byte, error) {
+		return cbcMAC(single, triple, pad(data, padding)), nil
+	}, nil
+}
+
+// CMAC returns the AES-CMAC (NIST SP 800-38B) function, key is AES (16, 24 or 32 bytes).
+// Returned MAC are the leftmost size bytes, for example 8 for fields 64 and 128.
+func CMAC(key []byte, size int) (func(data []byte) ([]byte, error), error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: AES key is %v bytes long but should be 16, 24 or 32", ErrInvalidKey, len(key))
+	}
+
+	return cmac(block, size)
+}
+
+// TDESCMAC returns the TDES-CMAC (NIST SP 800-38B) function, also known as ISO 9797-1 MAC algorithm 5,
+// key is TDES (16 or 24 bytes). Returned MAC are the leftmost size bytes.
+func TDESCMAC(key []byte, size int) (func(data []byte) ([]byte, error), error) {
+	if len(key) != 16 && len(key) != 24 {
+		return nil, fmt.Errorf("%w: TDES key is %v bytes long but should be 16 or 24", ErrInvalidKey, len(key))
+	}
+
+	return cmac(tdes(key), size)
+}
+
+func cmac(block cipher.Block, size int) (func(data []byte) ([]byte, error), error) {
+	blockSize := block.BlockSize()
+	if size < 1 || size > blockSize {
+		return nil, fmt.Errorf("CMAC size %v should be between 1 and %v", size, blockSize)
+	}
+
+	k1, k2 := cmacSubkeys(block)
+
+	return func(data []byte) ([]byte, error) {
+		n := (len(data) + blockSize - 1) / blockSize
+		complete := n > 0 && len(data)%blockSize == 0
+		if n == 0 {
+			n = 1
+		}
+
+		last := make([]byte, blockSize)
+		copy(last, data[(n-1)*blockSize:])
+
+		subkey := k1
+		if !complete {
+			last[len(data)-(n-1)*blockSize] = 0x80
+			subkey = k2
+		}
+
+		for i := range last {
+			last[i] ^= subkey[i]
+		}
+
+		padded := append(append(make([]byte, 0, n*blockSize), data[:(n-1)*blockSize]...), last...)
+
+		return cbcMAC(block, block, padded)[:size], nil
+	}, nil
+}
+
+// cbcMAC chains data blocks under block and enciphers the last one under final.
+func cbcMAC(block cipher.Block, final cipher.Block, data []byte) []byte {
+	size := block.BlockSize()
+	chain := make([]byte, size)
+
+	for offset := 0; offset < len(data); offset += size {
+		for i := range chain {
+			chain[i] ^= data[offset+i]
+		}
+
+		if offset+size == len(data) {
+			final.Encrypt(chain, chain)
+			break
+		}
+
+		block.Encrypt(chain, chain)
+	}
+
+	return chain
+}
+
+// cmacSubkeys derives the K1 and K2 subkeys of CMAC.
+func cmacSubkeys(block cipher.Block) ([]byte, []byte) {
+	l := make([]byte, block.BlockSize())
+	block.Encrypt(l, l)
+
+	k1 := shiftLeft(l)
+	k2 := shiftLeft(k1)
+
+	return k1, k2
+}
+
+// shiftLeft returns b shifted one bit to the left, reduced by the CMAC polynomial of its block size
+// if the high bit was on.
+func shiftLeft(b []byte) []byte {
+	output := make([]byte, len(b))
+	for i := range b {
+		output[i] = b[i] << 1
+		if i+1 < len(b) {
+			output[i] |= b[i+1] >> 7
+		}
+	}
+
+	if b[0]&0x80 != 0 {
+		if len(b) == aes.BlockSize {
+			output[len(output)-1] ^= 0x87
+		} else {
+			output[len(output)-1] ^= 0x1B
+		}
+	}
+
+	return output
+}
+
+func pad(data []byte, padding Padding) []byte {
+	padded := append(make([]byte, 0, len(data)+des.BlockSize), data...)
+	if padding == Padding2 {
+		padded = append(padded, 0x80)
+	}
+
+	for len(padded) == 0 || len(padded)%des.BlockSize != 0 {
+		padded = append(padded, 0)
+	}
+
+	return padded
+}
+
+func checkPadding(padding Padding) error {
+	if padding != Padding1 && padding != Padding2 {
+		return fmt.Errorf("padding method %v is not supported", int(padding))
+	}
+
+	return nil
+}
+
+func tdes(key []byte) cipher.Block {
+	if len(key) == 16 {
+		// Double length keys are used as K1 K2 K1.
+		key = append(append(make([]byte, 0, 24), key...), key[:8]...)
+	}
+
+	// Key length is checked by callers.
+	block, _ := des.NewTripleDESCipher(key)
+	return block
+}
@@ -0,0 +1,192 @@
+package mac_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/mac"
+)
+
+func decode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func TestAlgorithm1(t *testing.T) {
+	testList := []struct {
+		Name    string
+		Key     string
+		Padding mac.Padding
+		Data    string
+		Output  string
+	}{
+		{Name: "x9_9", Key: "0123456789ABCDEF", Padding: mac.Padding1,
+			Data: "4E6F77206973207468652074696D6520666F7220616C6C20", Output: "70A30640CC76DD8B"},
+		{Name: "equal_tdes_halves", Key: "0123456789ABCDEF0123456789ABCDEF", Padding: mac.Padding1,
+			Data: "4E6F77206973207468652074696D6520666F7220616C6C20", Output: "70A30640CC76DD8B"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			f, err := mac.Algorithm1(decode(testCase.Key), testCase.Padding)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			code, err := f(decode(testCase.Data))
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.Output, strings.ToUpper(hex.EncodeToString(code)))
+		})
+	}
+}
+
+func TestAlgorithm3(t *testing.T) {
+	testList := []struct {
+		Name    string
+		Key     string
+		Padding mac.Padding
+		Data    string
+		Output  string
+	}{
+		{Name: "x9_19", Key: "0123456789ABCDEFFEDCBA9876543210", Padding: mac.Padding1,
+			Data: "4E6F77206973207468652074696D6520666F7220616C6C20", Output: "A1C72E74EA3FA9B6"},
+		{Name: "equal_tdes_halves", Key: "0123456789ABCDEF0123456789ABCDEF", Padding: mac.Padding1,
+			Data: "4E6F77206973207468652074696D6520666F7220616C6C20", Output: "70A30640CC76DD8B"},
+		{Name: "triple_length", Key: "0123456789ABCDEFFEDCBA98765432100123456789ABCDEF", Padding: mac.Padding1,
+			Data: "4E6F77206973207468652074696D6520666F7220616C6C20", Output: "A1C72E74EA3FA9B6"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			f, err := mac.Algorithm3(decode(testCase.Key), testCase.Padding)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			code, err := f(decode(testCase.Data))
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.Output, strings.ToUpper(hex.EncodeToString(code)))
+		})
+	}
+}
+
+func TestPadding(t *testing.T) {
+	key := decode("0123456789ABCDEF")
+
+	f1, err := mac.Algorithm1(key, mac.Padding1)
+	assert.Nil(t, err)
+	f2, err := mac.Algorithm1(key, mac.Padding2)
+	assert.Nil(t, err)
+
+	// Method 2 of 8 bytes is method 1 of the data followed by a 0x80 block.
+	code, _ := f2(decode("0102030405060708"))
+	expected, _ := f1(decode("010203040506070880"))
+	assert.Equal(t, expected, code)
+
+	// Method 1 of a partial block is the data followed by zeros.
+	code, _ = f1(decode("0102030405"))
+	expected, _ = f1(decode("0102030405000000"))
+	assert.Equal(t, expected, code)
+
+	// Method 1 of empty data is a zero block.
+	code, _ = f1(nil)
+	expected, _ = f1(make([]byte, 8))
+	assert.Equal(t, expected, code)
+}
+
+func TestCMAC(t *testing.T) {
+	const message = "6BC1BEE22E409F96E93D7E117393172AAE2D8A571E03AC9C9EB76FAC45AF8E51" +
+		"30C81C46A35CE411E5FBC1191A0A52EFF69F2445DF4F9B17AD2B417BE66C3710"
+
+	testList := []struct {
+		Name   string
+		Key    string
+		Data   string
+		Size   int
+		Output string
+	}{
+		{Name: "empty", Key: "2B7E151628AED2A6ABF7158809CF4F3C", Size: 16,
+			Output: "BB1D6929E95937287FA37D129B756746"},
+		{Name: "one_block", Key: "2B7E151628AED2A6ABF7158809CF4F3C", Data: message[:32], Size: 16,
+			Output: "070A16B46B4D4144F79BDD9DD04A287C"},
+		{Name: "partial_block", Key: "2B7E151628AED2A6ABF7158809CF4F3C", Data: message[:80], Size: 16,
+			Output: "DFA66747DE9AE63030CA32611497C827"},
+		{Name: "four_blocks", Key: "2B7E151628AED2A6ABF7158809CF4F3C", Data: message, Size: 16,
+			Output: "51F0BEBF7E3B9D92FC49741779363CFE"},
+		{Name: "truncated", Key: "2B7E151628AED2A6ABF7158809CF4F3C", Data: message, Size: 8,
+			Output: "51F0BEBF7E3B9D92"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			f, err := mac.CMAC(decode(testCase.Key), testCase.Size)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			code, err := f(decode(testCase.Data))
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.Output, strings.ToUpper(hex.EncodeToString(code)))
+		})
+	}
+}
+
+func TestTDESCMAC(t *testing.T) {
+	testList := []struct {
+		Name   string
+		Key    string
+		Data   string
+		Output string
+	}{
+		{Name: "three_key_empty", Key: "8AA83BF8CBDA10620BC1BF19FBB6CD58BC313D4A371CA8B5", Output: "B7A688E122FFAF95"},
+		{Name: "three_key_one_block", Key: "8AA83BF8CBDA10620BC1BF19FBB6CD58BC313D4A371CA8B5",
+			Data: "6BC1BEE22E409F96", Output: "8E8F293136283797"},
+		{Name: "two_key_empty", Key: "4CF15134A2850DD58A3D10BA80570D38", Output: "BD2EBF9A3BA00361"},
+		{Name: "two_key_one_block", Key: "4CF15134A2850DD58A3D10BA80570D38",
+			Data: "6BC1BEE22E409F96", Output: "4FF2AB813C53CE83"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			f, err := mac.TDESCMAC(decode(testCase.Key), 8)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			code, err := f(decode(testCase.Data))
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.Output, strings.ToUpper(hex.EncodeToString(code)))
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	_, err := mac.Algorithm1(make([]byte, 10), mac.Padding1)
+	assert.EqualError(t, err, "invalid key: DES key is 10 bytes long but should be 8, 16 or 24")
+
+	_, err = mac.Algorithm3(make([]byte, 8), mac.Padding1)
+	assert.EqualError(t, err, "invalid key: TDES key is 8 bytes long but should be 16 or 24")
+
+	_, err = mac.Algorithm3(make([]byte, 16), mac.Padding(3))
+	assert.EqualError(t, err, "padding method 3 is not supported")
+
+	_, err = mac.CMAC(make([]byte, 15), 8)
+	assert.True(t, errors.Is(err, mac.ErrInvalidKey))
+
+	_, err = mac.CMAC(make([]byte, 16), 17)
+	assert.EqualError(t, err, "CMAC size 17 should be between 1 and 16")
+
+	_, err = mac.TDESCMAC(make([]byte, 16), 9)
+	assert.EqualError(t, err, "CMAC size 9 should be between 1 and 8")
+
+	_, err = mac.TDESCMAC(make([]byte, 8), 8)
+	assert.EqualError(t, err, "invalid key: TDES key is 8 bytes long but should be 16 or 24")
+}
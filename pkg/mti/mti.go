@@ -70,6 +70,23 @@ func (mti MTI) HigherOrEqualThan(v MTI) bool {
 	return atoi(string(mti)) >= atoi(string(v))
 }
 
+// Match reports whether the MTI matches the pattern, where each character of the pattern must be equal
+// to the MTI character in the same position or be a 'x' wildcard.
+// For example: "x1x0" matches any authorization request or response message.
+func (mti MTI) Match(pattern string) bool {
+	if len(pattern) != len(mti) {
+		return false
+	}
+
+	for n := range pattern {
+		if pattern[n] != 'x' && pattern[n] != 'X' && pattern[n] != mti[n] {
+			return false
+		}
+	}
+
+	return true
+}
+
 type origin int
 
 const (
@@ -73,3 +73,12 @@ func TestMTI_LowerThan(t *testing.T) {
 func TestMTI_LowerThan_Panics(t *testing.T) {
 	assert.Panics(t, func() { mti.MTI("AAAA").LowerThan("BBBB") })
 }
+
+func TestMTI_Match(t *testing.T) {
+	assert.True(t, mti.MTI("0110").Match("0110"))
+	assert.True(t, mti.MTI("0110").Match("x1x0"))
+	assert.True(t, mti.MTI("0210").Match("X2XX"))
+	assert.False(t, mti.MTI("0121").Match("x1x0"))
+	assert.False(t, mti.MTI("0110").Match("x1x"))
+	assert.False(t, mti.MTI("0110").Match(""))
+}
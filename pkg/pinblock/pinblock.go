@@ -0,0 +1,328 @@
+package pinblock
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPIN is returned when a PIN is not 4 to 12 digits long, exported error for asserting.
+	ErrInvalidPIN = errors.New("invalid pin")
+	// ErrInvalidPAN is returned when a PAN can not be used by a format, exported error for asserting.
+	ErrInvalidPAN = errors.New("invalid pan")
+	// ErrInvalidBlock is returned when a PIN block does not satisfy its format, exported error for asserting.
+	ErrInvalidBlock = errors.New("invalid pin block")
+	// ErrInvalidKey is returned when a key length is not allowed by the cipher of a format, exported error for asserting.
+	ErrInvalidKey = errors.New("invalid key")
+)
+
+// Rand is the source of the random fill digits of formats 1, 3 and 4, it can be replaced to obtain
+// reproducible PIN blocks.
+var Rand io.Reader = rand.Reader
+
+// Format is an ISO 9564-1 PIN block format.
+type Format int
+
+const (
+	// Format0 is the PIN combined with the PAN, padded with 'F' (ANSI X9.8), 8 bytes long.
+	Format0 Format = 0
+	// Format1 is the PIN padded with random digits without the PAN, 8 bytes long.
+	Format1 Format = 1
+	// Format3 is the PIN combined with the PAN, padded with random 'A' to 'F' nibbles, 8 bytes long.
+	Format3 Format = 3
+	// Format4 is the PIN and the PAN enciphered by AES in two steps, 16 bytes long.
+	Format4 Format = 4
+)
+
+const (
+	_minPINLength = 4
+	_maxPINLength = 12
+)
+
+// Build returns the clear PIN block of pin and pan. For format 4 the plain text PIN field is returned,
+// as its PAN field is only combined on encipherment. Format 1 ignores pan.
+func Build(format Format, pin string, pan string) ([]byte, error) {
+	if err := checkPIN(pin); err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case Format0, Format1, Format3:
+		fill, err := fillNibbles(format, 14-len(pin))
+		if err != nil {
+			return nil, err
+		}
+
+		// Hexadecimal digits can always be decoded.
+		field, _ := hex.DecodeString(strconv.Itoa(int(format)) + strconv.FormatInt(int64(len(pin)), 16) + pin + fill)
+		if format == Format1 {
+			return field, nil
+		}
+
+		panField, err := shortPANField(pan)
+		if err != nil {
+			return nil, err
+		}
+
+		return xor(field, panField), nil
+	case Format4:
+		random, err := randomNibbles(16, "0123456789ABCDEF")
+		if err != nil {
+			return nil, err
+		}
+
+		// Hexadecimal digits can always be decoded.
+		return hex.DecodeString("4" + strconv.FormatInt(int64(len(pin)), 16) + pin +
+			strings.Repeat("A", 14-len(pin)) + random)
+	}
+
+	return nil, fmt.Errorf("pin block format %v is not supported", int(format))
+}
+
+// Parse returns the PIN of a clear PIN block, for format 4 block is the plain text PIN field.
+// Format 1 and 4 ignore pan.
+func Parse(format Format, block []byte, pan string) (string, error) {
+	var field []byte
+	switch format {
+	case Format0, Format3:
+		panField, err := shortPANField(pan)
+		if err != nil {
+			return "", err
+		}
+
+		if len(block) != 8 {
+			return "", fmt.Errorf("%w: format %v block is %v bytes long but should be 8", ErrInvalidBlock, format, len(block))
+		}
+
+		field = xor(block, panField)
+	case Format1:
+		if len(block) != 8 {
+			return "", fmt.Errorf("%w: format 1 block is %v bytes long but should be 8", ErrInvalidBlock, len(block))
+		}
+
+		field = block
+	case Format4:
+		if len(block) != aes.BlockSize {
+			return "", fmt.Errorf("%w: format 4 block is %v bytes long but should be 16", ErrInvalidBlock, len(block))
+		}
+
+		field = block
+	default:
+		return "", fmt.Errorf("pin block format %v is not supported", int(format))
+	}
+
+	nibbles := fmt.Sprintf("%X", field)
+	if nibbles[0] != strconv.Itoa(int(format))[0] {
+		return "", fmt.Errorf("%w: control field is %c but format is %v", ErrInvalidBlock, nibbles[0], format)
+	}
+
+	// Any nibble can be parsed.
+	length, _ := strconv.ParseInt(nibbles[1:2], 16, 64)
+	if length < _minPINLength || length > _maxPINLength {
+		return "", fmt.Errorf("%w: pin length is %v", ErrInvalidBlock, length)
+	}
+
+	pin, fill := nibbles[2:2+length], nibbles[2+length:16]
+	if strings.Trim(pin, "0123456789") != "" {
+		return "", fmt.Errorf("%w: pin contains non numeric nibbles", ErrInvalidBlock)
+	}
+
+	valid := map[Format]string{Format0: "F", Format1: "0123456789ABCDEF", Format3: "ABCDEF", Format4: "A"}[format]
+	if strings.Trim(fill, valid) != "" {
+		return "", fmt.Errorf("%w: fill nibbles %s are not allowed by format %v", ErrInvalidBlock, fill, format)
+	}
+
+	return pin, nil
+}
+
+// Encrypt builds the PIN block and enciphers it under key: TDES (16 or 24 bytes) for formats 0, 1 and 3
+// and AES (16, 24 or 32 bytes) for format 4.
+func Encrypt(format Format, pin string, pan string, key []byte) ([]byte, error) {
+	block, err := Build(format, pin, pan)
+	if err != nil {
+		return nil, err
+	}
+
+	if format != Format4 {
+		c, err := tdes(key)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Encrypt(block, block)
+		return block, nil
+	}
+
+	c, err := aesCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	panField, err := longPANField(pan)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plain text PIN field is enciphered, combined with the PAN field and enciphered again.
+	c.Encrypt(block, block)
+	block = xor(block, panField)
+	c.Encrypt(block, block)
+
+	return block, nil
+}
+
+// Decrypt deciphers a PIN block under key and returns its PIN, as the inverse of Encrypt.
+func Decrypt(format Format, block []byte, pan string, key []byte) (string, error) {
+	if format != Format4 {
+		c, err := tdes(key)
+		if err != nil {
+			return "", err
+		}
+
+		if len(block) != des.BlockSize {
+			return "", fmt.Errorf("%w: format %v block is %v bytes long but should be 8", ErrInvalidBlock, format, len(block))
+		}
+
+		clear := make([]byte, des.BlockSize)
+		c.Decrypt(clear, block)
+
+		return Parse(format, clear, pan)
+	}
+
+	c, err := aesCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(block) != aes.BlockSize {
+		return "", fmt.Errorf("%w: format 4 block is %v bytes long but should be 16", ErrInvalidBlock, len(block))
+	}
+
+	panField, err := longPANField(pan)
+	if err != nil {
+		return "", err
+	}
+
+	clear := make([]byte, aes.BlockSize)
+	c.Decrypt(clear, block)
+	clear = xor(clear, panField)
+	c.Decrypt(clear, clear)
+
+	return Parse(Format4, clear, pan)
+}
+
+// shortPANField is the PAN field of formats 0 and 3: four zeros and the rightmost 12 digits
+// of the PAN excluding the check digit.
+func shortPANField(pan string) ([]byte, error) {
+	if err := checkPAN(pan); err != nil {
+		return nil, err
+	}
+
+	digits := pan[:len(pan)-1]
+	if len(digits) > 12 {
+		digits = digits[len(digits)-12:]
+	}
+
+	// Digits can always be decoded.
+	return hex.DecodeString("0000" + strings.Repeat("0", 12-len(digits)) + digits)
+}
+
+// longPANField is the PAN field of format 4: the PAN length minus 12, the PAN and zeros, 16 bytes long.
+// PAN shorter than 12 digits are padded with zeros on the left.
+func longPANField(pan string) ([]byte, error) {
+	if err := checkPAN(pan); err != nil {
+		return nil, err
+	}
+
+	m := 0
+	if len(pan) > 12 {
+		m = len(pan) - 12
+	} else {
+		pan = strings.Repeat("0", 12-len(pan)) + pan
+	}
+
+	// Digits can always be decoded.
+	return hex.DecodeString(strconv.Itoa(m) + pan + strings.Repeat("0", 31-len(pan)))
+}
+
+func checkPIN(pin string) error {
+	if len(pin) < _minPINLength || len(pin) > _maxPINLength || strings.Trim(pin, "0123456789") != "" {
+		return fmt.Errorf("%w: pin should be between %v and %v digits", ErrInvalidPIN, _minPINLength, _maxPINLength)
+	}
+
+	return nil
+}
+
+func checkPAN(pan string) error {
+	if len(pan) < 2 || len(pan) > 19 || strings.Trim(pan, "0123456789") != "" {
+		return fmt.Errorf("%w: pan should contain up to 19 digits", ErrInvalidPAN)
+	}
+
+	return nil
+}
+
+func fillNibbles(format Format, n int) (string, error) {
+	switch format {
+	case Format0:
+		return strings.Repeat("F", n), nil
+	case Format1:
+		return randomNibbles(n, "0123456789ABCDEF")
+	}
+
+	return randomNibbles(n, "ABCDEF")
+}
+
+// randomNibbles returns n characters randomly taken from alphabet.
+func randomNibbles(n int, alphabet string) (string, error) {
+	random := make([]byte, n)
+	if _, err := io.ReadFull(Rand, random); err != nil {
+		return "", fmt.Errorf("random fill: %w", err)
+	}
+
+	for i := range random {
+		random[i] = alphabet[int(random[i])%len(alphabet)]
+	}
+
+	return string(random), nil
+}
+
+func tdes(key []byte) (cipher.Block, error) {
+	switch len(key) {
+	case 16:
+		// Double length keys are used as K1 K2 K1.
+		key = append(append(make([]byte, 0, 24), key...), key[:8]...)
+	case 24:
+	default:
+		return nil, fmt.Errorf("%w: TDES key is %v bytes long but should be 16 or 24", ErrInvalidKey, len(key))
+	}
+
+	// Key length was already checked.
+	c, _ := des.NewTripleDESCipher(key)
+	return c, nil
+}
+
+func aesCipher(key []byte) (cipher.Block, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: AES key is %v bytes long but should be 16, 24 or 32", ErrInvalidKey, len(key))
+	}
+
+	return c, nil
+}
+
+func xor(a []byte, b []byte) []byte {
+	output := make([]byte, len(a))
+	for n := range a {
+		output[n] = a[n] ^ b[n]
+	}
+
+	return output
+}
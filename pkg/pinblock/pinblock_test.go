@@ -0,0 +1,178 @@
+package pinblock_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/pinblock"
+)
+
+// fixRand replaces the random source and returns a function that restores it.
+func fixRand(r io.Reader) func() {
+	original := pinblock.Rand
+	pinblock.Rand = r
+	return func() { pinblock.Rand = original }
+}
+
+func TestBuild(t *testing.T) {
+	defer fixRand(bytes.NewReader(make([]byte, 64)))()
+
+	testList := []struct {
+		Name        string
+		Format      pinblock.Format
+		PIN         string
+		PAN         string
+		Output      string
+		OutputError error
+	}{
+		{Name: "format_0", Format: pinblock.Format0, PIN: "1234", PAN: "4111111111111111", Output: "041225EEEEEEEEEE"},
+		{Name: "format_0_long_pin", Format: pinblock.Format0, PIN: "123456789012", PAN: "5400000000000011",
+			Output: "0C123456789012FE"},
+		{Name: "format_0_short_pan", Format: pinblock.Format0, PIN: "1234", PAN: "12345", Output: "041234FFFFFFEDCB"},
+		{Name: "format_1", Format: pinblock.Format1, PIN: "1234", Output: "1412340000000000"},
+		{Name: "format_3", Format: pinblock.Format3, PIN: "1234", PAN: "4111111111111111", Output: "341225BBBBBBBBBB"},
+		{Name: "format_4", Format: pinblock.Format4, PIN: "1234", Output: "441234AAAAAAAAAA0000000000000000"},
+		{Name: "short_pin", Format: pinblock.Format0, PIN: "123", PAN: "4111111111111111",
+			OutputError: pinblock.ErrInvalidPIN},
+		{Name: "non_numeric_pin", Format: pinblock.Format1, PIN: "12A4", OutputError: pinblock.ErrInvalidPIN},
+		{Name: "invalid_pan", Format: pinblock.Format3, PIN: "1234", PAN: "4111-1111", OutputError: pinblock.ErrInvalidPAN},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			b, err := pinblock.Build(testCase.Format, testCase.PIN, testCase.PAN)
+			if testCase.OutputError != nil {
+				assert.True(t, errors.Is(err, testCase.OutputError), err)
+				return
+			}
+
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.Output, strings.ToUpper(hex.EncodeToString(b)))
+
+			pin, err := pinblock.Parse(testCase.Format, b, testCase.PAN)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.PIN, pin)
+		})
+	}
+}
+
+func TestBuild_unsupported_format(t *testing.T) {
+	_, err := pinblock.Build(pinblock.Format(2), "1234", "4111111111111111")
+	assert.EqualError(t, err, "pin block format 2 is not supported")
+}
+
+func TestParse_errors(t *testing.T) {
+	testList := []struct {
+		Name        string
+		Format      pinblock.Format
+		Block       string
+		PAN         string
+		OutputError string
+	}{
+		{Name: "control_field", Format: pinblock.Format1, Block: "0412340000000000",
+			OutputError: "invalid pin block: control field is 0 but format is 1"},
+		{Name: "pin_length", Format: pinblock.Format1, Block: "1312340000000000",
+			OutputError: "invalid pin block: pin length is 3"},
+		{Name: "pin_digits", Format: pinblock.Format1, Block: "14123A0000000000",
+			OutputError: "invalid pin block: pin contains non numeric nibbles"},
+		{Name: "format_0_fill", Format: pinblock.Format0, Block: "041225EEEEEEEEEF", PAN: "4111111111111111",
+			OutputError: "invalid pin block: fill nibbles FFFFFFFFFE are not allowed by format 0"},
+		{Name: "format_3_fill", Format: pinblock.Format3, Block: "3412251111111111", PAN: "4111111111111111",
+			OutputError: "invalid pin block: fill nibbles 0000000000 are not allowed by format 3"},
+		{Name: "block_length", Format: pinblock.Format4, Block: "441234AAAAAAAAAA",
+			OutputError: "invalid pin block: format 4 block is 8 bytes long but should be 16"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			b, _ := hex.DecodeString(testCase.Block)
+
+			_, err := pinblock.Parse(testCase.Format, b, testCase.PAN)
+			assert.EqualError(t, err, testCase.OutputError)
+		})
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	tdesKey, _ := hex.DecodeString("0123456789ABCDEFFEDCBA9876543210")
+	aesKey, _ := hex.DecodeString("000102030405060708090A0B0C0D0E0F")
+
+	testList := []struct {
+		Name   string
+		Format pinblock.Format
+		Key    []byte
+	}{
+		{Name: "format_0", Format: pinblock.Format0, Key: tdesKey},
+		{Name: "format_1_triple_length", Format: pinblock.Format1, Key: append(tdesKey, tdesKey[:8]...)},
+		{Name: "format_3", Format: pinblock.Format3, Key: tdesKey},
+		{Name: "format_4", Format: pinblock.Format4, Key: aesKey},
+		{Name: "format_4_aes_256", Format: pinblock.Format4, Key: append(aesKey, aesKey...)},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			const pin, pan = "98765", "5400000000000011"
+
+			b, err := pinblock.Encrypt(testCase.Format, pin, pan, testCase.Key)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			clear, err := pinblock.Decrypt(testCase.Format, b, pan, testCase.Key)
+			assert.Nil(t, err)
+			assert.Equal(t, pin, clear)
+		})
+	}
+}
+
+func TestEncrypt_format_4_layout(t *testing.T) {
+	defer fixRand(bytes.NewReader(make([]byte, 16)))()
+
+	key, _ := hex.DecodeString("000102030405060708090A0B0C0D0E0F")
+	plain, _ := hex.DecodeString("441234AAAAAAAAAA0000000000000000")
+	panField, _ := hex.DecodeString("45400000000000011000000000000000")
+
+	c, _ := aes.NewCipher(key)
+	expected := make([]byte, aes.BlockSize)
+	c.Encrypt(expected, plain)
+	for n := range expected {
+		expected[n] ^= panField[n]
+	}
+	c.Encrypt(expected, expected)
+
+	b, err := pinblock.Encrypt(pinblock.Format4, "1234", "5400000000000011", key)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, b)
+}
+
+func TestDecrypt_wrong_pan(t *testing.T) {
+	key, _ := hex.DecodeString("0123456789ABCDEFFEDCBA9876543210")
+
+	b, err := pinblock.Encrypt(pinblock.Format0, "1234", "4111111111111111", key)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	_, err = pinblock.Decrypt(pinblock.Format0, b, "4111111111111129", key)
+	assert.True(t, errors.Is(err, pinblock.ErrInvalidBlock), err)
+}
+
+func TestEncrypt_errors(t *testing.T) {
+	_, err := pinblock.Encrypt(pinblock.Format0, "1234", "4111111111111111", make([]byte, 8))
+	assert.EqualError(t, err, "invalid key: TDES key is 8 bytes long but should be 16 or 24")
+
+	_, err = pinblock.Encrypt(pinblock.Format4, "1234", "4111111111111111", make([]byte, 16+8+1))
+	assert.EqualError(t, err, "invalid key: AES key is 25 bytes long but should be 16, 24 or 32")
+
+	_, err = pinblock.Decrypt(pinblock.Format4, make([]byte, 8), "4111111111111111", make([]byte, 16))
+	assert.EqualError(t, err, "invalid pin block: format 4 block is 8 bytes long but should be 16")
+}
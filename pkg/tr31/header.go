@@ -0,0 +1,170 @@
+package tr31
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// _headerLength is the length of the fixed part of the header.
+	_headerLength = 16
+	// _optionalBlockHeaderLength is the length of the ID and length of an optional block.
+	_optionalBlockHeaderLength = 4
+)
+
+// Key block versions.
+const (
+	// VersionB is the TDES key derivation binding method.
+	VersionB byte = 'B'
+	// VersionD is the AES key derivation binding method.
+	VersionD byte = 'D'
+)
+
+// Header is the clear part of a key block, which describes the wrapped key.
+type Header struct {
+	// Version is the key block version, only VersionB and VersionD can be wrapped and unwrapped.
+	Version byte
+	// KeyUsage is the two characters usage of the key, for example "P0" PIN encryption or "K0" key encryption.
+	KeyUsage string
+	// Algorithm of the key, for example 'T' TDES or 'A' AES.
+	Algorithm byte
+	// ModeOfUse of the key, for example 'E' encrypt only or 'B' both encrypt and decrypt.
+	ModeOfUse byte
+	// KeyVersion is the two characters version number of the key, "00" if not used.
+	KeyVersion string
+	// Exportability of the key, for example 'E' exportable or 'N' non exportable.
+	Exportability byte
+	// OptionalBlocks in order, wrapping adds a padding block ("PB") if needed.
+	OptionalBlocks []OptionalBlock
+}
+
+// OptionalBlock is an optional header block identified by a two characters ID.
+type OptionalBlock struct {
+	ID   string
+	Data string
+}
+
+// ParseHeader reads the header of a key block, including its optional blocks.
+func ParseHeader(block string) (Header, error) {
+	h, _, _, err := parseHeader(block)
+	return h, err
+}
+
+// parseHeader reads the header of a key block and returns also its length and the indicated key block length.
+func parseHeader(block string) (Header, int, int, error) {
+	if len(block) < _headerLength {
+		return Header{}, 0, 0, fmt.Errorf("%w: header is %v characters long but should be at least %v",
+			ErrInvalidKeyBlock, len(block), _headerLength)
+	}
+
+	length, err := strconv.Atoi(block[1:5])
+	if err != nil {
+		return Header{}, 0, 0, fmt.Errorf("%w: key block length '%s' is not numeric", ErrInvalidKeyBlock, block[1:5])
+	}
+
+	count, err := strconv.Atoi(block[12:14])
+	if err != nil {
+		return Header{}, 0, 0, fmt.Errorf("%w: optional blocks amount '%s' is not numeric",
+			ErrInvalidKeyBlock, block[12:14])
+	}
+
+	h := Header{
+		Version:        block[0],
+		KeyUsage:       block[5:7],
+		Algorithm:      block[7],
+		ModeOfUse:      block[8],
+		KeyVersion:     block[9:11],
+		Exportability:  block[11],
+		OptionalBlocks: make([]OptionalBlock, 0, count),
+	}
+
+	offset := _headerLength
+	for n := 0; n < count; n++ {
+		if len(block)-offset < _optionalBlockHeaderLength {
+			return Header{}, 0, 0, fmt.Errorf("%w: optional block %v is truncated", ErrInvalidKeyBlock, n+1)
+		}
+
+		l, err := strconv.ParseUint(block[offset+2:offset+4], 16, 8)
+		if err != nil || l < _optionalBlockHeaderLength || len(block)-offset < int(l) {
+			return Header{}, 0, 0, fmt.Errorf("%w: optional block %s length '%s' is not valid",
+				ErrInvalidKeyBlock, block[offset:offset+2], block[offset+2:offset+4])
+		}
+
+		h.OptionalBlocks = append(h.OptionalBlocks, OptionalBlock{
+			ID:   block[offset : offset+2],
+			Data: block[offset+_optionalBlockHeaderLength : offset+int(l)],
+		})
+		offset += int(l)
+	}
+
+	return h, offset, length, nil
+}
+
+// Get returns the data of the indicated optional block, the returned bool indicates if it is present.
+func (h Header) Get(id string) (string, bool) {
+	for _, b := range h.OptionalBlocks {
+		if b.ID == id {
+			return b.Data, true
+		}
+	}
+
+	return "", false
+}
+
+// encode returns the header with the indicated key block length.
+func (h Header) encode(length int) (string, error) {
+	if len(h.KeyUsage) != 2 || len(h.KeyVersion) != 2 {
+		return "", fmt.Errorf("%w: key usage '%s' and key version '%s' should be 2 characters long",
+			ErrInvalidKeyBlock, h.KeyUsage, h.KeyVersion)
+	}
+
+	if length > 9999 || len(h.OptionalBlocks) > 99 {
+		return "", fmt.Errorf("%w: key block length %v or optional blocks amount %v exceeded",
+			ErrInvalidKeyBlock, length, len(h.OptionalBlocks))
+	}
+
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("%c%04d%s%c%c%s%c%02d00", h.Version, length, h.KeyUsage, h.Algorithm, h.ModeOfUse,
+		h.KeyVersion, h.Exportability, len(h.OptionalBlocks)))
+
+	for _, b := range h.OptionalBlocks {
+		l := _optionalBlockHeaderLength + len(b.Data)
+		if len(b.ID) != 2 || l > 0xFF {
+			return "", fmt.Errorf("%w: optional block '%s' should have a 2 characters ID and up to %v characters",
+				ErrInvalidKeyBlock, b.ID, 0xFF-_optionalBlockHeaderLength)
+		}
+
+		s.WriteString(fmt.Sprintf("%s%02X%s", b.ID, l, b.Data))
+	}
+
+	return s.String(), nil
+}
+
+// length returns the length of the encoded header.
+func (h Header) length() int {
+	l := _headerLength
+	for _, b := range h.OptionalBlocks {
+		l += _optionalBlockHeaderLength + len(b.Data)
+	}
+
+	return l
+}
+
+// padded returns h with a padding optional block if its length is not a multiple of blockSize.
+func (h Header) padded(blockSize int) Header {
+	if h.length()%blockSize == 0 {
+		return h
+	}
+
+	n := blockSize - h.length()%blockSize
+	if n < _optionalBlockHeaderLength {
+		n += blockSize
+	}
+
+	blocks := append(append(make([]OptionalBlock, 0, len(h.OptionalBlocks)+1), h.OptionalBlocks...),
+		OptionalBlock{ID: "PB", Data: strings.Repeat("0", n-_optionalBlockHeaderLength)})
+	h.OptionalBlocks = blocks
+
+	return h
+}
@@ -0,0 +1,263 @@
+package tr31
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jattento/go-iso8583/pkg/mac"
+)
+
+var (
+	// ErrInvalidKeyBlock is returned when a key block is malformed, exported error for asserting.
+	ErrInvalidKeyBlock = errors.New("invalid key block")
+	// ErrInvalidKey is returned when a key length is not allowed by the key block version,
+	// exported error for asserting.
+	ErrInvalidKey = errors.New("invalid key")
+	// ErrMACMismatch is returned when the MAC of a key block does not authenticate it, which happens
+	// if it was modified or the key block protection key is not the used one. Exported error for asserting.
+	ErrMACMismatch = errors.New("key block MAC mismatch")
+)
+
+// Rand is the source of the random padding of wrapped keys, it can be replaced to obtain reproducible key blocks.
+var Rand io.Reader = rand.Reader
+
+// version is the cryptography of a key block version.
+type version struct {
+	blockSize   int
+	keyLengths  []int
+	newCipher   func(key []byte) cipher.Block
+	newMAC      func(key []byte, size int) (func(data []byte) ([]byte, error), error)
+	algorithmID func(keyLength int) uint16
+}
+
+var _versions = map[byte]version{
+	VersionB: {
+		blockSize:  des.BlockSize,
+		keyLengths: []int{16, 24},
+		newCipher: func(key []byte) cipher.Block {
+			if len(key) == 16 {
+				// Double length keys are used as K1 K2 K1.
+				key = append(append(make([]byte, 0, 24), key...), key[:8]...)
+			}
+
+			// Key length is checked by callers.
+			block, _ := des.NewTripleDESCipher(key)
+			return block
+		},
+		newMAC: mac.TDESCMAC,
+		algorithmID: func(keyLength int) uint16 {
+			if keyLength == 16 {
+				return 0x0000
+			}
+			return 0x0001
+		},
+	},
+	VersionD: {
+		blockSize:  aes.BlockSize,
+		keyLengths: []int{16, 24, 32},
+		newCipher: func(key []byte) cipher.Block {
+			// Key length is checked by callers.
+			block, _ := aes.NewCipher(key)
+			return block
+		},
+		newMAC: mac.CMAC,
+		algorithmID: func(keyLength int) uint16 {
+			return uint16(0x0002 + (keyLength-16)/8)
+		},
+	},
+}
+
+// Wrap returns the key block of key with header h protected by kbpk, the key block protection key:
+// TDES (16 or 24 bytes) for VersionB and AES (16, 24 or 32 bytes) for VersionD.
+// Keys are padded with random bytes up to a multiple of the cipher block size.
+func Wrap(kbpk []byte, h Header, key []byte) (string, error) {
+	v, err := keyBlockVersion(h.Version, kbpk)
+	if err != nil {
+		return "", err
+	}
+
+	if len(key) == 0 || len(key) > 0xFFFF/8 {
+		return "", fmt.Errorf("%w: wrapped key is %v bytes long", ErrInvalidKey, len(key))
+	}
+
+	h = h.padded(v.blockSize)
+
+	// Key length in bits, key and random padding.
+	n := (2 + len(key) + v.blockSize - 1) / v.blockSize * v.blockSize
+	data := make([]byte, n)
+	binary.BigEndian.PutUint16(data, uint16(len(key)*8))
+	copy(data[2:], key)
+	if _, err := io.ReadFull(Rand, data[2+len(key):]); err != nil {
+		return "", fmt.Errorf("random padding: %w", err)
+	}
+
+	macLength := v.blockSize
+	header, err := h.encode(h.length() + 2*len(data) + 2*macLength)
+	if err != nil {
+		return "", err
+	}
+
+	encryptionKey, macKey, err := deriveKeys(v, kbpk)
+	if err != nil {
+		return "", err
+	}
+
+	code, err := v.newMAC(macKey, macLength)
+	if err != nil {
+		return "", err
+	}
+
+	tag, err := code(append([]byte(header), data...))
+	if err != nil {
+		return "", err
+	}
+
+	encrypted := make([]byte, len(data))
+	cipher.NewCBCEncrypter(v.newCipher(encryptionKey), tag).CryptBlocks(encrypted, data)
+
+	return header + strings.ToUpper(hex.EncodeToString(encrypted)+hex.EncodeToString(tag)), nil
+}
+
+// Unwrap returns the header and the key of a key block protected by kbpk, the key block protection key.
+// ErrMACMismatch is returned if the key block is not authenticated.
+func Unwrap(kbpk []byte, block string) (Header, []byte, error) {
+	h, headerLength, length, err := parseHeader(block)
+	if err != nil {
+		return Header{}, nil, err
+	}
+
+	v, err := keyBlockVersion(h.Version, kbpk)
+	if err != nil {
+		return Header{}, nil, err
+	}
+
+	if length != len(block) {
+		return Header{}, nil, fmt.Errorf("%w: key block is %v characters long but header indicates %v",
+			ErrInvalidKeyBlock, len(block), length)
+	}
+
+	b, err := hex.DecodeString(block[headerLength:])
+	if err != nil {
+		return Header{}, nil, fmt.Errorf("%w: encrypted key and MAC are not hexadecimal", ErrInvalidKeyBlock)
+	}
+
+	macLength := v.blockSize
+	if len(b) < macLength+v.blockSize || (len(b)-macLength)%v.blockSize != 0 {
+		return Header{}, nil, fmt.Errorf("%w: encrypted key length is not a multiple of %v bytes",
+			ErrInvalidKeyBlock, v.blockSize)
+	}
+
+	encrypted, tag := b[:len(b)-macLength], b[len(b)-macLength:]
+
+	encryptionKey, macKey, err := deriveKeys(v, kbpk)
+	if err != nil {
+		return Header{}, nil, err
+	}
+
+	data := make([]byte, len(encrypted))
+	cipher.NewCBCDecrypter(v.newCipher(encryptionKey), tag).CryptBlocks(data, encrypted)
+
+	code, err := v.newMAC(macKey, macLength)
+	if err != nil {
+		return Header{}, nil, err
+	}
+
+	expected, err := code(append([]byte(block[:headerLength]), data...))
+	if err != nil {
+		return Header{}, nil, err
+	}
+
+	if subtle.ConstantTimeCompare(expected, tag) != 1 {
+		return Header{}, nil, ErrMACMismatch
+	}
+
+	bits := int(binary.BigEndian.Uint16(data))
+	if bits%8 != 0 || bits/8 > len(data)-2 {
+		return Header{}, nil, fmt.Errorf("%w: key length %v bits is not valid", ErrInvalidKeyBlock, bits)
+	}
+
+	return h, data[2 : 2+bits/8], nil
+}
+
+// deriveKeys returns the key block encryption and MAC keys derived from kbpk as defined by TR-31:
+// the CMAC of the key usage, algorithm and length of the derived key, for each required block.
+func deriveKeys(v version, kbpk []byte) ([]byte, []byte, error) {
+	code, err := v.newMAC(kbpk, v.blockSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([][]byte, 0, 2)
+	for _, usage := range []uint16{0x0000, 0x0001} {
+		key := make([]byte, 0, len(kbpk)+v.blockSize)
+		for counter := byte(1); len(key) < len(kbpk); counter++ {
+			input := make([]byte, 8)
+			input[0] = counter
+			binary.BigEndian.PutUint16(input[1:], usage)
+			binary.BigEndian.PutUint16(input[4:], v.algorithmID(len(kbpk)))
+			binary.BigEndian.PutUint16(input[6:], uint16(len(kbpk)*8))
+
+			b, err := code(input)
+			if err != nil {
+				return nil, nil, err
+			}
+			key = append(key, b...)
+		}
+
+		keys = append(keys, key[:len(kbpk)])
+	}
+
+	return keys[0], keys[1], nil
+}
+
+func keyBlockVersion(id byte, kbpk []byte) (version, error) {
+	v, ok := _versions[id]
+	if !ok {
+		return version{}, fmt.Errorf("%w: version %c is not supported", ErrInvalidKeyBlock, id)
+	}
+
+	for _, l := range v.keyLengths {
+		if len(kbpk) == l {
+			return v, nil
+		}
+	}
+
+	return version{}, fmt.Errorf("%w: version %c key block protection key can not be %v bytes long",
+		ErrInvalidKey, id, len(kbpk))
+}
+
+// KeyBlock wraps keys under KBPK as key blocks with Header, it can be used as keyexchange.Wrapper.
+type KeyBlock struct {
+	KBPK   []byte
+	Header Header
+}
+
+// Format returns "02".
+func (k KeyBlock) Format() string { return "02" }
+
+// Wrap returns the key block of key.
+func (k KeyBlock) Wrap(key []byte) (string, error) { return Wrap(k.KBPK, k.Header, key) }
+
+// Unwrap returns the key of a key block, which version and key usage must be the ones of Header.
+func (k KeyBlock) Unwrap(block string) ([]byte, error) {
+	h, key, err := Unwrap(k.KBPK, block)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.Version != k.Header.Version || h.KeyUsage != k.Header.KeyUsage {
+		return nil, fmt.Errorf("%w: key block %c %s was received but %c %s is expected", ErrInvalidKeyBlock,
+			h.Version, h.KeyUsage, k.Header.Version, k.Header.KeyUsage)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,267 @@
+package tr31_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jattento/go-iso8583/pkg/iso8583"
+	"github.com/jattento/go-iso8583/pkg/keyexchange"
+	"github.com/jattento/go-iso8583/pkg/template"
+	"github.com/jattento/go-iso8583/pkg/tr31"
+)
+
+func encode(b []byte) string { return strings.ToUpper(hex.EncodeToString(b)) }
+
+func decode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// fixRand replaces the random source and returns a function that restores it.
+func fixRand(r io.Reader) func() {
+	original := tr31.Rand
+	tr31.Rand = r
+	return func() { tr31.Rand = original }
+}
+
+func TestWrap_version_d_vector(t *testing.T) {
+	defer fixRand(bytes.NewReader(decode("1C2965473CE206BB855B01533782")))()
+
+	kbpk := decode("88E1AB2A2E3DD38C1FA039A536500CC8A87AB9D62DC92C01058FA79F44657DE6")
+	h := tr31.Header{Version: tr31.VersionD, KeyUsage: "P0", Algorithm: 'A', ModeOfUse: 'E', KeyVersion: "00",
+		Exportability: 'E'}
+
+	block, err := tr31.Wrap(kbpk, h, decode("3F419E1CB7079442AA37474C2EFBF8B8"))
+	assert.Nil(t, err)
+	assert.Equal(t, "D0112P0AE00E0000B82679114F470F540165EDFBF7E250FCEA43F810D215F8D207E2E417C07156A27E8E31DA"+
+		"05F7425509593D03A457DC34", block)
+
+	unwrappedHeader, key, err := tr31.Unwrap(kbpk, block)
+	assert.Nil(t, err)
+	assert.Equal(t, "3F419E1CB7079442AA37474C2EFBF8B8", encode(key))
+	assert.Equal(t, h.KeyUsage, unwrappedHeader.KeyUsage)
+}
+
+// The version B blocks were computed independently of this package with the OpenSSL TDEA CMAC,
+// to derive the keys and authenticate, and TDEA CBC, to encrypt.
+func TestWrap_version_b_vector(t *testing.T) {
+	testList := []struct {
+		Name   string
+		KBPK   string
+		Output string
+	}{
+		{
+			Name: "double_length_kbpk",
+			KBPK: "89E88CF7931444F334BD7547FC3F380C",
+			Output: "B0080P0TE00E0000135F612927E176615ECEF882D6CEB13CFDE619259310E22F" +
+				"2739AE00FBFD7ABF",
+		},
+		{
+			Name: "triple_length_kbpk",
+			KBPK: "0123456789ABCDEFFEDCBA98765432100123456789ABCDEF",
+			Output: "B0080P0TE00E0000D98A0C15927CC48CEAB9E12FEF3C9D2B76BF2C6DE8A84DD1" +
+				"14D7AA472CEFF2F9",
+		},
+	}
+
+	h := tr31.Header{Version: tr31.VersionB, KeyUsage: "P0", Algorithm: 'T', ModeOfUse: 'E', KeyVersion: "00",
+		Exportability: 'E'}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			defer fixRand(bytes.NewReader(decode("720DF563893C")))()
+
+			block, err := tr31.Wrap(decode(testCase.KBPK), h, decode("F039121BEC83D26B169BDCD5B22AAF8F"))
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.Output, block)
+
+			unwrappedHeader, key, err := tr31.Unwrap(decode(testCase.KBPK), testCase.Output)
+			assert.Nil(t, err)
+			assert.Equal(t, "F039121BEC83D26B169BDCD5B22AAF8F", encode(key))
+			assert.Equal(t, h.KeyUsage, unwrappedHeader.KeyUsage)
+		})
+	}
+}
+
+func TestWrap_round_trip(t *testing.T) {
+	testList := []struct {
+		Name   string
+		KBPK   string
+		Header tr31.Header
+		Key    string
+		Length int
+	}{
+		{
+			Name: "version_b_double_length",
+			KBPK: "0123456789ABCDEFFEDCBA9876543210",
+			Header: tr31.Header{Version: tr31.VersionB, KeyUsage: "P0", Algorithm: 'T', ModeOfUse: 'E',
+				KeyVersion: "00", Exportability: 'N'},
+			Key:    "F039121BEC83D26B169BDCD5B22AAF8F",
+			Length: 16 + 48 + 16,
+		},
+		{
+			Name: "version_b_triple_length_optional_blocks",
+			KBPK: "0123456789ABCDEFFEDCBA98765432100123456789ABCDEF",
+			Header: tr31.Header{Version: tr31.VersionB, KeyUsage: "M3", Algorithm: 'T', ModeOfUse: 'C',
+				KeyVersion: "01", Exportability: 'E', OptionalBlocks: []tr31.OptionalBlock{{ID: "KS", Data: "00604B120F9292800000"}}},
+			Key:    "F039121BEC83D26B169BDCD5B22AAF8F0123456789ABCDEF",
+			Length: 16 + 24 + 64 + 16,
+		},
+		{
+			Name: "version_d_aes_256_key",
+			KBPK: "88E1AB2A2E3DD38C1FA039A536500CC8",
+			Header: tr31.Header{Version: tr31.VersionD, KeyUsage: "D0", Algorithm: 'A', ModeOfUse: 'B',
+				KeyVersion: "00", Exportability: 'S'},
+			Key:    "88E1AB2A2E3DD38C1FA039A536500CC8A87AB9D62DC92C01058FA79F44657DE6",
+			Length: 16 + 96 + 32,
+		},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			block, err := tr31.Wrap(decode(testCase.KBPK), testCase.Header, decode(testCase.Key))
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Len(t, block, testCase.Length)
+
+			h, key, err := tr31.Unwrap(decode(testCase.KBPK), block)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, testCase.Key, encode(key))
+			assert.Equal(t, testCase.Header.KeyUsage, h.KeyUsage)
+			assert.Equal(t, testCase.Header.Exportability, h.Exportability)
+
+			for _, b := range testCase.Header.OptionalBlocks {
+				data, ok := h.Get(b.ID)
+				assert.True(t, ok)
+				assert.Equal(t, b.Data, data)
+			}
+
+			// Any modification of the header or the key is detected.
+			tampered := []byte(block)
+			tampered[6] = '1'
+			_, _, err = tr31.Unwrap(decode(testCase.KBPK), string(tampered))
+			assert.True(t, errors.Is(err, tr31.ErrMACMismatch), err)
+		})
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	h, err := tr31.ParseHeader("B0104K0TB00E0200KS1800604B120F9292800000PB080000" + "00000000")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, tr31.Header{
+		Version: 'B', KeyUsage: "K0", Algorithm: 'T', ModeOfUse: 'B', KeyVersion: "00", Exportability: 'E',
+		OptionalBlocks: []tr31.OptionalBlock{{ID: "KS", Data: "00604B120F9292800000"}, {ID: "PB", Data: "0000"}},
+	}, h)
+}
+
+func TestUnwrap_errors(t *testing.T) {
+	kbpk := decode("0123456789ABCDEFFEDCBA9876543210")
+	h := tr31.Header{Version: tr31.VersionB, KeyUsage: "P0", Algorithm: 'T', ModeOfUse: 'E', KeyVersion: "00",
+		Exportability: 'N'}
+	block, _ := tr31.Wrap(kbpk, h, decode("F039121BEC83D26B169BDCD5B22AAF8F"))
+
+	testList := []struct {
+		Name        string
+		KBPK        string
+		Block       string
+		OutputError string
+	}{
+		{Name: "wrong_kbpk", KBPK: "FEDCBA98765432100123456789ABCDEF", Block: block,
+			OutputError: "key block MAC mismatch"},
+		{Name: "truncated", KBPK: "0123456789ABCDEFFEDCBA9876543210", Block: block[:len(block)-2],
+			OutputError: "invalid key block: key block is 78 characters long but header indicates 80"},
+		{Name: "unsupported_version", KBPK: "0123456789ABCDEFFEDCBA9876543210", Block: "A" + block[1:],
+			OutputError: "invalid key block: version A is not supported"},
+		{Name: "short_header", KBPK: "0123456789ABCDEFFEDCBA9876543210", Block: "B0080P0",
+			OutputError: "invalid key block: header is 7 characters long but should be at least 16"},
+		{Name: "invalid_kbpk", KBPK: "0123456789ABCDEF", Block: block,
+			OutputError: "invalid key: version B key block protection key can not be 8 bytes long"},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, _, err := tr31.Unwrap(decode(testCase.KBPK), testCase.Block)
+			assert.EqualError(t, err, testCase.OutputError)
+		})
+	}
+}
+
+func TestWrap_padding_block(t *testing.T) {
+	h := tr31.Header{Version: tr31.VersionD, KeyUsage: "B0", Algorithm: 'A', ModeOfUse: 'X', KeyVersion: "00",
+		Exportability: 'N', OptionalBlocks: []tr31.OptionalBlock{{ID: "KV", Data: "00"}}}
+
+	block, err := tr31.Wrap(decode("88E1AB2A2E3DD38C1FA039A536500CC8"), h, decode("3F419E1CB7079442AA37474C2EFBF8B8"))
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "D0128B0AX00N0200KV0600PB0A000000", block[:32])
+}
+
+func TestKeyBlock_key_exchange(t *testing.T) {
+	key := keyexchange.Key{Class: keyexchange.KeyClassPIN, Index: 1, Cycle: 1, Value: decode("F039121BEC83D26B169BDCD5B22AAF8F")}
+
+	testList := []struct {
+		Name    string
+		KBPK    string
+		Version byte
+	}{
+		{Name: "version_b", KBPK: "0123456789ABCDEFFEDCBA9876543210", Version: tr31.VersionB},
+		{Name: "version_d", KBPK: "88E1AB2A2E3DD38C1FA039A536500CC8A87AB9D62DC92C01058FA79F44657DE6",
+			Version: tr31.VersionD},
+	}
+
+	for _, testCase := range testList {
+		t.Run(testCase.Name, func(t *testing.T) {
+			w := tr31.KeyBlock{
+				KBPK: decode(testCase.KBPK),
+				Header: tr31.Header{Version: testCase.Version, KeyUsage: "P0", Algorithm: 'T', ModeOfUse: 'E',
+					KeyVersion: "00", Exportability: 'N'},
+			}
+
+			request, err := keyexchange.NewRequest(key, w, "000001", time.Now())
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+			assert.Equal(t, iso8583.VAR("0201010000000000"), request.SecurityRelatedControlInformation)
+
+			b, err := iso8583.Marshal(request)
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			var received template.MasterCardISO87
+			if _, err := iso8583.Unmarshal(b, &received); !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			receivedKey, err := keyexchange.ParseRequest(received, w)
+			assert.Nil(t, err)
+			assert.Equal(t, key, receivedKey)
+
+			other := w
+			other.Header.KeyUsage = "M3"
+			_, err = keyexchange.ParseRequest(received, other)
+			assert.EqualError(t, err, fmt.Sprintf("key can not be unwrapped: invalid key block: "+
+				"key block %c P0 was received but %c M3 is expected", testCase.Version, testCase.Version))
+		})
+	}
+}